@@ -0,0 +1,524 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BasePath - prefix semua route API (lihat routes.SetupRoutes)
+const BasePath = "/alumni-management-system"
+
+// Hak akses operasi, dipetakan ke security bearerAuth dan keterangan role
+const (
+	AccessPublic = ""
+	AccessUser   = "user" // admin dan user
+	AccessAdmin  = "admin"
+)
+
+// Operation - dokumentasi satu route. Path ditulis dengan gaya Fiber (":id") relatif terhadap BasePath.
+// Body dan Data berisi nilai contoh dari model (mis. models.Alumni{}) yang dibaca lewat reflection,
+// sehingga schema selalu mengikuti struct Go.
+type Operation struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Description string
+	Access      string
+	Query       []Param
+	PathParams  []Param                // path parameter yang bukan id integer (mis. :field)
+	Body        interface{}            // request body JSON
+	MergePatch  bool                   // Body dikirim sebagai JSON Merge Patch: semua field opsional
+	Upload      bool                   // body multipart/form-data dengan satu field file
+	Data        interface{}            // isi field "data" pada envelope {success, message, data}
+	Extra       map[string]interface{} // field tambahan di samping "data" (mis. as_of, company_match)
+	List        interface{}            // response lengkap tanpa envelope (mis. models.AlumniResponse)
+	CursorList  interface{}            // response alternatif untuk ?cursor= (oneOf dengan List)
+	Status      int                    // status sukses, default 200
+	ETag        bool                   // response membawa ETag dan mendukung If-None-Match (304)
+	IfMatch     bool                   // wajib header If-Match berisi ETag terakhir (412/428)
+	Idempotent  bool                   // menerima header Idempotency-Key (replay response pertama)
+	CSV         bool                   // bisa mengirim text/csv
+	File        []string               // response berupa isi file (bukan JSON) dengan content type ini
+	Redirect    bool                   // bisa merespons 302
+}
+
+// Param - satu query parameter (atau path parameter lewat Operation.PathParams)
+type Param struct {
+	Name        string
+	Type        string // string, integer, boolean
+	Format      string
+	Description string
+	Enum        []string
+	Default     interface{}
+	Required    bool
+}
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// Spec - dokumen OpenAPI 3 yang dibangun dari tabel operations dan model Go
+func Spec() map[string]interface{} {
+	builder := &schemaBuilder{schemas: map[string]interface{}{}}
+	builder.schemas["ErrorResponse"] = errorResponseSchema()
+
+	paths := map[string]map[string]interface{}{}
+	for _, op := range operations {
+		path := openAPIPath(op.Path)
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		paths[path][strings.ToLower(op.Method)] = builder.operation(op)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Alumni Management System API",
+			"version":     "2.0.0",
+			"description": "API tracer study alumni: data alumni, pekerjaan, perusahaan, survey dan statistik. Pesan response mengikuti header Accept-Language (id/en).",
+		},
+		"servers": []interface{}{map[string]interface{}{"url": BasePath}},
+		"tags":    tags(),
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": builder.schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	}
+}
+
+// Handler - GET /openapi.json
+func Handler(c *fiber.Ctx) error {
+	specOnce.Do(func() {
+		specJSON, specErr = json.Marshal(Spec())
+	})
+	if specErr != nil {
+		return specErr
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(specJSON)
+}
+
+// UI - GET /docs, Swagger UI yang membaca /openapi.json
+func UI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(uiHTML)
+}
+
+const uiHTML = `<!DOCTYPE html>
+<html lang="id">
+<head>
+<meta charset="utf-8">
+<title>Alumni Management System API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>
+window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui", persistAuthorization: true});
+</script>
+</body>
+</html>
+`
+
+// Verify - bandingkan route yang terdaftar di app dengan tabel operations.
+// Mengembalikan error jika ada route yang belum didokumentasikan atau dokumentasi untuk route yang tidak ada.
+func Verify(app *fiber.App) error {
+	registered := map[string]bool{}
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || !strings.HasPrefix(route.Path, BasePath) {
+			continue
+		}
+		registered[routeKey(route.Method, strings.TrimPrefix(route.Path, BasePath))] = true
+	}
+
+	documented := map[string]bool{}
+	for _, op := range operations {
+		documented[routeKey(op.Method, op.Path)] = true
+	}
+
+	var problems []string
+	for key := range registered {
+		if !documented[key] {
+			problems = append(problems, "belum ada di spec: "+key)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			problems = append(problems, "tidak ada di routes: "+key)
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("openapi spec tidak sinkron dengan routes:\n  %s", strings.Join(problems, "\n  "))
+}
+
+func routeKey(method, path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if path == "" {
+		path = "/"
+	}
+	return strings.ToUpper(method) + " " + path
+}
+
+var fiberParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// openAPIPath - "/alumni/:id/" -> "/alumni/{id}"
+func openAPIPath(path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return fiberParam.ReplaceAllString(path, "{$1}")
+}
+
+func tags() []interface{} {
+	var result []interface{}
+	seen := map[string]bool{}
+	for _, op := range operations {
+		if !seen[op.Tag] {
+			seen[op.Tag] = true
+			result = append(result, map[string]interface{}{"name": op.Tag})
+		}
+	}
+	return result
+}
+
+func errorResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"success", "code", "message"},
+		"properties": map[string]interface{}{
+			"success": map[string]interface{}{"type": "boolean", "example": false},
+			"code":    map[string]interface{}{"type": "string", "example": "NOT_FOUND"},
+			"message": map[string]interface{}{"type": "string"},
+			"errors": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"field":   map[string]interface{}{"type": "string"},
+						"rule":    map[string]interface{}{"type": "string"},
+						"message": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+			"error": map[string]interface{}{"type": "string", "description": "Detail error internal, hanya jika APP_DEBUG=true"},
+		},
+	}
+}
+
+// schemaBuilder - ubah tipe Go menjadi JSON schema; struct bernama disimpan di components.schemas
+type schemaBuilder struct {
+	schemas map[string]interface{}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+func (b *schemaBuilder) operation(op Operation) map[string]interface{} {
+	result := map[string]interface{}{
+		"tags":        []string{op.Tag},
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+	}
+
+	description := op.Description
+	switch op.Access {
+	case AccessAdmin:
+		description = strings.TrimSpace(description + "\n\nHanya admin.")
+	case AccessUser:
+		description = strings.TrimSpace(description + "\n\nAdmin dan user.")
+	}
+	if description != "" {
+		result["description"] = description
+	}
+	if op.Access != AccessPublic {
+		result["security"] = []interface{}{map[string]interface{}{"bearerAuth": []string{}}}
+	}
+
+	var parameters []interface{}
+	for _, name := range fiberParam.FindAllStringSubmatch(op.Path, -1) {
+		parameter := map[string]interface{}{
+			"name": name[1], "in": "path", "required": true,
+			"schema": map[string]interface{}{"type": "integer"},
+		}
+		for _, param := range op.PathParams {
+			if param.Name == name[1] {
+				parameter = param.openAPI()
+				parameter["in"], parameter["required"] = "path", true
+			}
+		}
+		parameters = append(parameters, parameter)
+	}
+	for _, param := range op.Query {
+		parameters = append(parameters, param.openAPI())
+	}
+	if op.ETag {
+		parameters = append(parameters, map[string]interface{}{
+			"name": fiber.HeaderIfNoneMatch, "in": "header", "schema": map[string]interface{}{"type": "string"},
+			"description": "ETag dari response sebelumnya; dibalas 304 tanpa body jika data belum berubah",
+		})
+	}
+	if op.IfMatch {
+		parameters = append(parameters, map[string]interface{}{
+			"name": fiber.HeaderIfMatch, "in": "header", "required": true, "schema": map[string]interface{}{"type": "string"},
+			"description": "ETag dari GET terakhir; ditolak 412 jika data sudah diubah sejak itu",
+		})
+	}
+	if op.Idempotent {
+		parameters = append(parameters, map[string]interface{}{
+			"name": "Idempotency-Key", "in": "header", "schema": map[string]interface{}{"type": "string", "maxLength": 255},
+			"description": "Key unik per percobaan submit. Request ulang dengan key dan body yang sama mendapat response pertama " +
+				"(header Idempotent-Replayed: true); key yang sama dengan body berbeda ditolak 409.",
+		})
+	}
+	if parameters != nil {
+		result["parameters"] = parameters
+	}
+
+	if op.Body != nil {
+		body := map[string]interface{}{fiber.MIMEApplicationJSON: map[string]interface{}{"schema": b.schema(reflect.TypeOf(op.Body))}}
+		if op.MergePatch {
+			// Field yang tidak dikirim tidak berubah, sehingga tidak ada field wajib
+			schema := b.structSchema(reflect.TypeOf(op.Body))
+			delete(schema, "required")
+			body = map[string]interface{}{
+				fiber.MIMEApplicationJSON:      map[string]interface{}{"schema": schema},
+				"application/merge-patch+json": map[string]interface{}{"schema": schema},
+			}
+		}
+		result["requestBody"] = map[string]interface{}{"required": true, "content": body}
+	}
+	if op.Upload {
+		result["requestBody"] = map[string]interface{}{"required": true, "content": map[string]interface{}{
+			fiber.MIMEMultipartForm: map[string]interface{}{"schema": map[string]interface{}{
+				"type":       "object",
+				"required":   []string{"file"},
+				"properties": map[string]interface{}{"file": map[string]interface{}{"type": "string", "format": "binary"}},
+			}},
+		}}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = fiber.StatusOK
+	}
+	content := map[string]interface{}{fiber.MIMEApplicationJSON: map[string]interface{}{"schema": b.successSchema(op)}}
+	if op.CSV {
+		content["text/csv"] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+	}
+	if op.File != nil {
+		content = map[string]interface{}{}
+		for _, contentType := range op.File {
+			content[contentType] = map[string]interface{}{"schema": map[string]interface{}{"type": "string", "format": "binary"}}
+		}
+	}
+	success := map[string]interface{}{"description": http.StatusText(status), "content": content}
+	if op.ETag || (op.IfMatch && op.Data != nil) {
+		success["headers"] = map[string]interface{}{
+			fiber.HeaderETag: map[string]interface{}{"description": "Versi data, kirim kembali lewat If-Match saat mengubah", "schema": map[string]interface{}{"type": "string"}},
+		}
+	}
+	responses := map[string]interface{}{
+		fmt.Sprint(status): success,
+		"default":          errorResponse("Error"),
+	}
+	if op.ETag {
+		responses["304"] = map[string]interface{}{"description": "Data belum berubah sejak ETag di If-None-Match"}
+	}
+	if op.IfMatch {
+		responses["412"] = errorResponse("If-Match tidak cocok, data sudah diubah pengguna lain")
+		responses["428"] = errorResponse("Header If-Match tidak dikirim")
+	}
+	if op.Idempotent {
+		responses["409"] = errorResponse("Idempotency-Key dipakai untuk request berbeda atau request pertama masih diproses")
+	}
+	if op.Redirect {
+		responses["302"] = map[string]interface{}{"description": "Redirect ke identity provider"}
+	}
+	if op.Access != AccessPublic {
+		responses["401"] = errorResponse("Token tidak ada atau tidak valid")
+		responses["403"] = errorResponse("Role tidak diizinkan")
+	}
+	if op.Body != nil || op.Upload {
+		responses["422"] = errorResponse("Validasi request gagal")
+	}
+	result["responses"] = responses
+	return result
+}
+
+func (b *schemaBuilder) successSchema(op Operation) map[string]interface{} {
+	if op.List != nil && op.CursorList != nil {
+		return map[string]interface{}{"oneOf": []interface{}{
+			b.schema(reflect.TypeOf(op.List)), b.schema(reflect.TypeOf(op.CursorList)),
+		}}
+	}
+	if op.List != nil {
+		return b.schema(reflect.TypeOf(op.List))
+	}
+	properties := map[string]interface{}{
+		"success": map[string]interface{}{"type": "boolean", "example": true},
+		"message": map[string]interface{}{"type": "string"},
+	}
+	if op.Data != nil {
+		properties["data"] = b.schema(reflect.TypeOf(op.Data))
+	}
+	for name, value := range op.Extra {
+		properties[name] = b.schema(reflect.TypeOf(value))
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func errorResponse(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			fiber.MIMEApplicationJSON: map[string]interface{}{"schema": map[string]interface{}{"$ref": "#/components/schemas/ErrorResponse"}},
+		},
+	}
+}
+
+// operationID - "GET /alumni/:id/history" -> "getAlumniIdHistory"
+func operationID(op Operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	for _, part := range strings.FieldsFunc(op.Path, func(r rune) bool { return r == '/' || r == ':' || r == '-' || r == '_' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func (p Param) openAPI() map[string]interface{} {
+	schema := map[string]interface{}{"type": p.Type}
+	if p.Type == "" {
+		schema["type"] = "string"
+	}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if p.Enum != nil {
+		schema["enum"] = p.Enum
+	}
+	if p.Default != nil {
+		schema["default"] = p.Default
+	}
+	result := map[string]interface{}{"name": p.Name, "in": "query", "schema": schema}
+	if p.Description != "" {
+		result["description"] = p.Description
+	}
+	if p.Required {
+		result["required"] = true
+	}
+	return result
+}
+
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var result map[string]interface{}
+	switch {
+	case t == timeType:
+		result = map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == rawMessageType:
+		result = map[string]interface{}{"description": "Nilai JSON bebas"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		if _, ok := b.schemas[t.Name()]; !ok {
+			b.schemas[t.Name()] = nil // tandai lebih dulu agar struct rekursif tidak diproses ulang
+			b.schemas[t.Name()] = b.structSchema(t)
+		}
+		result = map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+		if nullable {
+			return map[string]interface{}{"allOf": []interface{}{result}, "nullable": true}
+		}
+		return result
+	case t.Kind() == reflect.Struct:
+		result = b.structSchema(t)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		result = map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case t.Kind() == reflect.Map:
+		result = map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case t.Kind() == reflect.Bool:
+		result = map[string]interface{}{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		result = map[string]interface{}{"type": "integer"}
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			result["format"] = "int64"
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		result = map[string]interface{}{"type": "number"}
+	case t.Kind() == reflect.String:
+		result = map[string]interface{}{"type": "string"}
+	default:
+		result = map[string]interface{}{}
+	}
+	if nullable {
+		result["nullable"] = true
+	}
+	return result
+}
+
+// structSchema - properti dari tag json, required/enum/format dari tag validate
+func (b *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := b.schema(field.Type)
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			key, value, _ := strings.Cut(rule, "=")
+			if key == "dive" {
+				break // aturan setelah dive berlaku untuk elemen slice
+			}
+			switch key {
+			case "required":
+				required = append(required, name)
+			case "email":
+				property["format"] = "email"
+			case "oneof":
+				property["enum"] = strings.Fields(value)
+			}
+		}
+		properties[name] = property
+	}
+
+	result := map[string]interface{}{"type": "object", "properties": properties}
+	if required != nil {
+		result["required"] = required
+	}
+	return result
+}
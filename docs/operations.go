@@ -0,0 +1,333 @@
+package docs
+
+import (
+	"time"
+
+	"alumni-management-system/models"
+)
+
+// Query parameter yang dipakai bersama beberapa endpoint
+var (
+	pageParam   = Param{Name: "page", Type: "integer", Default: 1, Description: "Nomor halaman, mulai dari 1"}
+	limitParam  = Param{Name: "limit", Type: "integer", Default: 10, Description: "Jumlah data per halaman"}
+	orderParam  = Param{Name: "order", Enum: []string{"asc", "desc"}, Default: "asc"}
+	searchParam = Param{Name: "search", Description: "Pencarian teks (ILIKE)"}
+	asOfParam   = Param{Name: "as_of", Description: "Tampilkan data pada waktu tertentu (RFC3339 atau YYYY-MM-DD)"}
+	cursorParam = Param{Name: "cursor", Description: "Pagination keyset: kirim kosong (?cursor=) untuk halaman pertama, lalu meta.next/meta.prev. page diabaikan, sortBy/order harus sama, tidak mendukung relevance"}
+
+	// foto alumni dan dokumen pekerjaan
+	thumbnailParam = Param{Name: "thumbnail", Type: "boolean", Description: "true: kirim thumbnail JPEG (hanya untuk file gambar)"}
+
+	// alumni dan pekerjaan: full-text + trigram, hasil membawa score dan highlights
+	textSearchParam = Param{Name: "search", Description: "Pencarian full-text (mendukung \"frasa\", or, -kata) dan fuzzy (tahan salah ketik); hasil membawa score dan highlights (<mark>)"}
+	relevanceOrder  = Param{Name: "order", Enum: []string{"asc", "desc"}, Description: "Default asc; desc jika sortBy=relevance"}
+	sortDescription = "Alias: sort. relevance hanya berlaku jika search diisi"
+
+	alumniSortParam    = Param{Name: "sortBy", Default: "id", Description: sortDescription, Enum: []string{"relevance", "id", "nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "created_at"}}
+	pekerjaanSortParam = Param{Name: "sortBy", Default: "id", Description: sortDescription, Enum: []string{"relevance", "id", "alumni_id", "nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja", "gaji_min", "gaji_max", "tanggal_mulai_kerja", "status_pekerjaan", "created_at"}}
+	trashSortParam     = Param{Name: "sortBy", Default: "id", Description: sortDescription, Enum: []string{"relevance", "id", "alumni_id", "nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja", "gaji_min", "gaji_max", "tanggal_mulai_kerja", "status_pekerjaan", "created_at", "updated_at"}}
+	companySortParam   = Param{Name: "sortBy", Default: "nama", Enum: []string{"id", "nama", "jumlah_alumni", "created_at"}}
+
+	alumniCohortParams = []Param{
+		{Name: "jurusan", Description: "Satu atau beberapa jurusan (dipisah koma atau parameter berulang)"},
+		{Name: "angkatan", Type: "integer"}, {Name: "angkatan_from", Type: "integer"}, {Name: "angkatan_to", Type: "integer"},
+		{Name: "tahun_lulus", Type: "integer"}, {Name: "tahun_lulus_from", Type: "integer"}, {Name: "tahun_lulus_to", Type: "integer"},
+	}
+	alumniCreatedParams = []Param{
+		{Name: "created_from", Description: "RFC3339 atau YYYY-MM-DD"}, {Name: "created_to", Description: "RFC3339 atau YYYY-MM-DD"},
+	}
+	alumniFilterParams = params(alumniCohortParams, []Param{
+		{Name: "has_pekerjaan", Type: "boolean", Description: "Punya pekerjaan (status apa pun) yang belum dihapus"},
+		{Name: "currently_employed", Type: "boolean", Description: "Punya pekerjaan berstatus aktif"},
+		{Name: "bidang_industri", Description: "Bidang industri pekerjaan aktif, bisa lebih dari satu"},
+	}, alumniCreatedParams)
+
+	gajiParams = []Param{
+		{Name: "gaji_min", Type: "integer", Format: "int64", Description: "Pekerjaan dengan rentang gaji yang beririsan dengan [gaji_min, gaji_max]"},
+		{Name: "gaji_max", Type: "integer", Format: "int64"},
+		{Name: "gaji_currency", Description: "Kode mata uang 3 huruf, default IDR jika gaji_min/gaji_max diisi"},
+	}
+
+	pekerjaanFilterParams = []Param{
+		{Name: "status_pekerjaan", Description: "aktif, selesai, resigned; bisa lebih dari satu (dipisah koma atau parameter berulang)"},
+		{Name: "bidang_industri", Description: "Bisa lebih dari satu"},
+		{Name: "lokasi_kerja", Description: "Bisa lebih dari satu"},
+		{Name: "tanggal_mulai_from", Description: "RFC3339 atau YYYY-MM-DD"}, {Name: "tanggal_mulai_to", Description: "RFC3339 atau YYYY-MM-DD"},
+		{Name: "tanggal_selesai_from", Description: "RFC3339 atau YYYY-MM-DD"}, {Name: "tanggal_selesai_to", Description: "RFC3339 atau YYYY-MM-DD"},
+		{Name: "jurusan", Description: "Jurusan alumni, bisa lebih dari satu"},
+		{Name: "angkatan", Type: "integer"}, {Name: "angkatan_from", Type: "integer"}, {Name: "angkatan_to", Type: "integer"},
+		{Name: "employed_at", Description: "Pekerjaan yang sedang berjalan pada tanggal tersebut (RFC3339 atau YYYY-MM-DD)"},
+	}
+
+	statsFilterParams = []Param{
+		{Name: "jurusan"},
+		{Name: "angkatan", Type: "integer"},
+		{Name: "tahun_lulus", Type: "integer"},
+		{Name: "tahun_lulus_from", Type: "integer"},
+		{Name: "tahun_lulus_to", Type: "integer"},
+		{Name: "format", Enum: []string{"json", "csv"}, Default: "json"},
+	}
+	statsGroupParam = Param{Name: "group_by", Enum: []string{"angkatan", "jurusan", "tahun_lulus"}, Description: "Kosong = semua alumni"}
+	onlyActiveParam = Param{Name: "only_active", Type: "boolean", Default: false, Description: "Hanya pekerjaan berstatus aktif"}
+)
+
+func params(groups ...interface{}) []Param {
+	var result []Param
+	for _, group := range groups {
+		switch value := group.(type) {
+		case Param:
+			result = append(result, value)
+		case []Param:
+			result = append(result, value...)
+		}
+	}
+	return result
+}
+
+// operations - semua route di routes.SetupRoutes. Setiap route baru wajib ditambahkan di sini;
+// docs.Verify menolak start server jika tabel ini dan route yang terdaftar tidak sama.
+var operations = []Operation{
+	{Method: "GET", Path: "/health", Tag: "System", Summary: "Health check",
+		Extra: map[string]interface{}{"version": "", "features": []string{}}},
+
+	// Auth
+	{Method: "POST", Path: "/auth/login", Tag: "Auth", Summary: "Login dengan username dan password",
+		Body: models.LoginRequest{}, Data: models.LoginResponse{}},
+	{Method: "POST", Path: "/auth/register", Tag: "Auth", Summary: "Registrasi user baru",
+		Body: models.RegisterRequest{}, Data: models.User{}, Status: 201},
+	{Method: "GET", Path: "/auth/oidc/login", Tag: "Auth", Summary: "Mulai login SSO (OpenID Connect)",
+		Description: "Redirect ke identity provider, atau kembalikan URL login jika mode=json.",
+		Query:       []Param{{Name: "mode", Enum: []string{"json"}}},
+		Data:        models.OIDCLoginResponse{}, Redirect: true},
+	{Method: "GET", Path: "/auth/oidc/callback", Tag: "Auth", Summary: "Callback login SSO",
+		Query: []Param{{Name: "state", Required: true}, {Name: "code"}, {Name: "error"}, {Name: "error_description"}},
+		Data:  models.LoginResponse{}},
+	{Method: "GET", Path: "/auth/profile", Tag: "Auth", Summary: "Profil user yang sedang login", Access: AccessUser,
+		Data: models.ProfileResponse{}},
+	{Method: "POST", Path: "/auth/logout", Tag: "Auth", Summary: "Logout (hapus token di client)", Access: AccessUser},
+	{Method: "GET", Path: "/auth/validate", Tag: "Auth", Summary: "Validasi token", Access: AccessUser,
+		Data: models.JWTClaims{}},
+
+	// Alumni
+	{Method: "GET", Path: "/alumni", Tag: "Alumni", Summary: "Daftar alumni", Access: AccessUser,
+		Description: "Filter terstruktur bisa digabung dengan search dan ditampilkan kembali di meta.filters.",
+		Query:       params(pageParam, limitParam, cursorParam, alumniSortParam, relevanceOrder, textSearchParam, alumniFilterParams),
+		List:        models.AlumniResponse{}, CursorList: models.AlumniCursorResponse{}},
+	{Method: "GET", Path: "/alumni/without-jobs", Tag: "Alumni", Summary: "Alumni yang belum memiliki pekerjaan", Access: AccessUser,
+		Description: "Kontrak pagination/search/sort sama dengan GET /alumni. Alumni dan pekerjaan yang di-soft delete tidak dihitung; setiap alumni membawa years_since_graduation.",
+		Query: params(pageParam, limitParam, cursorParam,
+			Param{Name: "sortBy", Default: "id", Description: sortDescription, Enum: []string{"relevance", "id", "nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "created_at", models.SortYearsSinceGraduation}},
+			relevanceOrder, textSearchParam,
+			Param{Name: "status", Enum: []string{models.WithoutJobsNeverEmployed, models.WithoutJobsNoActiveJob}, Default: models.WithoutJobsNeverEmployed,
+				Description: "never_employed: belum pernah punya pekerjaan; no_active_job: tidak punya pekerjaan aktif saat ini"},
+			alumniCohortParams, alumniCreatedParams,
+		),
+		List: models.AlumniResponse{}, CursorList: models.AlumniCursorResponse{}},
+	{Method: "GET", Path: "/alumni/duplicates", Tag: "Alumni", Summary: "Kandidat alumni duplikat", Access: AccessAdmin,
+		Description: "Pasangan alumni dengan nama mirip (trigram), email sama, atau nomor telepon sama, diurutkan dari score tertinggi. " +
+			"Angkatan dan jurusan yang sama menambah score.",
+		Query: params(pageParam, limitParam,
+			Param{Name: "min_similarity", Type: "number", Default: 0.6, Description: "Kemiripan nama minimum, 0.3 sampai 1"},
+			Param{Name: "alumni_id", Type: "integer", Description: "Hanya pasangan yang melibatkan alumni ini"},
+		),
+		List: models.DuplicateCandidateResponse{}},
+	{Method: "GET", Path: "/alumni/:id/history", Tag: "Alumni", Summary: "Riwayat versi alumni", Access: AccessUser,
+		Data: models.RecordHistoryResponse{}},
+	{Method: "GET", Path: "/alumni/:id/career", Tag: "Alumni", Summary: "Timeline karier alumni", Access: AccessUser,
+		Data: models.CareerTimeline{}},
+	{Method: "GET", Path: "/alumni/:id/photo", Tag: "Alumni", Summary: "Foto profil alumni", Access: AccessUser,
+		Query: []Param{thumbnailParam}, File: []string{"image/jpeg", "image/png"}},
+	{Method: "GET", Path: "/alumni/:id", Tag: "Alumni", Summary: "Detail alumni", Access: AccessUser,
+		Description: "ETag tidak dikirim untuk as_of.",
+		Query:       []Param{asOfParam}, Data: models.Alumni{}, Extra: map[string]interface{}{"as_of": time.Time{}}, ETag: true},
+	{Method: "POST", Path: "/alumni", Tag: "Alumni", Summary: "Tambah alumni", Access: AccessAdmin,
+		Body: models.CreateAlumniRequest{}, Data: models.Alumni{}, Status: 201, Idempotent: true},
+	{Method: "PUT", Path: "/alumni/:id", Tag: "Alumni", Summary: "Ubah alumni", Access: AccessAdmin,
+		Body: models.UpdateAlumniRequest{}, Data: models.Alumni{}, IfMatch: true},
+	{Method: "PATCH", Path: "/alumni/:id", Tag: "Alumni", Summary: "Ubah sebagian data alumni", Access: AccessAdmin,
+		Description: "JSON Merge Patch (RFC 7386): hanya field yang dikirim yang berubah, null mengosongkan no_telepon/alamat.",
+		Body:        models.UpdateAlumniRequest{}, MergePatch: true, Data: models.Alumni{}, IfMatch: true},
+	{Method: "DELETE", Path: "/alumni/:id", Tag: "Alumni", Summary: "Hapus alumni", Access: AccessAdmin, IfMatch: true},
+	{Method: "POST", Path: "/alumni/:id/history/:version/revert", Tag: "Alumni", Summary: "Kembalikan alumni ke versi tertentu", Access: AccessAdmin,
+		Data: models.Alumni{}, IfMatch: true},
+	{Method: "POST", Path: "/alumni/:id/merge", Tag: "Alumni", Summary: "Gabungkan alumni duplikat ke alumni ini", Access: AccessAdmin,
+		Description: "Pekerjaan, respons survey, dan akun user source_id dipindah ke alumni :id lalu source dihapus permanen. " +
+			"Ditolak 409 jika keduanya punya pekerjaan aktif atau terhubung ke akun user berbeda. " +
+			"If-Match harus memuat ETag alumni :id dan source_id, dipisah koma.",
+		Body: models.MergeAlumniRequest{}, Data: models.Alumni{}, IfMatch: true,
+		Extra: map[string]interface{}{"merged_id": 0, "pekerjaan_moved": int64(0), "survey_responses_moved": int64(0), "user_moved": false}},
+	{Method: "PUT", Path: "/alumni/:id/photo", Tag: "Alumni", Summary: "Upload atau ganti foto profil alumni", Access: AccessUser,
+		Description: "JPEG atau PNG, maksimal UPLOAD_MAX_PHOTO_MB (default 2 MB). Thumbnail dibuat otomatis. " +
+			"Role user hanya untuk alumni yang terhubung ke akunnya.",
+		Upload: true, Data: models.Attachment{}},
+	{Method: "DELETE", Path: "/alumni/:id/photo", Tag: "Alumni", Summary: "Hapus foto profil alumni", Access: AccessUser,
+		Description: "Role user hanya untuk alumni yang terhubung ke akunnya."},
+
+	// Pekerjaan
+	{Method: "GET", Path: "/pekerjaan", Tag: "Pekerjaan", Summary: "Daftar pekerjaan alumni", Access: AccessUser,
+		Description: "Filter terstruktur bisa digabung dengan search dan ditampilkan kembali di meta.filters.",
+		Query:       params(pageParam, limitParam, cursorParam, pekerjaanSortParam, relevanceOrder, textSearchParam, gajiParams, pekerjaanFilterParams),
+		List:        models.PekerjaanResponse{}, CursorList: models.PekerjaanCursorResponse{}},
+	{Method: "GET", Path: "/pekerjaan/trash", Tag: "Pekerjaan", Summary: "Daftar pekerjaan di trash", Access: AccessAdmin,
+		Description: "Mendukung filter yang sama dengan GET /pekerjaan.",
+		Query:       params(pageParam, limitParam, cursorParam, trashSortParam, relevanceOrder, textSearchParam, gajiParams, pekerjaanFilterParams),
+		List:        models.PekerjaanResponse{}, CursorList: models.PekerjaanCursorResponse{}},
+	{Method: "GET", Path: "/pekerjaan/alumni/:alumni_id", Tag: "Pekerjaan", Summary: "Pekerjaan milik satu alumni", Access: AccessAdmin,
+		Data: []models.PekerjaanAlumni{}},
+	{Method: "GET", Path: "/pekerjaan/trash/:id", Tag: "Pekerjaan", Summary: "Detail pekerjaan di trash", Access: AccessAdmin,
+		Description: "ETag dipakai sebagai If-Match untuk hapus permanen dan restore.",
+		Data:        models.PekerjaanAlumni{}, ETag: true},
+	{Method: "DELETE", Path: "/pekerjaan/trash/:id", Tag: "Pekerjaan", Summary: "Hapus permanen pekerjaan dari trash", Access: AccessAdmin,
+		IfMatch: true},
+	{Method: "PUT", Path: "/pekerjaan/trash/restore/:id", Tag: "Pekerjaan", Summary: "Restore pekerjaan dari trash", Access: AccessAdmin,
+		Data: models.PekerjaanAlumni{}, IfMatch: true},
+	{Method: "GET", Path: "/pekerjaan/:id/history", Tag: "Pekerjaan", Summary: "Riwayat versi pekerjaan", Access: AccessUser,
+		Data: models.RecordHistoryResponse{}},
+	{Method: "GET", Path: "/pekerjaan/:id", Tag: "Pekerjaan", Summary: "Detail pekerjaan", Access: AccessUser,
+		Description: "ETag tidak dikirim untuk as_of.",
+		Query:       []Param{asOfParam}, Data: models.PekerjaanAlumni{}, Extra: map[string]interface{}{"as_of": time.Time{}}, ETag: true},
+	{Method: "POST", Path: "/pekerjaan", Tag: "Pekerjaan", Summary: "Tambah pekerjaan", Access: AccessAdmin,
+		Body: models.CreatePekerjaanRequest{}, Data: models.PekerjaanAlumni{}, Status: 201, Idempotent: true,
+		Extra: map[string]interface{}{"company_match": &models.CompanyMatch{}}},
+	{Method: "PUT", Path: "/pekerjaan/:id", Tag: "Pekerjaan", Summary: "Ubah pekerjaan", Access: AccessAdmin,
+		Body: models.UpdatePekerjaanRequest{}, Data: models.PekerjaanAlumni{}, IfMatch: true,
+		Extra: map[string]interface{}{"company_match": &models.CompanyMatch{}}},
+	{Method: "PATCH", Path: "/pekerjaan/:id", Tag: "Pekerjaan", Summary: "Ubah sebagian data pekerjaan", Access: AccessAdmin,
+		Description: "JSON Merge Patch (RFC 7386): hanya field yang dikirim yang berubah, null mengosongkan field opsional. " +
+			"Aturan tanggal, transisi status, dan gaji diperiksa terhadap hasil gabungan; company_match diisi jika nama_perusahaan atau company_id dikirim.",
+		Body: models.UpdatePekerjaanRequest{}, MergePatch: true, Data: models.PekerjaanAlumni{}, IfMatch: true,
+		Extra: map[string]interface{}{"company_match": &models.CompanyMatch{}}},
+	{Method: "DELETE", Path: "/pekerjaan/:id", Tag: "Pekerjaan", Summary: "Hapus pekerjaan", Access: AccessAdmin, IfMatch: true},
+	{Method: "POST", Path: "/pekerjaan/:id/history/:version/revert", Tag: "Pekerjaan", Summary: "Kembalikan pekerjaan ke versi tertentu", Access: AccessAdmin,
+		Data: models.PekerjaanAlumni{}, IfMatch: true},
+	{Method: "POST", Path: "/pekerjaan/:id/end", Tag: "Pekerjaan", Summary: "Akhiri pekerjaan aktif", Access: AccessAdmin,
+		Description: "Body opsional; default status selesai dengan tanggal selesai hari ini.",
+		Body:        models.EndPekerjaanRequest{}, Data: models.PekerjaanAlumni{}},
+	{Method: "DELETE", Path: "/pekerjaan/soft-delete/:id", Tag: "Pekerjaan", Summary: "Pindahkan pekerjaan ke trash", Access: AccessUser,
+		Description: "User hanya bisa menghapus pekerjaan miliknya sendiri.", IfMatch: true},
+	{Method: "GET", Path: "/pekerjaan/:id/attachments", Tag: "Pekerjaan", Summary: "Daftar dokumen pekerjaan", Access: AccessUser,
+		Description: "Role user hanya untuk pekerjaan miliknya sendiri.", Data: []models.Attachment{}},
+	{Method: "GET", Path: "/pekerjaan/:id/attachments/:attachment_id", Tag: "Pekerjaan", Summary: "Unduh dokumen pekerjaan", Access: AccessUser,
+		Description: "Role user hanya untuk pekerjaan miliknya sendiri.",
+		Query:       []Param{thumbnailParam}, File: []string{"application/pdf", "image/jpeg", "image/png"}},
+	{Method: "POST", Path: "/pekerjaan/:id/attachments", Tag: "Pekerjaan", Summary: "Upload dokumen pekerjaan", Access: AccessUser,
+		Description: "PDF, JPEG, atau PNG (mis. surat keterangan kerja), maksimal UPLOAD_MAX_DOCUMENT_MB (default 10 MB). " +
+			"Role user hanya untuk pekerjaan miliknya sendiri.",
+		Upload: true, Data: models.Attachment{}, Status: 201},
+	{Method: "DELETE", Path: "/pekerjaan/:id/attachments/:attachment_id", Tag: "Pekerjaan", Summary: "Hapus dokumen pekerjaan", Access: AccessUser,
+		Description: "Role user hanya untuk pekerjaan miliknya sendiri."},
+
+	// Audit
+	{Method: "GET", Path: "/audit", Tag: "Audit", Summary: "Log audit", Access: AccessAdmin,
+		Query: []Param{
+			pageParam, {Name: "limit", Type: "integer", Default: 20},
+			{Name: "order", Enum: []string{"asc", "desc"}, Default: "desc"},
+			{Name: "actor_user_id", Type: "integer"}, {Name: "actor_role"}, {Name: "action"}, {Name: "entity"},
+			{Name: "entity_id", Type: "integer"}, {Name: "from", Description: "RFC3339 atau YYYY-MM-DD"}, {Name: "to", Description: "RFC3339 atau YYYY-MM-DD"},
+		},
+		List: models.AuditLogResponse{}},
+
+	// Survey
+	{Method: "GET", Path: "/surveys/available", Tag: "Survey", Summary: "Survey yang sedang dibuka untuk alumni yang login", Access: AccessUser,
+		Data: []models.Survey{}},
+	{Method: "GET", Path: "/surveys/:id/response", Tag: "Survey", Summary: "Jawaban survey milik alumni yang login", Access: AccessUser,
+		Data: models.SurveyResponse{}},
+	{Method: "PUT", Path: "/surveys/:id/response", Tag: "Survey", Summary: "Simpan atau kirim jawaban survey", Access: AccessUser,
+		Body: models.SubmitSurveyResponseRequest{}, Data: models.SurveyResponse{}},
+	{Method: "GET", Path: "/surveys/:id", Tag: "Survey", Summary: "Detail survey beserta pertanyaan", Access: AccessUser,
+		Data: models.Survey{}},
+	{Method: "GET", Path: "/surveys", Tag: "Survey", Summary: "Daftar survey", Access: AccessAdmin,
+		Query: []Param{{Name: "status", Enum: []string{models.SurveyStatusDraft, models.SurveyStatusOpen, models.SurveyStatusClosed}}},
+		Data:  []models.Survey{}},
+	{Method: "POST", Path: "/surveys", Tag: "Survey", Summary: "Buat survey", Access: AccessAdmin,
+		Body: models.CreateSurveyRequest{}, Data: models.Survey{}, Status: 201},
+	{Method: "PUT", Path: "/surveys/:id", Tag: "Survey", Summary: "Ubah survey draft", Access: AccessAdmin,
+		Body: models.UpdateSurveyRequest{}, Data: models.Survey{}},
+	{Method: "DELETE", Path: "/surveys/:id", Tag: "Survey", Summary: "Hapus survey draft", Access: AccessAdmin},
+	{Method: "POST", Path: "/surveys/:id/open", Tag: "Survey", Summary: "Buka survey", Access: AccessAdmin,
+		Data: models.Survey{}},
+	{Method: "POST", Path: "/surveys/:id/close", Tag: "Survey", Summary: "Tutup survey", Access: AccessAdmin,
+		Data: models.Survey{}},
+	{Method: "GET", Path: "/surveys/:id/completion", Tag: "Survey", Summary: "Progres pengisian survey", Access: AccessAdmin,
+		Data: models.SurveyCompletion{}},
+	{Method: "GET", Path: "/surveys/:id/export", Tag: "Survey", Summary: "Export jawaban survey (CSV atau JSON)", Access: AccessAdmin,
+		Query: []Param{
+			{Name: "status", Enum: []string{models.SurveyResponseSubmitted, models.SurveyResponseInProgress}},
+			{Name: "format", Enum: []string{"csv", "json"}, Default: "csv"},
+		},
+		Data: struct {
+			Survey    models.Survey           `json:"survey"`
+			Responses []models.SurveyResponse `json:"responses"`
+		}{},
+		CSV: true},
+
+	// Statistik
+	{Method: "GET", Path: "/stats/employment-rate", Tag: "Statistik", Summary: "Tingkat keterserapan kerja", Access: AccessUser,
+		Query: params(statsGroupParam, statsFilterParams), Data: []models.EmploymentRateStat{}, CSV: true},
+	{Method: "GET", Path: "/stats/time-to-first-job", Tag: "Statistik", Summary: "Waktu tunggu kerja pertama", Access: AccessUser,
+		Query: params(statsGroupParam, statsFilterParams), Data: []models.TimeToFirstJobStat{}, CSV: true},
+	{Method: "GET", Path: "/stats/distribution", Tag: "Statistik", Summary: "Sebaran pekerjaan", Access: AccessUser,
+		Query: params(Param{Name: "by", Enum: []string{"bidang_industri", "lokasi_kerja"}, Default: "bidang_industri"}, onlyActiveParam, statsFilterParams),
+		Data:  []models.DistributionStat{}, CSV: true},
+	{Method: "GET", Path: "/stats/active", Tag: "Statistik", Summary: "Porsi alumni yang sedang bekerja", Access: AccessUser,
+		Query: statsFilterParams, Data: models.ActiveShareStat{}, CSV: true},
+	{Method: "GET", Path: "/stats/salary", Tag: "Statistik", Summary: "Sebaran gaji", Access: AccessUser,
+		Query: params(
+			Param{Name: "group_by", Enum: []string{"angkatan", "jurusan", "tahun_lulus", "bidang_industri"}, Description: "Kosong = semua alumni"},
+			Param{Name: "currency", Default: "IDR"}, onlyActiveParam, statsFilterParams,
+		),
+		Data: []models.SalaryStat{}, CSV: true},
+
+	// Perusahaan
+	{Method: "GET", Path: "/companies", Tag: "Perusahaan", Summary: "Daftar perusahaan", Access: AccessUser,
+		Query: params(pageParam, limitParam, companySortParam, orderParam, searchParam), List: models.CompanyResponse{}},
+	{Method: "GET", Path: "/companies/match", Tag: "Perusahaan", Summary: "Cocokkan nama ke master perusahaan", Access: AccessUser,
+		Query: []Param{{Name: "name", Required: true}},
+		Data:  []models.CompanyMatch{}, Extra: map[string]interface{}{"threshold": float64(0)}},
+	{Method: "GET", Path: "/companies/:id/alumni", Tag: "Perusahaan", Summary: "Alumni yang bekerja di perusahaan", Access: AccessUser,
+		Query: []Param{pageParam, limitParam, {Name: "status", Enum: []string{models.StatusPekerjaanAktif, models.StatusPekerjaanSelesai, models.StatusPekerjaanResigned}}},
+		Data:  []models.PekerjaanAlumni{}, Extra: map[string]interface{}{"company": models.Company{}, "meta": models.MetaInfo{}}},
+	{Method: "GET", Path: "/companies/:id", Tag: "Perusahaan", Summary: "Detail perusahaan", Access: AccessUser,
+		Data: models.Company{}},
+	{Method: "POST", Path: "/companies", Tag: "Perusahaan", Summary: "Tambah perusahaan", Access: AccessAdmin,
+		Body: models.CreateCompanyRequest{}, Data: models.Company{}, Status: 201},
+	{Method: "PUT", Path: "/companies/:id", Tag: "Perusahaan", Summary: "Ubah perusahaan", Access: AccessAdmin,
+		Body: models.UpdateCompanyRequest{}, Data: models.Company{}},
+	{Method: "DELETE", Path: "/companies/:id", Tag: "Perusahaan", Summary: "Hapus perusahaan", Access: AccessAdmin},
+	{Method: "POST", Path: "/companies/:id/aliases", Tag: "Perusahaan", Summary: "Tambah alias perusahaan", Access: AccessAdmin,
+		Body: models.AddCompanyAliasRequest{}, Data: models.Company{}, Status: 201},
+	{Method: "DELETE", Path: "/companies/:id/aliases/:alias_id", Tag: "Perusahaan", Summary: "Hapus alias perusahaan", Access: AccessAdmin,
+		Data: models.Company{}},
+	{Method: "POST", Path: "/companies/:id/merge", Tag: "Perusahaan", Summary: "Gabungkan perusahaan duplikat ke perusahaan ini", Access: AccessAdmin,
+		Body: models.MergeCompaniesRequest{}, Data: models.Company{},
+		Extra: map[string]interface{}{"merged_ids": []int{}, "pekerjaan_moved": int64(0)}},
+
+	// Autocomplete
+	{Method: "GET", Path: "/suggestions/:field", Tag: "Autocomplete", Summary: "Saran nilai yang sudah dipakai (prefix)", Access: AccessUser,
+		Description: "Nilai berbeda yang diawali q (atau punya kata yang diawali q), diurutkan dari yang paling sering dipakai.",
+		PathParams:  []Param{{Name: "field", Enum: models.SuggestionFields}},
+		Query:       []Param{{Name: "q", Description: "Prefix; kosong = nilai paling sering"}, {Name: "limit", Type: "integer", Default: 10, Description: "Maksimal 50"}},
+		Data:        []models.Suggestion{}},
+
+	// Webhook
+	{Method: "GET", Path: "/webhooks", Tag: "Webhook", Summary: "Daftar webhook subscription", Access: AccessAdmin,
+		Data: []models.WebhookSubscription{}},
+	{Method: "POST", Path: "/webhooks", Tag: "Webhook", Summary: "Tambah webhook subscription", Access: AccessAdmin,
+		Description: "Setiap event dikirim sebagai POST JSON {id, event, created_at, data} dengan header X-Webhook-Event, X-Webhook-Id (id event, sama untuk pengiriman ulang), " +
+			"X-Webhook-Delivery, X-Webhook-Timestamp, dan X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, timestamp + \".\" + body)). " +
+			"Response 2xx dianggap sukses; selain itu dicoba ulang dengan exponential backoff (30 detik, 1 menit, 2 menit, ... maksimal 6 jam) sampai WEBHOOK_MAX_ATTEMPTS. " +
+			"secret hanya ditampilkan di response ini.",
+		Body: models.CreateWebhookRequest{}, Data: models.WebhookSubscription{}, Status: 201},
+	{Method: "GET", Path: "/webhooks/:id", Tag: "Webhook", Summary: "Detail webhook subscription", Access: AccessAdmin,
+		Data: models.WebhookSubscription{}},
+	{Method: "PUT", Path: "/webhooks/:id", Tag: "Webhook", Summary: "Ubah webhook subscription", Access: AccessAdmin,
+		Description: "Delivery yang masih pending dikirim ke URL terbaru; jika subscription dinonaktifkan, delivery pending ditandai failed.",
+		Body:        models.UpdateWebhookRequest{}, Data: models.WebhookSubscription{}},
+	{Method: "DELETE", Path: "/webhooks/:id", Tag: "Webhook", Summary: "Hapus webhook subscription beserta log delivery", Access: AccessAdmin},
+	{Method: "GET", Path: "/webhooks/:id/deliveries", Tag: "Webhook", Summary: "Log delivery webhook", Access: AccessAdmin,
+		Query: []Param{pageParam, limitParam, {Name: "status", Enum: []string{models.WebhookDeliveryPending, models.WebhookDeliverySuccess, models.WebhookDeliveryFailed}}},
+		List:  models.WebhookDeliveryResponse{}},
+	{Method: "POST", Path: "/webhooks/:id/deliveries/:delivery_id/redeliver", Tag: "Webhook", Summary: "Kirim ulang delivery webhook", Access: AccessAdmin,
+		Description: "Membuat delivery baru dengan payload dan id event yang sama (redelivery_of menunjuk delivery asal).",
+		Data:        models.WebhookDelivery{}, Status: 202},
+}
@@ -0,0 +1,339 @@
+package i18n
+
+// english - terjemahan bahasa Inggris, kunci = format pesan bahasa Indonesia di kode
+var english = map[string]string{
+	"Token akses diperlukan":                                               "Access token is required",
+	"Format token tidak valid":                                             "Invalid token format",
+	"Token tidak boleh kosong":                                             "Token must not be empty",
+	"Token tidak valid atau expired":                                       "Token is invalid or expired",
+	"Informasi user tidak ditemukan":                                       "User information not found",
+	"Akses ditolak. Hanya admin yang diizinkan":                            "Access denied. Only admins are allowed",
+	"Akses ditolak. Role tidak valid":                                      "Access denied. Invalid role",
+	"Validasi request gagal":                                               "Request validation failed",
+	"Data tidak ditemukan":                                                 "Data not found",
+	"Data sudah ada":                                                       "Data already exists",
+	"Data masih terkait dengan data lain":                                  "Data is still referenced by other data",
+	"Data tidak memenuhi aturan penyimpanan":                               "Data violates storage constraints",
+	"Terjadi kesalahan pada server":                                        "An internal server error occurred",
+	"Token tidak valid":                                                    "Invalid token",
+	"Token valid":                                                          "Token is valid",
+	"Gagal mengambil data alumni":                                          "Failed to fetch alumni data",
+	"Gagal menghitung alumni":                                              "Failed to count alumni",
+	"ID tidak valid":                                                       "Invalid ID",
+	"Format as_of tidak valid (RFC3339 atau YYYY-MM-DD)":                   "Invalid as_of format (RFC3339 or YYYY-MM-DD)",
+	"Gagal mengambil riwayat alumni":                                       "Failed to fetch alumni history",
+	"Alumni tidak ditemukan pada waktu tersebut":                           "Alumni not found at that point in time",
+	"Data alumni berhasil diambil":                                         "Alumni data retrieved successfully",
+	"Alumni tidak ditemukan":                                               "Alumni not found",
+	"Gagal membuat data alumni":                                            "Failed to create alumni",
+	"Alumni berhasil ditambahkan":                                          "Alumni created successfully",
+	"Gagal memeriksa data alumni":                                          "Failed to check alumni data",
+	"Gagal memperbarui data alumni":                                        "Failed to update alumni",
+	"Alumni berhasil diupdate":                                             "Alumni updated successfully",
+	"Gagal menghapus data alumni":                                          "Failed to delete alumni",
+	"Alumni berhasil dihapus":                                              "Alumni deleted successfully",
+	"Riwayat alumni tidak ditemukan":                                       "Alumni history not found",
+	"Riwayat alumni berhasil diambil":                                      "Alumni history retrieved successfully",
+	"Versi tidak valid":                                                    "Invalid version",
+	"Gagal mengambil versi alumni":                                         "Failed to fetch alumni version",
+	"Versi alumni tidak ditemukan":                                         "Alumni version not found",
+	"Gagal mengembalikan alumni ke versi sebelumnya":                       "Failed to revert alumni to the previous version",
+	"Alumni berhasil dikembalikan ke versi %d":                             "Alumni reverted to version %d successfully",
+	"Gagal mengambil data pekerjaan alumni":                                "Failed to fetch alumni jobs",
+	"Timeline karier alumni berhasil diambil":                              "Alumni career timeline retrieved successfully",
+	"Pekerjaan #%d berstatus aktif tetapi tanggal selesai sudah lewat":     "Job #%d is marked active but its end date has passed",
+	"Pekerjaan #%d berstatus %s tanpa tanggal selesai":                     "Job #%d has status %s but no end date",
+	"actor_user_id tidak valid":                                            "Invalid actor_user_id",
+	"entity_id tidak valid":                                                "Invalid entity_id",
+	"Format tanggal from tidak valid (RFC3339 atau YYYY-MM-DD)":            "Invalid from date format (RFC3339 or YYYY-MM-DD)",
+	"Format tanggal to tidak valid (RFC3339 atau YYYY-MM-DD)":              "Invalid to date format (RFC3339 or YYYY-MM-DD)",
+	"Gagal mengambil log audit":                                            "Failed to fetch audit logs",
+	"Gagal menghitung log audit":                                           "Failed to count audit logs",
+	"Gagal mencari user":                                                   "Failed to look up user",
+	"Username atau password salah":                                         "Invalid username or password",
+	"Gagal generate token":                                                 "Failed to generate token",
+	"Login berhasil":                                                       "Login successful",
+	"Gagal memeriksa username":                                             "Failed to check username",
+	"Username sudah digunakan":                                             "Username is already taken",
+	"Gagal memeriksa email":                                                "Failed to check email",
+	"Email sudah digunakan":                                                "Email is already taken",
+	"Gagal hash password":                                                  "Failed to hash password",
+	"Gagal membuat user":                                                   "Failed to create user",
+	"Registrasi berhasil":                                                  "Registration successful",
+	"User ID tidak ditemukan di context":                                   "User ID not found in context",
+	"Gagal mengambil profil user":                                          "Failed to fetch user profile",
+	"User tidak ditemukan":                                                 "User not found",
+	"Profile berhasil diambil":                                             "Profile retrieved successfully",
+	"Logout berhasil. Hapus token dari client":                             "Logout successful. Remove the token from the client",
+	"Perusahaan tidak ditemukan":                                           "Company not found",
+	"Nama perusahaan tidak valid":                                          "Invalid company name",
+	"Gagal mengambil data perusahaan":                                      "Failed to fetch companies",
+	"Gagal menghitung perusahaan":                                          "Failed to count companies",
+	"Data perusahaan berhasil diambil":                                     "Company data retrieved successfully",
+	"Parameter name harus diisi":                                           "Parameter name is required",
+	"Gagal mencocokkan perusahaan":                                         "Failed to match company",
+	"Perusahaan ditemukan":                                                 "Company found",
+	"Kandidat perusahaan berhasil diambil":                                 "Company candidates retrieved successfully",
+	"Status pekerjaan harus salah satu dari: aktif, selesai, resigned":     "Job status must be one of: aktif, selesai, resigned",
+	"Gagal mengambil alumni perusahaan":                                    "Failed to fetch company alumni",
+	"Gagal menghitung alumni perusahaan":                                   "Failed to count company alumni",
+	"Data alumni di perusahaan berhasil diambil":                           "Company alumni retrieved successfully",
+	"Nama perusahaan harus diisi":                                          "Company name is required",
+	"Gagal membuat data perusahaan":                                        "Failed to create company",
+	"Perusahaan berhasil ditambahkan":                                      "Company created successfully",
+	"Gagal memeriksa data perusahaan":                                      "Failed to check company data",
+	"Gagal memperbarui data perusahaan":                                    "Failed to update company",
+	"Perusahaan berhasil diupdate":                                         "Company updated successfully",
+	"Gagal menghapus data perusahaan":                                      "Failed to delete company",
+	"Perusahaan berhasil dihapus":                                          "Company deleted successfully",
+	"Alias harus diisi":                                                    "Alias is required",
+	"Gagal menambahkan alias perusahaan":                                   "Failed to add company alias",
+	"Alias perusahaan berhasil ditambahkan":                                "Company alias added successfully",
+	"ID alias tidak valid":                                                 "Invalid alias ID",
+	"Alias perusahaan tidak ditemukan":                                     "Company alias not found",
+	"Gagal menghapus alias perusahaan":                                     "Failed to delete company alias",
+	"Alias perusahaan berhasil dihapus":                                    "Company alias deleted successfully",
+	"Perusahaan tujuan tidak ditemukan":                                    "Target company not found",
+	"Perusahaan tujuan tidak boleh ada di source_ids":                      "Target company must not be listed in source_ids",
+	"Perusahaan dengan ID %d tidak ditemukan":                              "Company with ID %d not found",
+	"source_ids harus berisi minimal satu ID perusahaan":                   "source_ids must contain at least one company ID",
+	"Gagal menggabungkan perusahaan":                                       "Failed to merge companies",
+	"Perusahaan berhasil digabung":                                         "Companies merged successfully",
+	"Gagal membuat perusahaan":                                             "Failed to create company",
+	"Gagal memeriksa nama perusahaan":                                      "Failed to check company name",
+	"Nama \"%s\" sudah dipakai oleh perusahaan %s":                         "Name \"%s\" is already used by company %s",
+	"Login SSO tidak diaktifkan":                                           "SSO login is not enabled",
+	"Gagal menghubungi identity provider":                                  "Failed to contact the identity provider",
+	"Gagal generate state":                                                 "Failed to generate state",
+	"Gagal generate nonce":                                                 "Failed to generate nonce",
+	"Gagal generate code verifier":                                         "Failed to generate code verifier",
+	"URL login SSO berhasil dibuat":                                        "SSO login URL created successfully",
+	"Login SSO dibatalkan atau ditolak":                                    "SSO login was cancelled or denied",
+	"State login SSO tidak valid atau expired":                             "SSO login state is invalid or expired",
+	"Authorization code tidak ditemukan":                                   "Authorization code not found",
+	"Gagal menukar authorization code":                                     "Failed to exchange authorization code",
+	"ID token tidak valid":                                                 "Invalid ID token",
+	"Login SSO berhasil":                                                   "SSO login successful",
+	"Gagal memperbarui identitas SSO":                                      "Failed to update SSO identity",
+	"Email akun SSO tidak tersedia atau belum terverifikasi":               "SSO account email is missing or not verified",
+	"Akun SSO belum terdaftar di sistem":                                   "SSO account is not registered in the system",
+	"Gagal menghubungkan identitas SSO":                                    "Failed to link SSO identity",
+	"ID pekerjaan tidak valid":                                             "Invalid job ID",
+	"Gagal mengambil detail pekerjaan":                                     "Failed to fetch job details",
+	"Pekerjaan tidak ditemukan atau sudah dihapus.":                        "Job not found or already deleted.",
+	"Gagal mengambil detail alumni terkait pekerjaan":                      "Failed to fetch the alumni linked to the job",
+	"Alumni terkait pekerjaan tidak ditemukan.":                            "Alumni linked to the job not found.",
+	"Gagal melakukan soft delete pekerjaan":                                "Failed to soft delete job",
+	"Pekerjaan berhasil di-soft delete oleh admin.":                        "Job soft deleted by admin successfully.",
+	"Akses ditolak. Anda hanya dapat menghapus pekerjaan Anda sendiri.":    "Access denied. You can only delete your own jobs.",
+	"Pekerjaan Anda berhasil di-soft delete.":                              "Your job was soft deleted successfully.",
+	"Akses ditolak. Role tidak valid.":                                     "Access denied. Invalid role.",
+	"Gagal menghitung pekerjaan alumni":                                    "Failed to count alumni jobs",
+	"Gagal mengambil riwayat pekerjaan":                                    "Failed to fetch job history",
+	"Pekerjaan tidak ditemukan pada waktu tersebut":                        "Job not found at that point in time",
+	"Data pekerjaan berhasil diambil":                                      "Job data retrieved successfully",
+	"Gagal mengambil data pekerjaan":                                       "Failed to fetch jobs",
+	"Pekerjaan tidak ditemukan":                                            "Job not found",
+	"Alumni ID tidak valid":                                                "Invalid alumni ID",
+	"Data pekerjaan alumni berhasil diambil":                               "Alumni jobs retrieved successfully",
+	"Gagal memeriksa pekerjaan aktif":                                      "Failed to check active jobs",
+	"Gagal membuat data pekerjaan":                                         "Failed to create job",
+	"Pekerjaan berhasil ditambahkan":                                       "Job created successfully",
+	"Gagal memeriksa data pekerjaan":                                       "Failed to check job data",
+	"Transisi status dari %s ke %s tidak diizinkan":                        "Status transition from %s to %s is not allowed",
+	"Gagal memperbarui data pekerjaan":                                     "Failed to update job",
+	"Pekerjaan berhasil diupdate":                                          "Job updated successfully",
+	"Gagal menghapus data pekerjaan":                                       "Failed to delete job",
+	"Pekerjaan berhasil dihapus":                                           "Job deleted successfully",
+	"Gagal mengambil pekerjaan di trash":                                   "Failed to fetch trashed jobs",
+	"Gagal menghitung pekerjaan di trash":                                  "Failed to count trashed jobs",
+	"Tidak ada data pekerjaan di trash":                                    "There are no jobs in the trash",
+	"Gagal mengambil data pekerjaan di trash":                              "Failed to fetch trashed job",
+	"Data pekerjaan tidak ditemukan di trash":                              "Job not found in the trash",
+	"Gagal melakukan hard delete dari trash":                               "Failed to permanently delete from the trash",
+	"Data pekerjaan berhasil di-hard delete dari trash":                    "Job permanently deleted from the trash",
+	"Gagal melakukan restore dari trash":                                   "Failed to restore from the trash",
+	"Data pekerjaan berhasil direstore dari trash":                         "Job restored from the trash successfully",
+	"Riwayat pekerjaan tidak ditemukan":                                    "Job history not found",
+	"Riwayat pekerjaan berhasil diambil":                                   "Job history retrieved successfully",
+	"Gagal mengambil versi pekerjaan":                                      "Failed to fetch job version",
+	"Versi pekerjaan tidak ditemukan":                                      "Job version not found",
+	"Gagal mengembalikan pekerjaan ke versi sebelumnya":                    "Failed to revert job to the previous version",
+	"Pekerjaan berhasil dikembalikan ke versi %d":                          "Job reverted to version %d successfully",
+	"Parameter %s harus berupa angka positif":                              "Parameter %s must be a positive number",
+	"Parameter gaji_max tidak boleh lebih kecil dari gaji_min":             "Parameter gaji_max must not be less than gaji_min",
+	"Parameter gaji_currency harus kode mata uang 3 huruf, contoh: IDR":    "Parameter gaji_currency must be a 3-letter currency code, e.g. IDR",
+	"Gaji range tidak dapat dibaca, gunakan gaji_min dan gaji_max":         "Salary range could not be parsed, use gaji_min and gaji_max",
+	"Gaji tidak boleh bernilai negatif":                                    "Salary must not be negative",
+	"Gaji maksimum tidak boleh lebih kecil dari gaji minimum":              "Maximum salary must not be less than minimum salary",
+	"Mata uang gaji harus kode 3 huruf, contoh: IDR":                       "Salary currency must be a 3-letter code, e.g. IDR",
+	"Tanggal selesai kerja harus setelah tanggal mulai kerja":              "Job end date must be after the start date",
+	"Pekerjaan berstatus aktif tidak boleh memiliki tanggal selesai kerja": "An active job must not have an end date",
+	"Tanggal selesai kerja wajib diisi untuk status %s":                    "Job end date is required for status %s",
+	"Tanggal selesai kerja untuk status %s tidak boleh di masa depan":      "Job end date for status %s must not be in the future",
+	"Hanya pekerjaan berstatus aktif yang bisa diakhiri":                   "Only active jobs can be ended",
+	"Gagal mengakhiri pekerjaan":                                           "Failed to end job",
+	"Pekerjaan sudah tidak aktif":                                          "Job is no longer active",
+	"Pekerjaan berhasil diakhiri":                                          "Job ended successfully",
+	"Request body tidak valid":                                             "Invalid request body",
+	"Gagal mengambil statistik tingkat keterserapan kerja":                 "Failed to fetch employment rate statistics",
+	"Statistik tingkat keterserapan kerja berhasil diambil":                "Employment rate statistics retrieved successfully",
+	"Gagal mengambil statistik waktu tunggu kerja pertama":                 "Failed to fetch time-to-first-job statistics",
+	"Statistik waktu tunggu kerja pertama berhasil diambil":                "Time-to-first-job statistics retrieved successfully",
+	"Parameter by harus salah satu dari: bidang_industri, lokasi_kerja":    "Parameter by must be one of: bidang_industri, lokasi_kerja",
+	"Gagal mengambil statistik distribusi":                                 "Failed to fetch distribution statistics",
+	"Statistik sebaran pekerjaan berhasil diambil":                         "Job distribution statistics retrieved successfully",
+	"Gagal mengambil statistik pekerjaan aktif":                            "Failed to fetch active job statistics",
+	"Statistik alumni yang sedang bekerja berhasil diambil":                "Currently employed alumni statistics retrieved successfully",
+	"Parameter group_by harus salah satu dari: angkatan, jurusan, tahun_lulus, bidang_industri": "Parameter group_by must be one of: angkatan, jurusan, tahun_lulus, bidang_industri",
+	"Parameter currency harus kode mata uang 3 huruf, contoh: IDR":                              "Parameter currency must be a 3-letter currency code, e.g. IDR",
+	"Gagal mengambil statistik gaji":                                                            "Failed to fetch salary statistics",
+	"Statistik gaji berhasil diambil":                                                           "Salary statistics retrieved successfully",
+	"Parameter group_by harus salah satu dari: angkatan, jurusan, tahun_lulus":                  "Parameter group_by must be one of: angkatan, jurusan, tahun_lulus",
+	"Parameter %s harus berupa angka":                                                           "Parameter %s must be a number",
+	"Gagal membuat file CSV":                                                                    "Failed to generate CSV file",
+	"Gagal mengambil data survey":                                                               "Failed to fetch surveys",
+	"Data survey berhasil diambil":                                                              "Survey data retrieved successfully",
+	"Gagal memeriksa data survey":                                                               "Failed to check survey data",
+	"Survey tidak ditemukan atau tidak dibuka untuk Anda":                                       "Survey not found or not open to you",
+	"Survey tidak ditemukan":                                                                    "Survey not found",
+	"Definisi survey tidak valid":                                                               "Invalid survey definition",
+	"Gagal membuat data survey":                                                                 "Failed to create survey",
+	"Survey berhasil dibuat":                                                                    "Survey created successfully",
+	"Hanya survey berstatus draft yang bisa diubah":                                             "Only draft surveys can be modified",
+	"Gagal memperbarui data survey":                                                             "Failed to update survey",
+	"Survey berhasil diupdate":                                                                  "Survey updated successfully",
+	"Survey yang sudah dibuka tidak bisa dihapus, tutup survey sebagai gantinya":                "An opened survey cannot be deleted, close it instead",
+	"Gagal menghapus data survey":                                                               "Failed to delete survey",
+	"Survey berhasil dihapus":                                                                   "Survey deleted successfully",
+	"Status survey sudah %s":                                                                    "Survey status is already %s",
+	"Hanya survey yang sedang dibuka yang bisa ditutup":                                         "Only open surveys can be closed",
+	"Survey tanpa pertanyaan tidak bisa dibuka":                                                 "A survey without questions cannot be opened",
+	"Gagal memperbarui status survey":                                                           "Failed to update survey status",
+	"Status survey berhasil diubah menjadi %s":                                                  "Survey status changed to %s successfully",
+	"Gagal mengambil progres pengisian survey":                                                  "Failed to fetch survey progress",
+	"Progres survey berhasil diambil":                                                           "Survey progress retrieved successfully",
+	"Status harus salah satu dari: submitted, in_progress":                                      "Status must be one of: submitted, in_progress",
+	"Gagal mengambil jawaban survey":                                                            "Failed to fetch survey answers",
+	"Jawaban survey berhasil diambil":                                                           "Survey answers retrieved successfully",
+	"Anda belum mengisi survey ini":                                                             "You have not filled in this survey",
+	"Jawaban survey sudah dikirim dan tidak bisa diubah":                                        "Survey answers have been submitted and can no longer be changed",
+	"Pertanyaan tidak dikenal":                                                                  "Unknown question",
+	"Pertanyaan wajib diisi":                                                                    "This question is required",
+	"Jawaban survey tidak valid":                                                                "Invalid survey answers",
+	"Gagal menyimpan jawaban survey":                                                            "Failed to save survey answers",
+	"Jawaban survey berhasil dikirim":                                                           "Survey answers submitted successfully",
+	"Jawaban survey berhasil disimpan":                                                          "Survey answers saved successfully",
+	"Akun Anda belum terhubung dengan data alumni":                                              "Your account is not linked to an alumni record",
+	"Judul survey harus diisi":                                                                  "Survey title is required",
+	"closes_at harus setelah opens_at":                                                          "closes_at must be after opens_at",
+	"Survey minimal memiliki satu pertanyaan":                                                   "A survey must have at least one question",
+	"Kode pertanyaan harus diisi":                                                               "Question code is required",
+	"Kode pertanyaan duplikat: %s":                                                              "Duplicate question code: %s",
+	"Teks pertanyaan harus diisi":                                                               "Question text is required",
+	"Pertanyaan pilihan minimal memiliki dua opsi":                                              "A choice question must have at least two options",
+	"Pertanyaan skala membutuhkan min_value dan max_value":                                      "A scale question requires min_value and max_value",
+	"Tipe pertanyaan tidak dikenal: %s":                                                         "Unknown question type: %s",
+	"max_value harus lebih besar dari min_value":                                                "max_value must be greater than min_value",
+	"show_if harus merujuk kode pertanyaan sebelumnya":                                          "show_if must reference a previous question code",
+	"show_if.value harus diisi":                                                                 "show_if.value is required",
+	"Operator harus salah satu dari: equals, not_equals, in, answered":                          "Operator must be one of: equals, not_equals, in, answered",
+	"Jawaban harus berupa teks":                                                                 "Answer must be text",
+	"Jawaban harus berupa angka":                                                                "Answer must be a number",
+	"Jawaban skala harus bilangan bulat":                                                        "Scale answer must be an integer",
+	"Jawaban minimal %v":                                                                        "Answer must be at least %v",
+	"Jawaban maksimal %v":                                                                       "Answer must be at most %v",
+	"Jawaban harus berupa tanggal YYYY-MM-DD":                                                   "Answer must be a YYYY-MM-DD date",
+	"Jawaban harus berupa salah satu opsi":                                                      "Answer must be one of the options",
+	"Opsi tidak valid: %s":                                                                      "Invalid option: %s",
+	"Jawaban harus berupa daftar opsi":                                                          "Answer must be a list of options",
+	"Tipe pertanyaan tidak dikenal":                                                             "Unknown question type",
+	"%s minimal %s":                                                                             "%s must be at least %s",
+	"%s minimal %s karakter":                                                                    "%s must be at least %s characters",
+	"%s minimal %s item":                                                                        "%s must contain at least %s items",
+	"%s maksimal %s":                                                                            "%s must be at most %s",
+	"%s maksimal %s karakter":                                                                   "%s must be at most %s characters",
+	"%s maksimal %s item":                                                                       "%s must contain at most %s items",
+	"%s harus tepat %s":                                                                         "%s must be exactly %s",
+	"%s harus tepat %s karakter":                                                                "%s must be exactly %s characters",
+	"%s harus tepat %s item":                                                                    "%s must contain exactly %s items",
+	"%s harus diisi":                                                                            "%s is required",
+	"%s harus berupa alamat email yang valid":                                                   "%s must be a valid email address",
+	"%s harus salah satu dari: %s":                                                              "%s must be one of: %s",
+	"%s tidak sesuai format NIM":                                                                "%s does not match the NIM format",
+	"%s harus lebih besar dari %s":                                                              "%s must be greater than %s",
+	"%s tidak boleh lebih kecil dari %s":                                                        "%s must not be less than %s",
+	"Parameter %s tidak boleh lebih kecil dari %s":                                              "Parameter %s must not be less than %s",
+	"Format tanggal %s tidak valid (RFC3339 atau YYYY-MM-DD)":                                   "Invalid %s date format (RFC3339 or YYYY-MM-DD)",
+	"Parameter %s harus berupa true atau false":                                                 "Parameter %s must be true or false",
+	"%s tidak valid":                                                                            "%s is invalid",
+	"Alumni masih memiliki pekerjaan aktif, akhiri pekerjaan tersebut terlebih dahulu": "Alumni still has an active job, end that job first",
+	"Alumni masih memiliki pekerjaan aktif, akhiri pekerjaan tersebut sebelum restore": "Alumni still has an active job, end that job before restoring",
+	"Gagal mengambil saran":                                                     "Failed to fetch suggestions",
+	"Saran berhasil diambil":                                                    "Suggestions retrieved successfully",
+	"Pagination cursor tidak mendukung sortBy=relevance":                        "Cursor pagination does not support sortBy=relevance",
+	"Cursor tidak valid":                                                        "Invalid cursor",
+	"Cursor dibuat untuk sortBy=%s dan order=%s":                                "Cursor was created for sortBy=%s and order=%s",
+	"Body PATCH harus berupa objek JSON":                                        "PATCH body must be a JSON object",
+	"%s tidak bisa diubah":                                                      "%s cannot be changed",
+	"Header If-Match wajib dikirim, ambil ETag dari GET terlebih dahulu":        "If-Match header is required, get the ETag from GET first",
+	"Ukuran body request maksimal %d MB":                                        "Request body must not exceed %d MB",
+	"Data sudah diubah oleh pengguna lain, muat ulang data terlebih dahulu":     "Data was changed by another user, reload it first",
+	"Idempotency-Key maksimal %d karakter":                                      "Idempotency-Key must be at most %d characters",
+	"Gagal memeriksa Idempotency-Key":                                           "Failed to check Idempotency-Key",
+	"Idempotency-Key sudah dipakai untuk request yang berbeda":                  "Idempotency-Key was already used for a different request",
+	"Request dengan Idempotency-Key ini masih diproses":                         "A request with this Idempotency-Key is still being processed",
+	"Parameter min_similarity harus antara %.1f dan 1":                          "Parameter min_similarity must be between %.1f and 1",
+	"Gagal mencari duplikat alumni":                                             "Failed to find duplicate alumni",
+	"Alumni tujuan tidak boleh sama dengan source_id":                           "Target alumni must not be the same as source_id",
+	"Alumni tujuan tidak ditemukan":                                             "Target alumni not found",
+	"Alumni dengan ID %d tidak ditemukan":                                       "Alumni with ID %d not found",
+	"Kedua alumni memiliki pekerjaan aktif, akhiri salah satu sebelum digabung": "Both alumni have an active job, end one of them before merging",
+	"Kedua alumni terhubung ke akun user yang berbeda":                          "The alumni are linked to different user accounts",
+	"Gagal menggabungkan alumni":                                                "Failed to merge alumni",
+	"Alumni berhasil digabung":                                                  "Alumni merged successfully",
+	"Gagal menyimpan foto alumni":                                               "Failed to save alumni photo",
+	"Foto alumni berhasil diupload":                                             "Alumni photo uploaded successfully",
+	"Gagal mengambil foto alumni":                                               "Failed to retrieve alumni photo",
+	"Alumni belum memiliki foto":                                                "Alumni has no photo yet",
+	"Foto alumni berhasil dihapus":                                              "Alumni photo deleted successfully",
+	"Akses ditolak. Anda hanya dapat mengubah foto Anda sendiri.":               "Access denied. You can only change your own photo.",
+	"Gagal menyimpan dokumen pekerjaan":                                         "Failed to save job document",
+	"Dokumen pekerjaan berhasil diupload":                                       "Job document uploaded successfully",
+	"Gagal mengambil dokumen pekerjaan":                                         "Failed to retrieve job documents",
+	"Dokumen pekerjaan berhasil diambil":                                        "Job documents retrieved successfully",
+	"Dokumen pekerjaan berhasil dihapus":                                        "Job document deleted successfully",
+	"ID dokumen tidak valid":                                                    "Invalid document ID",
+	"Dokumen pekerjaan tidak ditemukan":                                         "Job document not found",
+	"Akses ditolak. Anda hanya dapat mengakses dokumen pekerjaan Anda sendiri.": "Access denied. You can only access documents of your own jobs.",
+	"Field file wajib dikirim sebagai multipart/form-data":                      "The file field must be sent as multipart/form-data",
+	"Ukuran file maksimal %s":                                                   "File size must be at most %s",
+	"Gagal membaca file upload":                                                 "Failed to read uploaded file",
+	"File tidak boleh kosong":                                                   "File must not be empty",
+	"Tipe file harus salah satu dari %s":                                        "File type must be one of %s",
+	"Gagal menyimpan file":                                                      "Failed to save file",
+	"File gambar rusak atau terlalu besar":                                      "Image file is corrupt or too large",
+	"Thumbnail hanya tersedia untuk file gambar":                                "Thumbnails are only available for image files",
+	"File tidak ditemukan di storage":                                           "File not found in storage",
+	"Gagal membaca file":                                                        "Failed to read file",
+	"Gagal menghapus file":                                                      "Failed to delete file",
+	"Gagal mengambil data webhook":                                              "Failed to retrieve webhook data",
+	"Data webhook berhasil diambil":                                             "Webhook data retrieved successfully",
+	"Gagal membuat secret webhook":                                              "Failed to generate webhook secret",
+	"Gagal membuat webhook":                                                     "Failed to create webhook",
+	"Webhook berhasil dibuat":                                                   "Webhook created successfully",
+	"Gagal memperbarui webhook":                                                 "Failed to update webhook",
+	"Webhook tidak ditemukan":                                                   "Webhook not found",
+	"Webhook berhasil diupdate":                                                 "Webhook updated successfully",
+	"Gagal menghapus webhook":                                                   "Failed to delete webhook",
+	"Webhook berhasil dihapus":                                                  "Webhook deleted successfully",
+	"Parameter status harus pending, success, atau failed":                      "The status parameter must be pending, success, or failed",
+	"Gagal mengambil log delivery webhook":                                      "Failed to retrieve webhook delivery log",
+	"Webhook tidak aktif, aktifkan terlebih dahulu sebelum mengirim ulang":      "Webhook is inactive, activate it before redelivering",
+	"ID delivery tidak valid":                                                   "Invalid delivery ID",
+	"Gagal mengambil delivery webhook":                                          "Failed to retrieve webhook delivery",
+	"Delivery webhook tidak ditemukan":                                          "Webhook delivery not found",
+	"Gagal mengantre ulang delivery webhook":                                    "Failed to queue webhook redelivery",
+	"Delivery webhook diantre untuk dikirim ulang":                              "Webhook delivery queued for redelivery",
+	"%s harus berupa URL http atau https":                                       "%s must be an http or https URL",
+}
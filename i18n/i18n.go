@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Bahasa yang didukung; pesan di kode ditulis dalam bahasa Indonesia dan sekaligus menjadi kunci katalog
+const (
+	LangID      = "id"
+	LangEN      = "en"
+	DefaultLang = LangID
+)
+
+// catalogs - terjemahan per bahasa selain Indonesia, kunci = format pesan bahasa Indonesia
+var catalogs = map[string]map[string]string{
+	LangEN: english,
+}
+
+// Language - pilih bahasa dari header Accept-Language ("en-US,en;q=0.9,id;q=0.8"), default id
+func Language(acceptLanguage string) string {
+	type candidate struct {
+		lang    string
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		lang := strings.SplitN(tag, "-", 2)[0]
+		if quality > 0 && (lang == LangID || catalogs[lang] != nil) {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].quality > candidates[j].quality })
+	if len(candidates) == 0 {
+		return DefaultLang
+	}
+	return candidates[0].lang
+}
+
+// FromContext - bahasa request (diisi middleware.Language, fallback ke header Accept-Language)
+func FromContext(c *fiber.Ctx) string {
+	if lang, ok := c.Locals("lang").(string); ok && lang != "" {
+		return lang
+	}
+	return Language(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// Translate - terjemahkan format pesan ke bahasa lang lalu terapkan args.
+// Pesan yang belum ada di katalog dikirim dalam bahasa Indonesia.
+func Translate(lang, format string, args ...interface{}) string {
+	if translated, ok := catalogs[lang][format]; ok {
+		format = translated
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// T - Translate dengan bahasa dari request
+func T(c *fiber.Ctx, format string, args ...interface{}) string {
+	return Translate(FromContext(c), format, args...)
+}
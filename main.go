@@ -2,11 +2,15 @@ package main
 
 import (
 	"alumni-management-system/config"
+	"alumni-management-system/docs"
+	"alumni-management-system/middleware"
 	"alumni-management-system/repositories"
 	"alumni-management-system/routes"
 	"alumni-management-system/services"
+	"alumni-management-system/utils"
 	"log"
 	"os"
+	"strconv"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -29,40 +33,68 @@ func main() {
 	alumniRepo := repositories.NewAlumniRepository()
 	pekerjaanRepo := repositories.NewPekerjaanRepository()
 	userRepo := repositories.NewUserRepository()
+	auditRepo := repositories.NewAuditRepository()
+	historyRepo := repositories.NewHistoryRepository()
+	surveyRepo := repositories.NewSurveyRepository()
+	statsRepo := repositories.NewStatsRepository()
+	companyRepo := repositories.NewCompanyRepository()
+	suggestionRepo := repositories.NewSuggestionRepository()
+	idempotencyRepo := repositories.NewIdempotencyRepository()
+	attachmentRepo := repositories.NewAttachmentRepository()
+	webhookRepo := repositories.NewWebhookRepository()
+
+	uploadConfig := utils.LoadUploadConfig()
+	storage, err := utils.NewStorage(uploadConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Initialize services
-	alumniService := services.NewAlumniService(alumniRepo)
-	pekerjaanService := services.NewPekerjaanService(pekerjaanRepo, alumniRepo)
-	authService := services.NewAuthService(userRepo)
+	auditService := services.NewAuditService(auditRepo)
+	webhookService := services.NewWebhookService(webhookRepo, auditService)
+	alumniService := services.NewAlumniService(alumniRepo, pekerjaanRepo, historyRepo, auditService, webhookService)
+	companyService := services.NewCompanyService(companyRepo, auditService)
+	pekerjaanService := services.NewPekerjaanService(pekerjaanRepo, alumniRepo, historyRepo, auditService, companyService, webhookService)
+	authService := services.NewAuthService(userRepo, auditService)
+	oidcService := services.NewOIDCService(userRepo, auditService, utils.LoadOIDCConfig())
+	surveyService := services.NewSurveyService(surveyRepo, alumniRepo, auditService)
+	statsService := services.NewStatsService(statsRepo)
+	suggestionService := services.NewSuggestionService(suggestionRepo)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo)
+	attachmentService := services.NewAttachmentService(attachmentRepo, alumniRepo, pekerjaanRepo, storage, uploadConfig, auditService)
 
 	// Initialize Fiber app
+	// Detail error internal hanya dikirim ke client jika APP_DEBUG=true
+	debug, _ := strconv.ParseBool(os.Getenv("APP_DEBUG"))
 	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			return c.Status(code).JSON(fiber.Map{
-				"success": false,
-				"message": "Internal Server Error",
-				"error":   err.Error(),
-			})
-		},
+		ErrorHandler: middleware.ErrorHandler(debug),
+		BodyLimit:    uploadConfig.BodyLimit(), // batas server = upload terbesar; route lain dibatasi middleware.BodyLimit
 	})
 
 	// Global middleware
 	app.Use(recover.New()) // Recover from panics
+	app.Use(middleware.Language())
+	app.Use(middleware.BodyLimit(fiber.DefaultBodyLimit, uploadConfig.BodyLimit(), routes.UploadRoutes...))
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:  "Origin,Content-Type,Accept,Accept-Language,Authorization,If-Match,If-None-Match,Idempotency-Key",
+		ExposeHeaders: "ETag,Idempotent-Replayed,Content-Disposition",
 	}))
 	app.Use(logger.New(logger.Config{
 		Format: "[${time}] ${status} - ${method} ${path} (${latency})\n",
 	}))
 
 	// Setup routes
-	routes.SetupRoutes(app, alumniService, pekerjaanService, authService) // Pass services directly
+	routes.SetupRoutes(app, alumniService, pekerjaanService, authService, oidcService, auditService, surveyService, statsService, companyService, suggestionService, idempotencyService, attachmentService, webhookService) // Pass services directly
+
+	// Setiap route harus terdokumentasi di /openapi.json (lihat docs/operations.go)
+	if err := docs.Verify(app); err != nil {
+		log.Fatal(err)
+	}
+
+	// Kirim webhook yang tertunda/dicoba ulang di background
+	webhookService.StartDispatcher()
 
 	// Get port from environment or use default
 	port := os.Getenv("SERVER_PORT")
@@ -14,40 +14,24 @@ func AuthRequired() fiber.Handler {
         // Ambil token dari header Authorization
         authHeader := c.Get("Authorization")
         if authHeader == "" {
-            return c.Status(401).JSON(fiber.Map{
-                "success": false,
-                "message": "Token akses diperlukan",
-                "error":   "Authorization header missing",
-            })
+            return utils.Unauthorized("Token akses diperlukan").WithCode("TOKEN_MISSING")
         }
 
         // Extract token dari "Bearer TOKEN"
         tokenParts := strings.Split(authHeader, " ")
         if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-            return c.Status(401).JSON(fiber.Map{
-                "success": false,
-                "message": "Format token tidak valid",
-                "error":   "Invalid authorization header format",
-            })
+            return utils.Unauthorized("Format token tidak valid").WithCode("TOKEN_INVALID")
         }
 
         tokenString := tokenParts[1]
         if tokenString == "" {
-            return c.Status(401).JSON(fiber.Map{
-                "success": false,
-                "message": "Token tidak boleh kosong",
-                "error":   "Empty token",
-            })
+            return utils.Unauthorized("Token tidak boleh kosong").WithCode("TOKEN_MISSING")
         }
 
         // Validasi token
         claims, err := utils.ValidateToken(tokenString)
         if err != nil {
-            return c.Status(401).JSON(fiber.Map{
-                "success": false,
-                "message": "Token tidak valid atau expired",
-                "error":   err.Error(),
-            })
+            return utils.Unauthorized("Token tidak valid atau expired").WithCode("TOKEN_INVALID").WithError(err)
         }
 
         // Simpan informasi user di context untuk digunakan di handler
@@ -65,20 +49,12 @@ func AdminOnly() fiber.Handler {
     return func(c *fiber.Ctx) error {
         role := c.Locals("role")
         if role == nil {
-            return c.Status(401).JSON(fiber.Map{
-                "success": false,
-                "message": "Informasi user tidak ditemukan",
-                "error":   "User context missing",
-            })
+            return utils.Unauthorized("Informasi user tidak ditemukan")
         }
 
         userRole, ok := role.(string)
         if !ok || userRole != "admin" {
-            return c.Status(403).JSON(fiber.Map{
-                "success": false,
-                "message": "Akses ditolak. Hanya admin yang diizinkan",
-                "error":   "Insufficient privileges",
-            })
+            return utils.Forbidden("Akses ditolak. Hanya admin yang diizinkan")
         }
 
         return c.Next()
@@ -90,20 +66,12 @@ func UserOrAdmin() fiber.Handler {
     return func(c *fiber.Ctx) error {
         role := c.Locals("role")
         if role == nil {
-            return c.Status(401).JSON(fiber.Map{
-                "success": false,
-                "message": "Informasi user tidak ditemukan",
-                "error":   "User context missing",
-            })
+            return utils.Unauthorized("Informasi user tidak ditemukan")
         }
 
         userRole, ok := role.(string)
         if !ok || (userRole != "admin" && userRole != "user") {
-            return c.Status(403).JSON(fiber.Map{
-                "success": false,
-                "message": "Akses ditolak. Role tidak valid",
-                "error":   "Invalid role",
-            })
+            return utils.Forbidden("Akses ditolak. Role tidak valid")
         }
 
         return c.Next()
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"alumni-management-system/utils"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit - batas ukuran body per route. fasthttp menolak body di atas fiber.Config.BodyLimit sebelum routing,
+// sehingga batas server harus sebesar upload terbesar; middleware ini mengembalikan batas limit untuk semua route
+// lain. uploadRoutes berformat "METHOD /path/:param" dan boleh menerima body sampai uploadLimit.
+func BodyLimit(limit, uploadLimit int, uploadRoutes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		max := limit
+		for _, route := range uploadRoutes {
+			if matchRoute(route, c.Method(), c.Path()) {
+				max = uploadLimit
+				break
+			}
+		}
+		if len(c.Request().Body()) > max {
+			return utils.PayloadTooLarge("Ukuran body request maksimal %d MB", max>>20)
+		}
+		return c.Next()
+	}
+}
+
+// matchRoute - cocokkan "METHOD /path/:param" dengan request; segmen :param cocok dengan segmen apa pun
+// yang tidak kosong. Seperti router Fiber (default), huruf besar/kecil dan garis miring di akhir diabaikan.
+func matchRoute(route, method, path string) bool {
+	routeMethod, routePath, ok := strings.Cut(route, " ")
+	if !ok || routeMethod != method {
+		return false
+	}
+	want := strings.Split(strings.Trim(routePath, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if strings.HasPrefix(want[i], ":") {
+			if got[i] == "" {
+				return false
+			}
+			continue
+		}
+		if !strings.EqualFold(want[i], got[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBodyLimit(t *testing.T) {
+	const limit, uploadLimit = 1 << 20, 3 << 20
+	app := fiber.New(fiber.Config{BodyLimit: uploadLimit, ErrorHandler: ErrorHandler(false)})
+	app.Use(BodyLimit(limit, uploadLimit, "PUT /api/alumni/:id/photo"))
+	app.Post("/api/auth/login", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	app.Put("/api/alumni/:id/photo", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		size   int
+		want   int
+	}{
+		{"small body", http.MethodPost, "/api/auth/login", 1024, fiber.StatusNoContent},
+		{"large body on regular route", http.MethodPost, "/api/auth/login", 2 << 20, fiber.StatusRequestEntityTooLarge},
+		{"large body on upload route", http.MethodPut, "/api/alumni/5/photo", 2 << 20, fiber.StatusNoContent},
+		{"upload route with trailing slash", http.MethodPut, "/api/alumni/5/photo/", 2 << 20, fiber.StatusNoContent},
+		{"upload path with other method", http.MethodPost, "/api/alumni/5/photo", 2 << 20, fiber.StatusRequestEntityTooLarge},
+		{"upload path without id", http.MethodPut, "/api/alumni//photo", 2 << 20, fiber.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader(make([]byte, tt.size)))
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
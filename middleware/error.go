@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"alumni-management-system/i18n"
+	"alumni-management-system/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+// ErrorHandler - pemetaan terpusat error dari handler ke response JSON:
+// {"success": false, "code": ..., "message": ...}. Pesan diterjemahkan sesuai Accept-Language,
+// detail error internal hanya ikut dikirim (field "error") jika debug aktif (env APP_DEBUG).
+func ErrorHandler(debug bool) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		appErr, fieldErrors := mapError(err)
+
+		if appErr.Status >= fiber.StatusInternalServerError {
+			log.Printf("[ERROR] %s %s: %v", c.Method(), c.Path(), err)
+		}
+
+		lang := i18n.FromContext(c)
+		body := fiber.Map{"success": false, "code": appErr.Code, "message": i18n.Translate(lang, appErr.Message, appErr.Args...)}
+		for key, value := range appErr.Details {
+			body[key] = value
+		}
+		if fieldErrors != nil {
+			translated := make([]utils.FieldError, len(fieldErrors))
+			for i, fe := range fieldErrors {
+				translated[i] = utils.FieldError{Field: fe.Field, Rule: fe.Rule, Message: i18n.Translate(lang, fe.Message, fe.Args...)}
+			}
+			body["errors"] = translated
+		}
+		if debug && appErr.Err != nil {
+			body["error"] = appErr.Err.Error()
+		}
+		return c.Status(appErr.Status).JSON(body)
+	}
+}
+
+// mapError - ubah error apa pun menjadi AppError; error yang tidak dikenal dianggap 500
+func mapError(err error) (*utils.AppError, []utils.FieldError) {
+	var validationErr *utils.ValidationError
+	if errors.As(err, &validationErr) {
+		message := validationErr.Message
+		if message == "" {
+			message = "Validasi request gagal"
+		}
+		return &utils.AppError{Status: fiber.StatusUnprocessableEntity, Code: utils.ErrCodeValidation, Message: message}, validationErr.Errors
+	}
+
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) {
+		return appErr, nil
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return &utils.AppError{Status: fiberErr.Code, Code: fiberErrorCode(fiberErr.Code), Message: fiberErr.Message}, nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return utils.NotFound("Data tidak ditemukan").WithError(err), nil
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return utils.Conflict("Data sudah ada").WithError(err), nil
+		case "foreign_key_violation":
+			return utils.Conflict("Data masih terkait dengan data lain").WithError(err), nil
+		case "check_violation", "not_null_violation":
+			return utils.BadRequest("Data tidak memenuhi aturan penyimpanan").WithError(err), nil
+		}
+	}
+
+	return utils.Internal("Terjadi kesalahan pada server", err), nil
+}
+
+func fiberErrorCode(status int) string {
+	switch status {
+	case fiber.StatusBadRequest:
+		return utils.ErrCodeBadRequest
+	case fiber.StatusUnauthorized:
+		return utils.ErrCodeUnauthorized
+	case fiber.StatusForbidden:
+		return utils.ErrCodeForbidden
+	case fiber.StatusNotFound:
+		return utils.ErrCodeNotFound
+	case fiber.StatusConflict:
+		return utils.ErrCodeConflict
+	case fiber.StatusUnprocessableEntity:
+		return utils.ErrCodeValidation
+	}
+	if status >= fiber.StatusInternalServerError {
+		return utils.ErrCodeInternal
+	}
+	return utils.ErrCodeBadRequest
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"alumni-management-system/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Language middleware - simpan bahasa response (dari Accept-Language) di Locals("lang")
+func Language() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lang := i18n.Language(c.Get(fiber.HeaderAcceptLanguage))
+		c.Locals("lang", lang)
+		c.Set(fiber.HeaderContentLanguage, lang)
+		c.Vary(fiber.HeaderAcceptLanguage)
+		return c.Next()
+	}
+}
@@ -0,0 +1,13 @@
+-- Relasi user lokal dengan subject di OpenID Connect identity provider
+CREATE TABLE IF NOT EXISTS user_oidc_identities (
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer      VARCHAR(255) NOT NULL,
+    subject     VARCHAR(255) NOT NULL,
+    email       VARCHAR(255) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (issuer, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_oidc_identities_user_id ON user_oidc_identities(user_id);
@@ -0,0 +1,30 @@
+-- Audit trail untuk semua operasi yang mengubah data (append-only)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id             BIGSERIAL PRIMARY KEY,
+    actor_user_id  INTEGER,
+    actor_role     VARCHAR(20),
+    action         VARCHAR(50) NOT NULL,
+    entity         VARCHAR(50) NOT NULL,
+    entity_id      INTEGER,
+    before_data    JSONB,
+    after_data     JSONB,
+    changes        JSONB,
+    ip_address     VARCHAR(64) NOT NULL DEFAULT '',
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs(entity, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(actor_user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at);
+
+-- Tolak UPDATE/DELETE agar audit trail tidak bisa diubah
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trg_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
@@ -0,0 +1,90 @@
+-- Riwayat versi baris alumni dan pekerjaan_alumni (snapshot penuh tiap perubahan)
+-- Tanpa foreign key agar riwayat tetap ada setelah baris dihapus permanen
+CREATE TABLE IF NOT EXISTS alumni_versions (
+    id           BIGSERIAL PRIMARY KEY,
+    alumni_id    INTEGER NOT NULL,
+    version      INTEGER NOT NULL,
+    operation    VARCHAR(10) NOT NULL,
+    data         JSONB NOT NULL,
+    recorded_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (alumni_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS pekerjaan_alumni_versions (
+    id            BIGSERIAL PRIMARY KEY,
+    pekerjaan_id  INTEGER NOT NULL,
+    version       INTEGER NOT NULL,
+    operation     VARCHAR(10) NOT NULL,
+    data          JSONB NOT NULL,
+    recorded_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (pekerjaan_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_alumni_versions_recorded ON alumni_versions(alumni_id, recorded_at);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_versions_recorded ON pekerjaan_alumni_versions(pekerjaan_id, recorded_at);
+
+CREATE OR REPLACE FUNCTION alumni_record_version() RETURNS trigger AS $$
+DECLARE
+    target RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target := OLD;
+    ELSE
+        target := NEW;
+    END IF;
+
+    INSERT INTO alumni_versions (alumni_id, version, operation, data, recorded_at)
+    VALUES (
+        target.id,
+        COALESCE((SELECT MAX(version) FROM alumni_versions WHERE alumni_id = target.id), 0) + 1,
+        TG_OP,
+        to_jsonb(target),
+        clock_timestamp()
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION pekerjaan_alumni_record_version() RETURNS trigger AS $$
+DECLARE
+    target RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target := OLD;
+    ELSE
+        target := NEW;
+    END IF;
+
+    INSERT INTO pekerjaan_alumni_versions (pekerjaan_id, version, operation, data, recorded_at)
+    VALUES (
+        target.id,
+        COALESCE((SELECT MAX(version) FROM pekerjaan_alumni_versions WHERE pekerjaan_id = target.id), 0) + 1,
+        TG_OP,
+        to_jsonb(target),
+        clock_timestamp()
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_alumni_record_version ON alumni;
+CREATE TRIGGER trg_alumni_record_version
+    AFTER INSERT OR UPDATE OR DELETE ON alumni
+    FOR EACH ROW EXECUTE FUNCTION alumni_record_version();
+
+DROP TRIGGER IF EXISTS trg_pekerjaan_alumni_record_version ON pekerjaan_alumni;
+CREATE TRIGGER trg_pekerjaan_alumni_record_version
+    AFTER INSERT OR UPDATE OR DELETE ON pekerjaan_alumni
+    FOR EACH ROW EXECUTE FUNCTION pekerjaan_alumni_record_version();
+
+-- Baseline untuk data yang sudah ada: state sekarang adalah satu-satunya yang diketahui,
+-- dianggap berlaku sejak baris dibuat
+INSERT INTO alumni_versions (alumni_id, version, operation, data, recorded_at)
+SELECT a.id, 1, 'BASELINE', to_jsonb(a), a.created_at
+FROM alumni a
+WHERE NOT EXISTS (SELECT 1 FROM alumni_versions v WHERE v.alumni_id = a.id);
+
+INSERT INTO pekerjaan_alumni_versions (pekerjaan_id, version, operation, data, recorded_at)
+SELECT p.id, 1, 'BASELINE', to_jsonb(p), p.created_at
+FROM pekerjaan_alumni p
+WHERE NOT EXISTS (SELECT 1 FROM pekerjaan_alumni_versions v WHERE v.pekerjaan_id = p.id);
@@ -0,0 +1,51 @@
+-- Modul kuesioner tracer study
+CREATE TABLE IF NOT EXISTS surveys (
+    id               SERIAL PRIMARY KEY,
+    judul            VARCHAR(255) NOT NULL,
+    deskripsi        TEXT,
+    status           VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'open', 'closed')),
+    target_angkatan  INTEGER[] NOT NULL DEFAULT '{}',
+    target_jurusan   TEXT[] NOT NULL DEFAULT '{}',
+    opens_at         TIMESTAMPTZ,
+    closes_at        TIMESTAMPTZ,
+    created_by       INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS survey_questions (
+    id          SERIAL PRIMARY KEY,
+    survey_id   INTEGER NOT NULL REFERENCES surveys(id) ON DELETE CASCADE,
+    urutan      INTEGER NOT NULL,
+    kode        VARCHAR(50) NOT NULL,
+    pertanyaan  TEXT NOT NULL,
+    tipe        VARCHAR(20) NOT NULL CHECK (tipe IN ('text', 'textarea', 'number', 'scale', 'date', 'single_choice', 'multiple_choice')),
+    options     JSONB NOT NULL DEFAULT '[]',
+    min_value   NUMERIC,
+    max_value   NUMERIC,
+    wajib       BOOLEAN NOT NULL DEFAULT FALSE,
+    show_if     JSONB,
+    UNIQUE (survey_id, kode)
+);
+
+CREATE TABLE IF NOT EXISTS survey_responses (
+    id            SERIAL PRIMARY KEY,
+    survey_id     INTEGER NOT NULL REFERENCES surveys(id) ON DELETE CASCADE,
+    alumni_id     INTEGER NOT NULL REFERENCES alumni(id) ON DELETE CASCADE,
+    status        VARCHAR(20) NOT NULL DEFAULT 'in_progress' CHECK (status IN ('in_progress', 'submitted')),
+    submitted_at  TIMESTAMP,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (survey_id, alumni_id)
+);
+
+CREATE TABLE IF NOT EXISTS survey_answers (
+    id           SERIAL PRIMARY KEY,
+    response_id  INTEGER NOT NULL REFERENCES survey_responses(id) ON DELETE CASCADE,
+    question_id  INTEGER NOT NULL REFERENCES survey_questions(id) ON DELETE CASCADE,
+    value        JSONB NOT NULL,
+    UNIQUE (response_id, question_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_survey_questions_survey_id ON survey_questions(survey_id, urutan);
+CREATE INDEX IF NOT EXISTS idx_survey_responses_alumni_id ON survey_responses(alumni_id);
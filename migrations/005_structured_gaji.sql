@@ -0,0 +1,114 @@
+-- Gaji terstruktur: gaji_min, gaji_max, gaji_currency menggantikan teks bebas gaji_range.
+-- Kolom gaji_range tetap ada sebagai label tampilan untuk client lama.
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_min BIGINT;
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_max BIGINT;
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS gaji_currency VARCHAR(3);
+
+-- Parser teks gaji lama ("5-10 juta", "Rp 7.500.000", "> 15jt", "USD 2k-3k").
+-- Logika sama dengan utils.ParseGajiRange: angka IDR tanpa satuan di bawah 1000 dianggap juta.
+CREATE OR REPLACE FUNCTION parse_gaji_range(raw TEXT, OUT gaji_min BIGINT, OUT gaji_max BIGINT, OUT gaji_currency VARCHAR(3))
+LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    s          TEXT := lower(btrim(coalesce(raw, '')));
+    multiplier NUMERIC := 1;
+    token      TEXT;
+    vals       NUMERIC[] := '{}';
+    amount     BIGINT;
+BEGIN
+    IF s = '' THEN
+        RETURN;
+    END IF;
+
+    gaji_currency := CASE
+        WHEN s LIKE '%usd%' OR s LIKE '%$%' THEN 'USD'
+        WHEN s LIKE '%sgd%' THEN 'SGD'
+        WHEN s LIKE '%myr%' THEN 'MYR'
+        WHEN s LIKE '%eur%' OR s LIKE '%€%' THEN 'EUR'
+        WHEN s LIKE '%jpy%' THEN 'JPY'
+        WHEN s LIKE '%aud%' THEN 'AUD'
+        ELSE 'IDR'
+    END;
+
+    IF s ~ '(miliar|milyar)' THEN
+        multiplier := 1000000000;
+    -- Satuan harus berdiri sebagai kata (boleh menempel ke angka: '15jt'); 'rb' di 'perbulan' tidak dihitung
+    ELSIF s ~ '(^|[^a-z])(juta|jt)\M' THEN
+        multiplier := 1000000;
+    ELSIF s ~ '((^|[^a-z])(ribu|rb)\M|\d\s*k\M)' THEN
+        multiplier := 1000;
+    END IF;
+
+    FOR token IN SELECT m[1] FROM regexp_matches(s, '(\d+(?:[.,]\d+)*)', 'g') AS m LOOP
+        IF token ~ '^\d{1,3}([.,]\d{3})+$' THEN
+            vals := vals || translate(token, '.,', '')::NUMERIC;
+        ELSE
+            vals := vals || replace(token, ',', '.')::NUMERIC;
+        END IF;
+    END LOOP;
+
+    IF cardinality(vals) = 0 OR cardinality(vals) > 2 THEN
+        gaji_currency := NULL;
+        RETURN;
+    END IF;
+
+    IF multiplier = 1 AND gaji_currency = 'IDR' AND (SELECT max(v) FROM unnest(vals) v) < 1000 THEN
+        multiplier := 1000000;
+    END IF;
+
+    IF cardinality(vals) = 2 THEN
+        gaji_min := round(least(vals[1], vals[2]) * multiplier);
+        gaji_max := round(greatest(vals[1], vals[2]) * multiplier);
+        RETURN;
+    END IF;
+
+    amount := round(vals[1] * multiplier);
+    IF s ~ '(>|lebih dari|di ?atas|minimal|\mmin\M|mulai)' THEN
+        gaji_min := amount;
+    ELSIF s ~ '(<|kurang dari|di ?bawah|maksimal|\mmax\M|\mmaks\M|sampai|hingga)' THEN
+        gaji_max := amount;
+    ELSE
+        gaji_min := amount;
+        gaji_max := amount;
+    END IF;
+END;
+$$;
+
+-- Backfill dari teks lama; baris yang tidak bisa diparse dibiarkan NULL (gaji_range tetap tersimpan)
+UPDATE pekerjaan_alumni p
+SET gaji_min = parsed.gaji_min,
+    gaji_max = parsed.gaji_max,
+    gaji_currency = parsed.gaji_currency
+FROM (
+    SELECT id, (parse_gaji_range(gaji_range)).*
+    FROM pekerjaan_alumni
+    WHERE gaji_range IS NOT NULL AND gaji_min IS NULL AND gaji_max IS NULL
+) parsed
+WHERE p.id = parsed.id;
+
+DO $$
+DECLARE
+    unparsed INTEGER;
+BEGIN
+    SELECT COUNT(*) INTO unparsed FROM pekerjaan_alumni
+    WHERE gaji_range IS NOT NULL AND btrim(gaji_range) <> '' AND gaji_min IS NULL AND gaji_max IS NULL;
+    IF unparsed > 0 THEN
+        RAISE NOTICE '% baris gaji_range tidak bisa diparse, periksa manual', unparsed;
+    END IF;
+END;
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'pekerjaan_alumni_gaji_check') THEN
+        ALTER TABLE pekerjaan_alumni ADD CONSTRAINT pekerjaan_alumni_gaji_check CHECK (
+            (gaji_min IS NULL OR gaji_min >= 0)
+            AND (gaji_max IS NULL OR gaji_max >= 0)
+            AND (gaji_min IS NULL OR gaji_max IS NULL OR gaji_max >= gaji_min)
+            AND (gaji_currency IS NULL OR gaji_currency ~ '^[A-Z]{3}$')
+            AND ((gaji_min IS NULL AND gaji_max IS NULL) = (gaji_currency IS NULL))
+        );
+    END IF;
+END;
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_gaji ON pekerjaan_alumni (gaji_currency, gaji_min, gaji_max);
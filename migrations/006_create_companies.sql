@@ -0,0 +1,53 @@
+-- Master data perusahaan: nama_perusahaan di pekerjaan_alumni dinormalisasi ke satu entitas companies
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Normalisasi nama untuk pencocokan: huruf kecil, tanpa tanda baca dan tanpa badan usaha (PT, Tbk, ...).
+-- Logika sama dengan utils.NormalizeCompanyName.
+CREATE OR REPLACE FUNCTION normalize_company_name(raw TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT btrim(regexp_replace(
+        regexp_replace(
+            regexp_replace(lower(coalesce(raw, '')), '[^a-z0-9]+', ' ', 'g'),
+            '\m(pt|cv|ud|tbk|persero|perseroan|terbatas|inc|ltd|corp|corporation|co)\M', ' ', 'g'),
+        '\s+', ' ', 'g'))
+$$;
+
+CREATE TABLE IF NOT EXISTS companies (
+    id               SERIAL PRIMARY KEY,
+    nama             VARCHAR(255) NOT NULL,
+    nama_normal      VARCHAR(255) NOT NULL UNIQUE,
+    bidang_industri  VARCHAR(255),
+    lokasi           VARCHAR(255),
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS company_aliases (
+    id            SERIAL PRIMARY KEY,
+    company_id    INTEGER NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    alias         VARCHAR(255) NOT NULL,
+    alias_normal  VARCHAR(255) NOT NULL UNIQUE,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_aliases_company ON company_aliases (company_id);
+CREATE INDEX IF NOT EXISTS idx_companies_nama_normal_trgm ON companies USING gin (nama_normal gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_company_aliases_alias_normal_trgm ON company_aliases USING gin (alias_normal gin_trgm_ops);
+
+ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS company_id INTEGER REFERENCES companies(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_company ON pekerjaan_alumni (company_id);
+
+-- Backfill: satu perusahaan per nama ternormalisasi, data industri/lokasi diambil dari pekerjaan paling awal.
+-- Varian yang tidak identik setelah normalisasi ("Telkom" vs "Telkom Indonesia") digabung lewat endpoint merge.
+INSERT INTO companies (nama, nama_normal, bidang_industri, lokasi)
+SELECT DISTINCT ON (normalize_company_name(nama_perusahaan))
+       btrim(nama_perusahaan), normalize_company_name(nama_perusahaan), bidang_industri, lokasi_kerja
+FROM pekerjaan_alumni
+WHERE normalize_company_name(nama_perusahaan) <> ''
+ORDER BY normalize_company_name(nama_perusahaan), created_at
+ON CONFLICT (nama_normal) DO NOTHING;
+
+UPDATE pekerjaan_alumni p
+SET company_id = c.id
+FROM companies c
+WHERE p.company_id IS NULL AND c.nama_normal = normalize_company_name(p.nama_perusahaan);
@@ -0,0 +1,32 @@
+-- Full-text search alumni dan pekerjaan_alumni: tsvector berbobot + trigram untuk nama (tahan salah ketik).
+-- Konfigurasi 'simple' dipakai karena PostgreSQL tidak punya stemmer bahasa Indonesia bawaan.
+-- Vektor dihitung lewat fungsi IMMUTABLE dengan expression index (bukan kolom baru) agar snapshot
+-- to_jsonb di *_versions tidak ikut berubah. Query di repositories harus memanggil fungsi yang sama.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION alumni_search_vector(nama TEXT, nim TEXT, email TEXT, jurusan TEXT) RETURNS tsvector
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT setweight(to_tsvector('simple', coalesce(nama, '')), 'A') ||
+           setweight(to_tsvector('simple', coalesce(nim, '') || ' ' || coalesce(email, '')), 'B') ||
+           setweight(to_tsvector('simple', coalesce(jurusan, '')), 'C')
+$$;
+
+CREATE OR REPLACE FUNCTION pekerjaan_search_vector(nama_perusahaan TEXT, posisi_jabatan TEXT, bidang_industri TEXT,
+                                                   lokasi_kerja TEXT, deskripsi_pekerjaan TEXT) RETURNS tsvector
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT setweight(to_tsvector('simple', coalesce(nama_perusahaan, '') || ' ' || coalesce(posisi_jabatan, '')), 'A') ||
+           setweight(to_tsvector('simple', coalesce(bidang_industri, '') || ' ' || coalesce(lokasi_kerja, '')), 'B') ||
+           setweight(to_tsvector('simple', coalesce(deskripsi_pekerjaan, '')), 'C')
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_alumni_search_vector
+    ON alumni USING gin (alumni_search_vector(nama, nim, email, jurusan));
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_search_vector
+    ON pekerjaan_alumni USING gin (pekerjaan_search_vector(nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, deskripsi_pekerjaan));
+
+-- Trigram: operator <% (word similarity) untuk salah ketik, sekaligus mempercepat ILIKE '%...%'
+CREATE INDEX IF NOT EXISTS idx_alumni_nama_trgm ON alumni USING gin (nama gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_alumni_nim_trgm ON alumni USING gin (nim gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_alumni_email_trgm ON alumni USING gin (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_nama_perusahaan_trgm ON pekerjaan_alumni USING gin (nama_perusahaan gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_posisi_jabatan_trgm ON pekerjaan_alumni USING gin (posisi_jabatan gin_trgm_ops);
@@ -0,0 +1,7 @@
+-- Index trigram untuk autocomplete (GET /suggestions/:field): ILIKE 'kata%' dan ILIKE '% kata%'.
+-- nama_perusahaan dan posisi_jabatan sudah punya index trigram dari 007_full_text_search.sql.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_alumni_jurusan_trgm ON alumni USING gin (jurusan gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_bidang_industri_trgm ON pekerjaan_alumni USING gin (bidang_industri gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_lokasi_kerja_trgm ON pekerjaan_alumni USING gin (lokasi_kerja gin_trgm_ops);
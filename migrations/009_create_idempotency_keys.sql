@@ -0,0 +1,15 @@
+-- Idempotency-Key untuk POST /alumni dan POST /pekerjaan: response pertama disimpan dan dikirim ulang
+-- untuk request berulang dengan key yang sama. status_code NULL = request pertama masih diproses.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id          INTEGER NOT NULL,
+    idempotency_key  VARCHAR(255) NOT NULL,
+    fingerprint      CHAR(64) NOT NULL,
+    status_code      INTEGER,
+    content_type     VARCHAR(255),
+    response_body    BYTEA,
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at       TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,10 @@
+-- Pencarian kandidat alumni duplikat (GET /alumni/duplicates): email dibandingkan tanpa huruf besar/kecil dan
+-- spasi, nomor telepon hanya digitnya dengan awalan 62 disamakan dengan 0. Query di repositories harus memanggil
+-- ekspresi/fungsi yang sama agar index terpakai. Kemiripan nama memakai idx_alumni_nama_trgm dari 007.
+CREATE OR REPLACE FUNCTION alumni_phone_key(no_telepon TEXT) RETURNS TEXT
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT regexp_replace(regexp_replace(coalesce(no_telepon, ''), '\D', '', 'g'), '^62', '0')
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_alumni_email_lower ON alumni (lower(btrim(email)));
+CREATE INDEX IF NOT EXISTS idx_alumni_phone_key ON alumni (alumni_phone_key(no_telepon)) WHERE alumni_phone_key(no_telepon) <> '';
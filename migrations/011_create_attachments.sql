@@ -0,0 +1,23 @@
+-- File milik alumni (foto profil) dan pekerjaan (dokumen pendukung, mis. surat keterangan kerja).
+-- Isi file ada di storage (lihat STORAGE_DRIVER); tabel ini hanya menyimpan metadata dan key-nya.
+-- Saat alumni/pekerjaan dihapus permanen, alumni_id/pekerjaan_id menjadi NULL dan file dibersihkan
+-- oleh service di background (storage tidak bisa ikut dalam transaksi database).
+CREATE TABLE IF NOT EXISTS attachments (
+    id             SERIAL PRIMARY KEY,
+    alumni_id      INTEGER REFERENCES alumni(id) ON DELETE SET NULL,
+    pekerjaan_id   INTEGER REFERENCES pekerjaan_alumni(id) ON DELETE SET NULL,
+    kind           VARCHAR(20) NOT NULL CHECK (kind IN ('photo', 'document')),
+    file_name      VARCHAR(255) NOT NULL,
+    content_type   VARCHAR(100) NOT NULL,
+    size_bytes     BIGINT NOT NULL,
+    storage_key    VARCHAR(255) NOT NULL UNIQUE,
+    thumbnail_key  VARCHAR(255),
+    uploaded_by    INTEGER,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (alumni_id IS NULL OR pekerjaan_id IS NULL)
+);
+
+-- Satu foto profil per alumni
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_alumni_photo ON attachments(alumni_id) WHERE kind = 'photo';
+CREATE INDEX IF NOT EXISTS idx_attachments_pekerjaan ON attachments(pekerjaan_id) WHERE pekerjaan_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_attachments_orphan ON attachments(id) WHERE alumni_id IS NULL AND pekerjaan_id IS NULL;
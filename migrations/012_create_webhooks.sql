@@ -0,0 +1,37 @@
+-- Webhook: subscription dikelola admin, setiap event menjadi satu delivery per subscription yang dikirim
+-- di background dengan retry (exponential backoff). Delivery disimpan sebagai log dan bisa dikirim ulang.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id           SERIAL PRIMARY KEY,
+    url          VARCHAR(2000) NOT NULL,
+    events       TEXT[] NOT NULL,
+    secret       VARCHAR(100) NOT NULL,
+    description  VARCHAR(255),
+    is_active    BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by   INTEGER,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_events ON webhook_subscriptions USING GIN (events) WHERE is_active;
+
+-- status: pending (menunggu dikirim/dicoba ulang pada next_attempt_at), success, failed (percobaan habis)
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                BIGSERIAL PRIMARY KEY,
+    subscription_id   INTEGER NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event             VARCHAR(50) NOT NULL,
+    event_id          VARCHAR(64) NOT NULL,
+    payload           JSONB NOT NULL,
+    status            VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'success', 'failed')),
+    attempts          INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at   TIMESTAMP,
+    last_status_code  INTEGER,
+    last_error        TEXT,
+    last_response     TEXT,
+    redelivery_of     BIGINT REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at      TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, id DESC);
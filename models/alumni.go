@@ -16,15 +16,21 @@ type Alumni struct {
     IsDeleted   bool      `json:"is_deleted"`
     CreatedAt   time.Time `json:"created_at"`
     UpdatedAt   time.Time `json:"updated_at"`
-      
+
+    // Hanya terisi pada hasil pencarian (search)
+    Score      *float64          `json:"score,omitempty"`
+    Highlights map[string]string `json:"highlights,omitempty"`
+
+    // Hanya terisi pada GET /alumni/without-jobs (untuk prioritas outreach)
+    YearsSinceGraduation *int `json:"years_since_graduation,omitempty"`
 }
 
 type CreateAlumniRequest struct {
-    NIM        string  `json:"nim" validate:"required"`
+    NIM        string  `json:"nim" validate:"required,nim"`
     Nama       string  `json:"nama" validate:"required"`
     Jurusan    string  `json:"jurusan" validate:"required"`
-    Angkatan   int     `json:"angkatan" validate:"required"`
-    TahunLulus int     `json:"tahun_lulus" validate:"required"`
+    Angkatan   int     `json:"angkatan" validate:"required,gt=0"`
+    TahunLulus int     `json:"tahun_lulus" validate:"required,gtefield=Angkatan"`
     Email      string  `json:"email" validate:"required,email"`
     NoTelepon  *string `json:"no_telepon"`
     Alamat     *string `json:"alamat"`
@@ -33,9 +39,34 @@ type CreateAlumniRequest struct {
 type UpdateAlumniRequest struct {
     Nama       string  `json:"nama" validate:"required"`
     Jurusan    string  `json:"jurusan" validate:"required"`
-    Angkatan   int     `json:"angkatan" validate:"required"`
-    TahunLulus int     `json:"tahun_lulus" validate:"required"`
+    Angkatan   int     `json:"angkatan" validate:"required,gt=0"`
+    TahunLulus int     `json:"tahun_lulus" validate:"required,gtefield=Angkatan"`
     Email      string  `json:"email" validate:"required,email"`
     NoTelepon  *string `json:"no_telepon"`
     Alamat     *string `json:"alamat"`
-}
\ No newline at end of file
+}
+
+// AlumniFilter - filter terstruktur untuk GET /alumni, bisa digabung dengan search.
+// Tag json mengikuti nama query parameter dan dipakai untuk menampilkan filter aktif di MetaInfo.
+type AlumniFilter struct {
+    Jurusan           []string   `json:"jurusan,omitempty"`
+    AngkatanFrom      *int       `json:"angkatan_from,omitempty"`
+    AngkatanTo        *int       `json:"angkatan_to,omitempty"`
+    TahunLulusFrom    *int       `json:"tahun_lulus_from,omitempty"`
+    TahunLulusTo      *int       `json:"tahun_lulus_to,omitempty"`
+    HasPekerjaan      *bool      `json:"has_pekerjaan,omitempty"`      // punya pekerjaan (status apa pun) yang belum dihapus
+    CurrentlyEmployed *bool      `json:"currently_employed,omitempty"` // punya pekerjaan berstatus aktif
+    BidangIndustri    []string   `json:"bidang_industri,omitempty"`    // bidang industri pekerjaan aktif
+    CreatedFrom       *time.Time `json:"created_from,omitempty"`
+    CreatedTo         *time.Time `json:"created_to,omitempty"`
+    ExcludeDeleted    bool       `json:"-"` // tanpa alumni yang di-soft delete (is_deleted)
+}
+
+// Nilai parameter status GET /alumni/without-jobs
+const (
+    WithoutJobsNeverEmployed = "never_employed" // tidak pernah punya pekerjaan (yang belum dihapus)
+    WithoutJobsNoActiveJob   = "no_active_job"  // tidak punya pekerjaan berstatus aktif saat ini
+)
+
+// SortYearsSinceGraduation - sortBy turunan: tahun berjalan dikurangi tahun_lulus
+const SortYearsSinceGraduation = "years_since_graduation"
@@ -0,0 +1,38 @@
+package models
+
+// Alasan sepasang alumni dianggap kandidat duplikat
+const (
+	DuplicateReasonSimilarName = "similar_name" // kemiripan trigram nama >= min_similarity
+	DuplicateReasonSameEmail   = "same_email"   // email sama (tanpa huruf besar/kecil)
+	DuplicateReasonSamePhone   = "same_phone"   // digit nomor telepon sama (62 = 0)
+	DuplicateReasonSameCohort  = "same_cohort"  // angkatan dan jurusan sama, hanya penguat skor
+)
+
+// DuplicateCandidate - sepasang alumni yang kemungkinan data orang yang sama. Alumni selalu ID yang lebih kecil.
+type DuplicateCandidate struct {
+	Alumni         Alumni   `json:"alumni"`
+	Duplicate      Alumni   `json:"duplicate"`
+	Score          float64  `json:"score"`           // 0-1, gabungan kemiripan nama dan kecocokan email/telepon/angkatan
+	NameSimilarity float64  `json:"name_similarity"` // similarity() pg_trgm
+	Reasons        []string `json:"reasons"`
+}
+
+// DuplicateCandidateResponse - hasil akhir untuk GET /alumni/duplicates
+type DuplicateCandidateResponse struct {
+	Data []DuplicateCandidate `json:"data"`
+	Meta MetaInfo             `json:"meta"`
+}
+
+// MergeAlumniRequest - alumni SourceID digabung ke alumni tujuan (:id) lalu dihapus. Nilai field tujuan
+// dipertahankan kecuali field di KeepFromSource; no_telepon/alamat tujuan yang kosong diisi dari source.
+type MergeAlumniRequest struct {
+	SourceID       int      `json:"source_id" validate:"required,gt=0"`
+	KeepFromSource []string `json:"keep_from_source" validate:"dive,oneof=nim nama jurusan angkatan tahun_lulus email no_telepon alamat"`
+}
+
+// AlumniMergeResult - data yang dipindahkan dari alumni source ke alumni tujuan
+type AlumniMergeResult struct {
+	PekerjaanMoved       int64 `json:"pekerjaan_moved"`
+	SurveyResponsesMoved int64 `json:"survey_responses_moved"` // respons survey yang juga diisi tujuan tidak dipindah
+	UserMoved            bool  `json:"user_moved"`             // akun user source dipindah ke tujuan
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Jenis attachment
+const (
+	AttachmentKindPhoto    = "photo"    // foto profil alumni, maksimal satu per alumni
+	AttachmentKindDocument = "document" // dokumen pendukung pekerjaan
+)
+
+// Attachment - metadata file yang disimpan di storage. Isi file diunduh lewat URL, bukan lewat JSON.
+type Attachment struct {
+	ID           int       `json:"id"`
+	AlumniID     *int      `json:"alumni_id,omitempty"`
+	PekerjaanID  *int      `json:"pekerjaan_id,omitempty"`
+	Kind         string    `json:"kind"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	StorageKey   string    `json:"-"`
+	ThumbnailKey *string   `json:"-"`
+	UploadedBy   *int      `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Diisi service: path download file dan thumbnail (hanya untuk gambar)
+	URL          string  `json:"url"`
+	ThumbnailURL *string `json:"thumbnail_url"`
+}
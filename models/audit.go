@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog - satu baris audit trail (append-only)
+type AuditLog struct {
+	ID          int             `json:"id"`
+	ActorUserID *int            `json:"actor_user_id"`
+	ActorRole   *string         `json:"actor_role"`
+	Action      string          `json:"action"`
+	Entity      string          `json:"entity"`
+	EntityID    *int            `json:"entity_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	Changes     json.RawMessage `json:"changes"`
+	IPAddress   string          `json:"ip_address"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// AuditFilter - filter untuk endpoint GET /audit
+type AuditFilter struct {
+	ActorUserID *int
+	ActorRole   string
+	Action      string
+	Entity      string
+	EntityID    *int
+	From        *time.Time
+	To          *time.Time
+}
+
+// AuditLogResponse - hasil akhir untuk endpoint /audit
+type AuditLogResponse struct {
+	Data []AuditLog `json:"data"`
+	Meta MetaInfo   `json:"meta"`
+}
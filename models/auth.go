@@ -27,7 +27,7 @@ type RegisterRequest struct {
     Username string `json:"username" validate:"required"`
     Email    string `json:"email" validate:"required,email"`
     Password string `json:"password" validate:"required,min=6"`
-    Role     string `json:"role" validate:"oneof=admin user"`
+    Role     string `json:"role" validate:"omitempty,oneof=admin user"`
 }
 
 // Login response DTO
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// CareerTimeline - riwayat karier satu alumni, dihitung dari pekerjaan yang belum dihapus
+type CareerTimeline struct {
+	Alumni               *Alumni           `json:"alumni"`
+	Entries              []CareerEntry     `json:"entries"`
+	Gaps                 []CareerGap       `json:"gaps"`
+	Overlaps             []CareerOverlap   `json:"overlaps"`
+	TotalExperienceDays  int               `json:"total_experience_days"`
+	TotalExperienceYears float64           `json:"total_experience_years"`
+	CurrentPosition      *PekerjaanAlumni  `json:"current_position"`
+	CurrentPositions     []PekerjaanAlumni `json:"current_positions"`
+	Warnings             []string          `json:"warnings"`
+}
+
+// CareerEntry - satu pekerjaan pada timeline. End adalah tanggal selesai efektif
+// (hari ini untuk pekerjaan aktif tanpa tanggal selesai).
+type CareerEntry struct {
+	Pekerjaan    PekerjaanAlumni `json:"pekerjaan"`
+	Start        time.Time       `json:"start"`
+	End          time.Time       `json:"end"`
+	Ongoing      bool            `json:"ongoing"`
+	DurationDays int             `json:"duration_days"`
+}
+
+// CareerGap - periode tanpa pekerjaan di antara dua pekerjaan (Start dan End termasuk hari gap)
+type CareerGap struct {
+	Start             time.Time `json:"start"`
+	End               time.Time `json:"end"`
+	Days              int       `json:"days"`
+	AfterPekerjaanID  int       `json:"after_pekerjaan_id"`
+	BeforePekerjaanID int       `json:"before_pekerjaan_id"`
+}
+
+// CareerOverlap - periode ketika dua pekerjaan berjalan bersamaan
+type CareerOverlap struct {
+	FirstPekerjaanID  int       `json:"first_pekerjaan_id"`
+	SecondPekerjaanID int       `json:"second_pekerjaan_id"`
+	Start             time.Time `json:"start"`
+	End               time.Time `json:"end"`
+	Days              int       `json:"days"`
+}
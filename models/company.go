@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Cara pekerjaan dihubungkan ke master data perusahaan
+const (
+	CompanyMatchByID    = "id"
+	CompanyMatchExact   = "exact"
+	CompanyMatchAlias   = "alias"
+	CompanyMatchFuzzy   = "fuzzy"
+	CompanyMatchCreated = "created"
+)
+
+// Company - master data perusahaan tempat alumni bekerja
+type Company struct {
+	ID             int            `json:"id"`
+	Nama           string         `json:"nama"`
+	BidangIndustri *string        `json:"bidang_industri"`
+	Lokasi         *string        `json:"lokasi"`
+	JumlahAlumni   int            `json:"jumlah_alumni"`
+	Aliases        []CompanyAlias `json:"aliases"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// CompanyAlias - nama lain yang dipakai saat input pekerjaan ("Telkom", "PT Telkom")
+type CompanyAlias struct {
+	ID        int       `json:"id"`
+	CompanyID int       `json:"company_id"`
+	Alias     string    `json:"alias"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CompanyMatch - hasil pencocokan nama perusahaan (Score 1 untuk exact/alias)
+type CompanyMatch struct {
+	Company *Company `json:"company"`
+	Method  string   `json:"method"`
+	Score   float64  `json:"score"`
+}
+
+type CreateCompanyRequest struct {
+	Nama           string   `json:"nama" validate:"required"`
+	BidangIndustri *string  `json:"bidang_industri"`
+	Lokasi         *string  `json:"lokasi"`
+	Aliases        []string `json:"aliases"`
+}
+
+type UpdateCompanyRequest struct {
+	Nama           string  `json:"nama" validate:"required"`
+	BidangIndustri *string `json:"bidang_industri"`
+	Lokasi         *string `json:"lokasi"`
+}
+
+type AddCompanyAliasRequest struct {
+	Alias string `json:"alias" validate:"required"`
+}
+
+// MergeCompaniesRequest - perusahaan di SourceIDs digabung ke perusahaan tujuan (:id)
+type MergeCompaniesRequest struct {
+	SourceIDs []int `json:"source_ids" validate:"required,min=1,dive,gt=0"`
+}
+
+// CompanyResponse - hasil akhir untuk endpoint /companies
+type CompanyResponse struct {
+	Data []Company `json:"data"`
+	Meta MetaInfo  `json:"meta"`
+}
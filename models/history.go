@@ -0,0 +1,21 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RecordVersion - satu versi baris alumni/pekerjaan_alumni dari tabel *_versions
+type RecordVersion struct {
+	Version    int             `json:"version"`
+	Operation  string          `json:"operation"`
+	Data       json.RawMessage `json:"data"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	RecordedAt time.Time       `json:"recorded_at"`
+}
+
+// RecordHistoryResponse - hasil akhir untuk endpoint /alumni/:id/history dan /pekerjaan/:id/history
+type RecordHistoryResponse struct {
+	EntityID int             `json:"entity_id"`
+	Versions []RecordVersion `json:"versions"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IdempotencyRecord - request yang pernah dikirim dengan Idempotency-Key beserta response-nya
+type IdempotencyRecord struct {
+	UserID       int
+	Key          string
+	Fingerprint  string // sha256 dari method, path, dan body
+	StatusCode   *int   // nil selama request pertama masih diproses
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -2,14 +2,25 @@ package models
 
 import "time"
 
+// Status pekerjaan. Transisi yang diizinkan: aktif -> selesai/resigned, serta koreksi selesai <-> resigned.
+const (
+    StatusPekerjaanAktif    = "aktif"
+    StatusPekerjaanSelesai  = "selesai"
+    StatusPekerjaanResigned = "resigned"
+)
+
 type PekerjaanAlumni struct {
     ID                   int       `json:"id"`
     AlumniID            int       `json:"alumni_id"`
     NamaPerusahaan      string    `json:"nama_perusahaan"`
+    CompanyID           *int      `json:"company_id"`
     PosisiJabatan       string    `json:"posisi_jabatan"`
     BidangIndustri      string    `json:"bidang_industri"`
     LokasiKerja         string    `json:"lokasi_kerja"`
     GajiRange           *string   `json:"gaji_range"`
+    GajiMin             *int64    `json:"gaji_min"`
+    GajiMax             *int64    `json:"gaji_max"`
+    GajiCurrency        *string   `json:"gaji_currency"`
     TanggalMulaiKerja   time.Time `json:"tanggal_mulai_kerja"`
     TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
     StatusPekerjaan     string    `json:"status_pekerjaan"`
@@ -20,15 +31,23 @@ type PekerjaanAlumni struct {
     
     // Join dengan alumni
     Alumni *Alumni `json:"alumni,omitempty"`
+
+    // Hanya terisi pada hasil pencarian (search)
+    Score      *float64          `json:"score,omitempty"`
+    Highlights map[string]string `json:"highlights,omitempty"`
 }
 
 type CreatePekerjaanRequest struct {
-    AlumniID            int        `json:"alumni_id" validate:"required"`
+    AlumniID            int        `json:"alumni_id" validate:"required,gt=0"`
     NamaPerusahaan      string     `json:"nama_perusahaan" validate:"required"`
+    CompanyID           *int       `json:"company_id"` // opsional, jika kosong dicocokkan dari nama_perusahaan
     PosisiJabatan       string     `json:"posisi_jabatan" validate:"required"`
     BidangIndustri      string     `json:"bidang_industri" validate:"required"`
     LokasiKerja         string     `json:"lokasi_kerja" validate:"required"`
-    GajiRange           *string    `json:"gaji_range"`
+    GajiRange           *string    `json:"gaji_range"` // teks lama, diparse jika gaji_min/gaji_max kosong
+    GajiMin             *int64     `json:"gaji_min"`
+    GajiMax             *int64     `json:"gaji_max"`
+    GajiCurrency        *string    `json:"gaji_currency"`
     TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja" validate:"required"`
     TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
     StatusPekerjaan     string     `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
@@ -37,12 +56,41 @@ type CreatePekerjaanRequest struct {
 
 type UpdatePekerjaanRequest struct {
     NamaPerusahaan      string     `json:"nama_perusahaan" validate:"required"`
+    CompanyID           *int       `json:"company_id"` // opsional, jika kosong dicocokkan dari nama_perusahaan
     PosisiJabatan       string     `json:"posisi_jabatan" validate:"required"`
     BidangIndustri      string     `json:"bidang_industri" validate:"required"`
     LokasiKerja         string     `json:"lokasi_kerja" validate:"required"`
-    GajiRange           *string    `json:"gaji_range"`
+    GajiRange           *string    `json:"gaji_range"` // teks lama, diparse jika gaji_min/gaji_max kosong
+    GajiMin             *int64     `json:"gaji_min"`
+    GajiMax             *int64     `json:"gaji_max"`
+    GajiCurrency        *string    `json:"gaji_currency"`
     TanggalMulaiKerja   time.Time  `json:"tanggal_mulai_kerja" validate:"required"`
     TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
     StatusPekerjaan     string     `json:"status_pekerjaan" validate:"required,oneof=aktif selesai resigned"`
     DeskripsiPekerjaan  *string    `json:"deskripsi_pekerjaan"`
-}
\ No newline at end of file
+}
+
+// EndPekerjaanRequest - akhiri pekerjaan aktif (default status selesai, tanggal selesai hari ini)
+type EndPekerjaanRequest struct {
+    StatusPekerjaan     string     `json:"status_pekerjaan" validate:"omitempty,oneof=selesai resigned"`
+    TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja"`
+}
+
+// PekerjaanFilter - filter tambahan untuk GET /pekerjaan dan /pekerjaan/trash.
+// Tag json mengikuti nama query parameter dan dipakai untuk menampilkan filter aktif di MetaInfo.
+type PekerjaanFilter struct {
+    GajiMin            *int64     `json:"gaji_min,omitempty"`    // pekerjaan dengan rentang gaji yang beririsan dengan [GajiMin, GajiMax]
+    GajiMax            *int64     `json:"gaji_max,omitempty"`
+    GajiCurrency       string     `json:"gaji_currency,omitempty"`
+    StatusPekerjaan    []string   `json:"status_pekerjaan,omitempty"`
+    BidangIndustri     []string   `json:"bidang_industri,omitempty"`
+    LokasiKerja        []string   `json:"lokasi_kerja,omitempty"`
+    TanggalMulaiFrom   *time.Time `json:"tanggal_mulai_from,omitempty"`
+    TanggalMulaiTo     *time.Time `json:"tanggal_mulai_to,omitempty"`
+    TanggalSelesaiFrom *time.Time `json:"tanggal_selesai_from,omitempty"`
+    TanggalSelesaiTo   *time.Time `json:"tanggal_selesai_to,omitempty"`
+    Jurusan            []string   `json:"jurusan,omitempty"`     // jurusan alumni
+    AngkatanFrom       *int       `json:"angkatan_from,omitempty"`
+    AngkatanTo         *int       `json:"angkatan_to,omitempty"`
+    EmployedAt         *time.Time `json:"employed_at,omitempty"` // pekerjaan yang sedang berjalan pada tanggal tersebut
+}
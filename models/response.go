@@ -2,13 +2,14 @@ package models
 
 // MetaInfo - informasi pagination & filter
 type MetaInfo struct {
-    Page   int    `json:"page"`
-    Limit  int    `json:"limit"`
-    Total  int    `json:"total"`
-    Pages  int    `json:"pages"`
-    SortBy string `json:"sortBy"`
-    Order  string `json:"order"`
-    Search string `json:"search"`
+    Page    int         `json:"page"`
+    Limit   int         `json:"limit"`
+    Total   int         `json:"total"`
+    Pages   int         `json:"pages"`
+    SortBy  string      `json:"sortBy"`
+    Order   string      `json:"order"`
+    Search  string      `json:"search"`
+    Filters interface{} `json:"filters,omitempty"` // filter terstruktur yang sedang aktif
 }
 
 // AlumniResponse - hasil akhir untuk endpoint /alumni
@@ -22,3 +23,26 @@ type PekerjaanResponse struct {
     Data []PekerjaanAlumni `json:"data"`
     Meta MetaInfo          `json:"meta"`
 }
+
+// CursorMetaInfo - informasi pagination cursor (keyset); tanpa page/total agar tidak perlu COUNT(*)
+type CursorMetaInfo struct {
+    Limit   int         `json:"limit"`
+    SortBy  string      `json:"sortBy"`
+    Order   string      `json:"order"`
+    Search  string      `json:"search"`
+    Filters interface{} `json:"filters,omitempty"`
+    Next    *string     `json:"next"` // cursor halaman berikutnya, null jika sudah di akhir
+    Prev    *string     `json:"prev"` // cursor halaman sebelumnya, null jika di halaman pertama
+}
+
+// AlumniCursorResponse - hasil endpoint /alumni dengan ?cursor=
+type AlumniCursorResponse struct {
+    Data []Alumni       `json:"data"`
+    Meta CursorMetaInfo `json:"meta"`
+}
+
+// PekerjaanCursorResponse - hasil endpoint /pekerjaan dan /pekerjaan/trash dengan ?cursor=
+type PekerjaanCursorResponse struct {
+    Data []PekerjaanAlumni `json:"data"`
+    Meta CursorMetaInfo    `json:"meta"`
+}
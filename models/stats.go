@@ -0,0 +1,61 @@
+package models
+
+// StatsFilter - filter alumni yang dipakai semua endpoint /stats
+type StatsFilter struct {
+	Jurusan        string
+	Angkatan       *int
+	TahunLulus     *int
+	TahunLulusFrom *int
+	TahunLulusTo   *int
+}
+
+// EmploymentRateStat - tingkat keterserapan kerja per kelompok
+type EmploymentRateStat struct {
+	Group           string  `json:"group"`
+	TotalAlumni     int     `json:"total_alumni"`
+	Employed        int     `json:"employed"`
+	CurrentlyActive int     `json:"currently_active"`
+	EmploymentRate  float64 `json:"employment_rate"`
+	ActiveRate      float64 `json:"active_rate"`
+}
+
+// TimeToFirstJobStat - waktu tunggu (bulan) dari tahun lulus sampai pekerjaan pertama
+type TimeToFirstJobStat struct {
+	Group            string  `json:"group"`
+	Alumni           int     `json:"alumni"`
+	AvgMonths        float64 `json:"avg_months"`
+	MedianMonths     float64 `json:"median_months"`
+	MinMonths        float64 `json:"min_months"`
+	MaxMonths        float64 `json:"max_months"`
+	BeforeGraduation int     `json:"before_graduation"`
+}
+
+// DistributionStat - sebaran pekerjaan berdasarkan bidang_industri / lokasi_kerja
+type DistributionStat struct {
+	Value  string  `json:"value"`
+	Jobs   int     `json:"jobs"`
+	Alumni int     `json:"alumni"`
+	Share  float64 `json:"share"`
+}
+
+// SalaryStat - sebaran gaji (titik tengah rentang gaji_min..gaji_max) per kelompok, satu mata uang
+type SalaryStat struct {
+	Group    string  `json:"group"`
+	Currency string  `json:"currency"`
+	Jobs     int     `json:"jobs"`
+	Alumni   int     `json:"alumni"`
+	Min      float64 `json:"min"`
+	P25      float64 `json:"p25"`
+	Median   float64 `json:"median"`
+	P75      float64 `json:"p75"`
+	P90      float64 `json:"p90"`
+	Max      float64 `json:"max"`
+	Avg      float64 `json:"avg"`
+}
+
+// ActiveShareStat - porsi alumni yang saat ini memiliki pekerjaan berstatus aktif
+type ActiveShareStat struct {
+	TotalAlumni     int     `json:"total_alumni"`
+	CurrentlyActive int     `json:"currently_active"`
+	Share           float64 `json:"share"`
+}
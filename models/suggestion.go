@@ -0,0 +1,21 @@
+package models
+
+// Field yang punya endpoint saran (GET /suggestions/:field)
+const (
+	SuggestionJurusan        = "jurusan"
+	SuggestionNamaPerusahaan = "nama_perusahaan"
+	SuggestionPosisiJabatan  = "posisi_jabatan"
+	SuggestionBidangIndustri = "bidang_industri"
+	SuggestionLokasiKerja    = "lokasi_kerja"
+)
+
+// SuggestionFields - urutan field saran untuk validasi dan dokumentasi
+var SuggestionFields = []string{
+	SuggestionJurusan, SuggestionNamaPerusahaan, SuggestionPosisiJabatan, SuggestionBidangIndustri, SuggestionLokasiKerja,
+}
+
+// Suggestion - satu nilai yang sudah dipakai beserta jumlah pemakaiannya
+type Suggestion struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
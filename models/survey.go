@@ -0,0 +1,134 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status kuesioner tracer study
+const (
+	SurveyStatusDraft  = "draft"
+	SurveyStatusOpen   = "open"
+	SurveyStatusClosed = "closed"
+)
+
+// Status jawaban alumni
+const (
+	SurveyResponseInProgress = "in_progress"
+	SurveyResponseSubmitted  = "submitted"
+)
+
+// Tipe pertanyaan
+const (
+	QuestionTypeText           = "text"
+	QuestionTypeTextarea       = "textarea"
+	QuestionTypeNumber         = "number"
+	QuestionTypeScale          = "scale"
+	QuestionTypeDate           = "date"
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleChoice = "multiple_choice"
+)
+
+// Survey - kuesioner tracer study
+type Survey struct {
+	ID             int              `json:"id"`
+	Judul          string           `json:"judul"`
+	Deskripsi      *string          `json:"deskripsi"`
+	Status         string           `json:"status"`
+	TargetAngkatan []int            `json:"target_angkatan"`
+	TargetJurusan  []string         `json:"target_jurusan"`
+	OpensAt        *time.Time       `json:"opens_at"`
+	ClosesAt       *time.Time       `json:"closes_at"`
+	CreatedBy      *int             `json:"created_by"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	Questions      []SurveyQuestion `json:"questions,omitempty"`
+}
+
+// SurveyQuestion - satu pertanyaan, Kode unik per survey dan dipakai untuk branching
+type SurveyQuestion struct {
+	ID         int                `json:"id"`
+	SurveyID   int                `json:"survey_id"`
+	Urutan     int                `json:"urutan"`
+	Kode       string             `json:"kode"`
+	Pertanyaan string             `json:"pertanyaan"`
+	Tipe       string             `json:"tipe"`
+	Options    []string           `json:"options,omitempty"`
+	MinValue   *float64           `json:"min_value,omitempty"`
+	MaxValue   *float64           `json:"max_value,omitempty"`
+	Wajib      bool               `json:"wajib"`
+	ShowIf     *QuestionCondition `json:"show_if,omitempty"`
+}
+
+// QuestionCondition - pertanyaan hanya tampil jika jawaban pertanyaan lain memenuhi kondisi
+// Operator: equals, not_equals, in, answered
+type QuestionCondition struct {
+	Question string          `json:"question"`
+	Operator string          `json:"operator"`
+	Value    json.RawMessage `json:"value,omitempty"`
+}
+
+// SurveyResponse - jawaban satu alumni untuk satu survey
+type SurveyResponse struct {
+	ID          int                        `json:"id"`
+	SurveyID    int                        `json:"survey_id"`
+	AlumniID    int                        `json:"alumni_id"`
+	Status      string                     `json:"status"`
+	SubmittedAt *time.Time                 `json:"submitted_at"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+	Answers     map[string]json.RawMessage `json:"answers"`
+
+	// Join dengan alumni (untuk export)
+	Alumni *Alumni `json:"alumni,omitempty"`
+}
+
+// SurveyCompletion - rekap progres pengisian survey
+type SurveyCompletion struct {
+	SurveyID       int                     `json:"survey_id"`
+	TargetAlumni   int                     `json:"target_alumni"`
+	Submitted      int                     `json:"submitted"`
+	InProgress     int                     `json:"in_progress"`
+	NotStarted     int                     `json:"not_started"`
+	CompletionRate float64                 `json:"completion_rate"`
+	Breakdown      []SurveyCompletionGroup `json:"breakdown"`
+}
+
+// SurveyCompletionGroup - rekap progres per jurusan dan angkatan
+type SurveyCompletionGroup struct {
+	Jurusan        string  `json:"jurusan"`
+	Angkatan       int     `json:"angkatan"`
+	TargetAlumni   int     `json:"target_alumni"`
+	Submitted      int     `json:"submitted"`
+	InProgress     int     `json:"in_progress"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+type CreateSurveyRequest struct {
+	Judul          string                  `json:"judul" validate:"required"`
+	Deskripsi      *string                 `json:"deskripsi"`
+	TargetAngkatan []int                   `json:"target_angkatan"`
+	TargetJurusan  []string                `json:"target_jurusan"`
+	OpensAt        *time.Time              `json:"opens_at"`
+	ClosesAt       *time.Time              `json:"closes_at"`
+	Questions      []SurveyQuestionRequest `json:"questions" validate:"required,min=1,dive"`
+}
+
+type UpdateSurveyRequest = CreateSurveyRequest
+
+type SurveyQuestionRequest struct {
+	Kode       string             `json:"kode" validate:"required"`
+	Pertanyaan string             `json:"pertanyaan" validate:"required"`
+	Tipe       string             `json:"tipe" validate:"required,oneof=text textarea number scale date single_choice multiple_choice"`
+	Options    []string           `json:"options"`
+	MinValue   *float64           `json:"min_value"`
+	MaxValue   *float64           `json:"max_value"`
+	Wajib      bool               `json:"wajib"`
+	ShowIf     *QuestionCondition `json:"show_if"`
+}
+
+// SubmitSurveyResponseRequest - jawaban dikirim per kode pertanyaan
+type SubmitSurveyResponseRequest struct {
+	Answers map[string]json.RawMessage `json:"answers"`
+	Submit  bool                       `json:"submit"`
+}
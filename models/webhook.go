@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event webhook. data pada payload berisi data setelah perubahan; untuk *.deleted dan *.soft_deleted
+// berisi data terakhir sebelum dihapus.
+const (
+	WebhookEventAlumniCreated        = "alumni.created"
+	WebhookEventAlumniUpdated        = "alumni.updated" // PUT, PATCH, revert
+	WebhookEventAlumniDeleted        = "alumni.deleted"
+	WebhookEventAlumniMerged         = "alumni.merged" // data: {source, target}
+	WebhookEventPekerjaanCreated     = "pekerjaan.created"
+	WebhookEventPekerjaanUpdated     = "pekerjaan.updated" // PUT, PATCH, revert, end
+	WebhookEventPekerjaanSoftDeleted = "pekerjaan.soft_deleted"
+	WebhookEventPekerjaanRestored    = "pekerjaan.restored"
+	WebhookEventPekerjaanDeleted     = "pekerjaan.deleted" // hapus permanen
+)
+
+// Status delivery webhook
+const (
+	WebhookDeliveryPending = "pending" // menunggu dikirim atau dicoba ulang pada next_attempt_at
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed" // percobaan habis
+)
+
+// WebhookSubscription - endpoint penerima event. Secret hanya dikirim sekali saat subscription dibuat.
+type WebhookSubscription struct {
+	ID          int       `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Secret      string    `json:"secret,omitempty"`
+	Description *string   `json:"description"`
+	IsActive    bool      `json:"is_active"`
+	CreatedBy   *int      `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,max=2000"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=alumni.created alumni.updated alumni.deleted alumni.merged pekerjaan.created pekerjaan.updated pekerjaan.soft_deleted pekerjaan.restored pekerjaan.deleted"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	IsActive    *bool    `json:"is_active"` // default true
+}
+
+type UpdateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,max=2000"`
+	Events      []string `json:"events" validate:"required,min=1,dive,oneof=alumni.created alumni.updated alumni.deleted alumni.merged pekerjaan.created pekerjaan.updated pekerjaan.soft_deleted pekerjaan.restored pekerjaan.deleted"`
+	Description *string  `json:"description" validate:"omitempty,max=255"`
+	IsActive    bool     `json:"is_active"`
+}
+
+// WebhookPayload - body JSON yang dikirim ke penerima. ID sama untuk pengiriman ulang event yang sama.
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookDelivery - satu event untuk satu subscription beserta hasil percobaan terakhir
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int             `json:"subscription_id"`
+	Event          string          `json:"event"`
+	EventID        string          `json:"event_id"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code"`
+	LastError      *string         `json:"last_error"`
+	LastResponse   *string         `json:"last_response"` // body response penerima, dipotong
+	RedeliveryOf   *int64          `json:"redelivery_of"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+
+	// Hanya terisi saat delivery diambil dispatcher
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookAttempt - hasil satu percobaan pengiriman
+type WebhookAttempt struct {
+	Status        string
+	NextAttemptAt *time.Time
+	StatusCode    *int
+	Error         *string
+	Response      *string
+}
+
+// WebhookDeliveryResponse - hasil akhir untuk GET /webhooks/:id/deliveries
+type WebhookDeliveryResponse struct {
+	Data []WebhookDelivery `json:"data"`
+	Meta MetaInfo          `json:"meta"`
+}
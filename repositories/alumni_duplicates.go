@@ -0,0 +1,300 @@
+package repositories
+
+import (
+	"alumni-management-system/models"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrAlumniUserConflict - alumni yang digabung terhubung ke akun user yang berbeda
+var ErrAlumniUserConflict = errors.New("alumni are linked to different users")
+
+// ActivePekerjaanConflictError - kedua alumni yang digabung masih punya pekerjaan aktif (aturan satu pekerjaan aktif)
+type ActivePekerjaanConflictError struct {
+	ActiveIDs []int // pekerjaan aktif milik tujuan lalu source
+}
+
+func (e *ActivePekerjaanConflictError) Error() string {
+	return fmt.Sprintf("both alumni have active pekerjaan %v", e.ActiveIDs)
+}
+
+// MinDuplicateNameSimilarity - batas bawah min_similarity: operator % memakai pg_trgm.similarity_threshold
+// (default 0.3) untuk memilih pasangan lewat index, sehingga nilai di bawahnya tidak akan pernah muncul
+const MinDuplicateNameSimilarity = 0.3
+
+// alumniMergeColumns - kolom tujuan yang bisa diisi dari alumni source saat merge (user_id diisi oleh Merge sendiri)
+var alumniMergeColumns = map[string]bool{
+	"nim": true, "nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true,
+	"email": true, "no_telepon": true, "alamat": true, "user_id": true,
+}
+
+// FindDuplicates - pasangan alumni (belum dihapus) dengan nama mirip, email sama, atau nomor telepon sama,
+// diurutkan dari skor tertinggi. alumniID > 0 membatasi ke pasangan yang melibatkan alumni tersebut.
+// Skor = 0.5 x kemiripan nama + 0.25 email sama + 0.15 telepon sama + 0.1 angkatan dan jurusan sama.
+func (r *alumniRepository) FindDuplicates(minSimilarity float64, alumniID, limit, offset int) ([]models.DuplicateCandidate, int, error) {
+	query := `
+		WITH candidates AS (
+			SELECT a.id AS alumni_id, b.id AS duplicate_id FROM alumni a
+			JOIN alumni b ON b.nama % a.nama AND a.id < b.id
+			WHERE $2 IN (0, a.id, b.id)
+			UNION
+			SELECT a.id, b.id FROM alumni a
+			JOIN alumni b ON lower(btrim(b.email)) = lower(btrim(a.email)) AND a.id < b.id
+			WHERE $2 IN (0, a.id, b.id)
+			UNION
+			SELECT a.id, b.id FROM alumni a
+			JOIN alumni b ON alumni_phone_key(b.no_telepon) = alumni_phone_key(a.no_telepon) AND a.id < b.id
+			WHERE alumni_phone_key(a.no_telepon) <> '' AND $2 IN (0, a.id, b.id)
+		), scored AS (
+			SELECT c.alumni_id, c.duplicate_id,
+			       similarity(a.nama, b.nama) AS name_similarity,
+			       lower(btrim(a.email)) = lower(btrim(b.email)) AS same_email,
+			       alumni_phone_key(a.no_telepon) <> '' AND alumni_phone_key(a.no_telepon) = alumni_phone_key(b.no_telepon) AS same_phone,
+			       a.angkatan = b.angkatan AND lower(btrim(a.jurusan)) = lower(btrim(b.jurusan)) AS same_cohort
+			FROM candidates c
+			JOIN alumni a ON a.id = c.alumni_id
+			JOIN alumni b ON b.id = c.duplicate_id
+			WHERE a.is_deleted = FALSE AND b.is_deleted = FALSE
+		)
+		SELECT alumni_id, duplicate_id, name_similarity, same_email, same_phone, same_cohort,
+		       0.5 * name_similarity + 0.25 * same_email::int + 0.15 * same_phone::int + 0.1 * same_cohort::int AS score,
+		       COUNT(*) OVER () AS total
+		FROM scored
+		WHERE same_email OR same_phone OR name_similarity >= $1
+		ORDER BY score DESC, alumni_id, duplicate_id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(query, minSimilarity, alumniID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	type pair struct {
+		alumniID, duplicateID int
+		candidate             models.DuplicateCandidate
+	}
+	var pairs []pair
+	var ids []int64
+	total := 0
+	for rows.Next() {
+		var p pair
+		var sameEmail, samePhone, sameCohort bool
+		if err := rows.Scan(&p.alumniID, &p.duplicateID, &p.candidate.NameSimilarity, &sameEmail, &samePhone, &sameCohort,
+			&p.candidate.Score, &total); err != nil {
+			return nil, 0, err
+		}
+
+		p.candidate.Reasons = []string{}
+		if p.candidate.NameSimilarity >= minSimilarity {
+			p.candidate.Reasons = append(p.candidate.Reasons, models.DuplicateReasonSimilarName)
+		}
+		if sameEmail {
+			p.candidate.Reasons = append(p.candidate.Reasons, models.DuplicateReasonSameEmail)
+		}
+		if samePhone {
+			p.candidate.Reasons = append(p.candidate.Reasons, models.DuplicateReasonSamePhone)
+		}
+		if sameCohort {
+			p.candidate.Reasons = append(p.candidate.Reasons, models.DuplicateReasonSameCohort)
+		}
+		pairs = append(pairs, p)
+		ids = append(ids, int64(p.alumniID), int64(p.duplicateID))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	alumni, err := r.getByIDs(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	candidates := make([]models.DuplicateCandidate, 0, len(pairs))
+	for _, p := range pairs {
+		p.candidate.Alumni, p.candidate.Duplicate = alumni[p.alumniID], alumni[p.duplicateID]
+		candidates = append(candidates, p.candidate)
+	}
+	return candidates, total, nil
+}
+
+// getByIDs - alumni berdasarkan ID (kolom sama dengan GetByID), kunci = ID
+func (r *alumniRepository) getByIDs(ids []int64) (map[int]models.Alumni, error) {
+	result := map[int]models.Alumni{}
+	if len(ids) == 0 {
+		return result, nil
+	}
+	rows, err := r.db.Query(`
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email,
+		       no_telepon, alamat, created_at, updated_at
+		FROM alumni
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var alumni models.Alumni
+		if err := rows.Scan(
+			&alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
+			&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+			&alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result[alumni.ID] = alumni
+	}
+	return result, rows.Err()
+}
+
+// Merge - dalam satu transaksi: pindahkan pekerjaan (termasuk yang di trash), respons survey yang belum diisi
+// tujuan, foto (jika tujuan belum punya), dan akun user dari sourceID ke targetID, hapus alumni source, lalu isi kolom tujuan dari changes
+// (kunci = nama kolom, lihat alumniMergeColumns). Source dihapus lebih dulu agar nim/email yang diambil dari
+// source tidak bentrok dengan constraint unik. Return ErrStaleRecord jika updated_at salah satu alumni sudah berbeda
+// dari yang diharapkan, ErrAlumniUserConflict jika keduanya punya akun user berbeda, dan *ActivePekerjaanConflictError
+// jika keduanya punya pekerjaan aktif sementara allowMultipleActive false.
+func (r *alumniRepository) Merge(targetID, sourceID int, targetUpdatedAt, sourceUpdatedAt time.Time, allowMultipleActive bool, changes map[string]interface{}) (*models.AlumniMergeResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Kunci kedua baris agar tidak ada perubahan lain selama merge
+	var targetUserID, sourceUserID *int
+	stale := false
+	rows, err := tx.Query(`SELECT id, user_id, updated_at FROM alumni WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`, targetID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	found := 0
+	for rows.Next() {
+		var id int
+		var userID *int
+		var updatedAt time.Time
+		if err := rows.Scan(&id, &userID, &updatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if id == targetID {
+			targetUserID = userID
+			stale = stale || !updatedAt.Equal(targetUpdatedAt)
+		} else {
+			sourceUserID = userID
+			stale = stale || !updatedAt.Equal(sourceUpdatedAt)
+		}
+		found++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if found < 2 {
+		return nil, nil
+	}
+	if stale {
+		return nil, ErrStaleRecord
+	}
+	if targetUserID != nil && sourceUserID != nil && *targetUserID != *sourceUserID {
+		return nil, ErrAlumniUserConflict
+	}
+	if !allowMultipleActive {
+		activeIDs, err := lockActivePekerjaan(tx, targetID, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if len(activeIDs[targetID]) > 0 && len(activeIDs[sourceID]) > 0 {
+			return nil, &ActivePekerjaanConflictError{ActiveIDs: append(activeIDs[targetID], activeIDs[sourceID]...)}
+		}
+	}
+
+	now := time.Now()
+	result := &models.AlumniMergeResult{}
+	moved, err := tx.Exec(`UPDATE pekerjaan_alumni SET alumni_id = $1, updated_at = $2 WHERE alumni_id = $3`, targetID, now, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	result.PekerjaanMoved, _ = moved.RowsAffected()
+
+	// Respons untuk survey yang juga sudah diisi tujuan ikut terhapus bersama source (ON DELETE CASCADE)
+	moved, err = tx.Exec(`
+		UPDATE survey_responses SET alumni_id = $1, updated_at = $2
+		WHERE alumni_id = $3 AND survey_id NOT IN (SELECT survey_id FROM survey_responses WHERE alumni_id = $1)
+	`, targetID, now, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	result.SurveyResponsesMoved, _ = moved.RowsAffected()
+
+	// Foto source yang tidak dipindah menjadi yatim saat source dihapus dan dibersihkan attachment service
+	if _, err := tx.Exec(`
+		UPDATE attachments SET alumni_id = $1
+		WHERE alumni_id = $2 AND kind = 'photo'
+		  AND NOT EXISTS (SELECT 1 FROM attachments WHERE alumni_id = $1 AND kind = 'photo')
+	`, targetID, sourceID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM alumni WHERE id = $1`, sourceID); err != nil {
+		return nil, err
+	}
+
+	columns := map[string]interface{}{}
+	for column, value := range changes {
+		columns[column] = value
+	}
+	if targetUserID == nil && sourceUserID != nil {
+		columns["user_id"] = *sourceUserID
+		result.UserMoved = true
+	}
+	if len(columns) > 0 {
+		query, args, err := patchQuery("alumni", alumniMergeColumns, targetID, targetUpdatedAt, columns)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(query, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	invalidateSuggestions("alumni", "pekerjaan_alumni")
+	return result, nil
+}
+
+// lockActivePekerjaan - kunci semua pekerjaan kedua alumni sampai transaksi selesai, sehingga pekerjaan yang
+// diaktifkan request lain menunggu merge (lalu gagal karena updated_at berubah). Return ID pekerjaan aktif per alumni.
+func lockActivePekerjaan(tx *sql.Tx, targetID, sourceID int) (map[int][]int, error) {
+	rows, err := tx.Query(`
+		SELECT id, alumni_id, status_pekerjaan = 'aktif' AND is_deleted = FALSE
+		FROM pekerjaan_alumni
+		WHERE alumni_id IN ($1, $2)
+		ORDER BY tanggal_mulai_kerja ASC, id ASC
+		FOR UPDATE
+	`, targetID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activeIDs := map[int][]int{}
+	for rows.Next() {
+		var id, alumniID int
+		var active bool
+		if err := rows.Scan(&id, &alumniID, &active); err != nil {
+			return nil, err
+		}
+		if active {
+			activeIDs[alumniID] = append(activeIDs[alumniID], id)
+		}
+	}
+	return activeIDs, rows.Err()
+}
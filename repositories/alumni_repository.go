@@ -5,19 +5,26 @@ import (
 	"alumni-management-system/models"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type AlumniRepository interface {
     GetAll() ([]models.Alumni, error)
-    GetAllPaginated(search, sortBy, order string, limit, offset int) ([]models.Alumni, error) 
-    CountAlumni(search string) (int, error) 
+    GetAllPaginated(search, sortBy, order string, filter models.AlumniFilter, limit, offset int) ([]models.Alumni, error)
+    GetAllKeyset(search, sortBy, order string, filter models.AlumniFilter, page KeysetPage) ([]models.Alumni, []Keyset, error)
+    CountAlumni(search string, filter models.AlumniFilter) (int, error)
     GetByID(id int) (*models.Alumni, error)
     Create(alumni *models.CreateAlumniRequest) (*models.Alumni, error)
-    Update(id int, alumni *models.UpdateAlumniRequest) (*models.Alumni, error)
-    Delete(id int) error
-    GetAlumniWithoutPekerjaan() ([]models.Alumni, error) 
+    // Update, Patch, dan Delete hanya menulis jika updated_at masih sama dengan updatedAt (ErrStaleRecord jika tidak)
+    Update(id int, alumni *models.UpdateAlumniRequest, updatedAt time.Time) (*models.Alumni, error)
+    Patch(id int, changes map[string]interface{}, updatedAt time.Time) (*models.Alumni, error) // kunci changes = nama kolom
+    Delete(id int, updatedAt time.Time) error
     GetAlumniByUserID(userID int) (*models.Alumni, error)
+    FindDuplicates(minSimilarity float64, alumniID, limit, offset int) ([]models.DuplicateCandidate, int, error)
+    Merge(targetID, sourceID int, targetUpdatedAt, sourceUpdatedAt time.Time, allowMultipleActive bool, changes map[string]interface{}) (*models.AlumniMergeResult, error) // nil jika salah satu tidak ada
     
 
 }
@@ -85,48 +92,153 @@ func (r *alumniRepository) GetAll() ([]models.Alumni, error) {
 }
 
 
-// GetAllPaginated - ambil data alumni dengan pagination, search, dan sorting
-func (r *alumniRepository) GetAllPaginated(search, sortBy, order string, limit, offset int) ([]models.Alumni, error) {
+// buildAlumniFilter - kondisi tambahan dari AlumniFilter, placeholder dimulai setelah args yang sudah ada.
+// Nilai filter selalu dikirim sebagai parameter; hanya potongan SQL tetap di bawah yang disisipkan ke query.
+func buildAlumniFilter(filter models.AlumniFilter, args []interface{}) (string, []interface{}) {
+    var conditions []string
+    add := func(condition string, value interface{}) {
+        args = append(args, value)
+        conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+    }
+    exists := func(negate bool, condition string) {
+        if negate {
+            condition = "NOT " + condition
+        }
+        conditions = append(conditions, condition)
+    }
+
+    if filter.ExcludeDeleted {
+        conditions = append(conditions, "a.is_deleted = FALSE")
+    }
+    if len(filter.Jurusan) > 0 {
+        add("LOWER(TRIM(a.jurusan)) = ANY($%d)", pq.Array(lowerAll(filter.Jurusan)))
+    }
+    if filter.AngkatanFrom != nil {
+        add("a.angkatan >= $%d", *filter.AngkatanFrom)
+    }
+    if filter.AngkatanTo != nil {
+        add("a.angkatan <= $%d", *filter.AngkatanTo)
+    }
+    if filter.TahunLulusFrom != nil {
+        add("a.tahun_lulus >= $%d", *filter.TahunLulusFrom)
+    }
+    if filter.TahunLulusTo != nil {
+        add("a.tahun_lulus <= $%d", *filter.TahunLulusTo)
+    }
+    if filter.HasPekerjaan != nil {
+        exists(!*filter.HasPekerjaan, `EXISTS (
+            SELECT 1 FROM pekerjaan_alumni p
+            WHERE p.alumni_id = a.id AND p.is_deleted = FALSE)`)
+    }
+    if filter.CurrentlyEmployed != nil {
+        exists(!*filter.CurrentlyEmployed, `EXISTS (
+            SELECT 1 FROM pekerjaan_alumni p
+            WHERE p.alumni_id = a.id AND p.is_deleted = FALSE AND p.status_pekerjaan = 'aktif')`)
+    }
+    if len(filter.BidangIndustri) > 0 {
+        add(`EXISTS (
+            SELECT 1 FROM pekerjaan_alumni p
+            WHERE p.alumni_id = a.id AND p.is_deleted = FALSE AND p.status_pekerjaan = 'aktif'
+              AND LOWER(TRIM(p.bidang_industri)) = ANY($%d))`, pq.Array(lowerAll(filter.BidangIndustri)))
+    }
+    if filter.CreatedFrom != nil {
+        add("a.created_at >= $%d", *filter.CreatedFrom)
+    }
+    if filter.CreatedTo != nil {
+        add("a.created_at <= $%d", *filter.CreatedTo)
+    }
+
+    if len(conditions) == 0 {
+        return "", args
+    }
+    return " AND " + strings.Join(conditions, " AND "), args
+}
+
+func lowerAll(values []string) []string {
+    result := make([]string, len(values))
+    for i, value := range values {
+        result[i] = strings.ToLower(strings.TrimSpace(value))
+    }
+    return result
+}
+
+// GetAllPaginated - ambil data alumni dengan pagination, search (full-text + trigram), filter, dan sorting.
+// Jika search diisi, setiap alumni membawa skor relevansi dan cuplikan yang cocok.
+func (r *alumniRepository) GetAllPaginated(search, sortBy, order string, filter models.AlumniFilter, limit, offset int) ([]models.Alumni, error) {
+    where, args := buildAlumniFilter(filter, []interface{}{search})
+    args = append(args, limit, offset)
+    tail := fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", searchOrder(sortBy, order, alumniSortColumn(sortBy), "a.id"), len(args)-1, len(args))
+    alumniList, _, err := r.queryList(search, "a.id", where+tail, args)
+    return alumniList, err
+}
+
+// GetAllKeyset - sama seperti GetAllPaginated tetapi dengan keyset (cursor) dan tanpa OFFSET.
+// Mengembalikan sampai page.Limit+1 baris beserta posisi keyset tiap baris.
+func (r *alumniRepository) GetAllKeyset(search, sortBy, order string, filter models.AlumniFilter, page KeysetPage) ([]models.Alumni, []Keyset, error) {
+    where, args := buildAlumniFilter(filter, []interface{}{search})
+    tail, args := page.clause(alumniSortColumn(sortBy), "a.id", false, order, args)
+    return r.queryList(search, alumniSortColumn(sortBy), where+tail, args)
+}
+
+// alumniSortColumn - ekspresi ORDER BY untuk sortBy; kolom turunan (bukan kolom tabel) didefinisikan di sini
+func alumniSortColumn(sortBy string) string {
+    if sortBy == models.SortYearsSinceGraduation {
+        return "(date_part('year', CURRENT_DATE)::int - a.tahun_lulus)"
+    }
+    return "a." + sortBy
+}
+
+// queryList - SELECT daftar alumni dengan kolom pencarian; tail berisi kondisi tambahan, ORDER BY, dan LIMIT.
+// Nilai sortColumn ikut diambil sebagai teks untuk membentuk Keyset tiap baris.
+func (r *alumniRepository) queryList(search, sortColumn, tail string, args []interface{}) ([]models.Alumni, []Keyset, error) {
     query := fmt.Sprintf(`
-        SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, 
-               no_telepon, alamat, created_at, updated_at 
-        FROM alumni 
-        WHERE nama ILIKE $1 OR email ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1
-        ORDER BY %s %s
-        LIMIT $2 OFFSET $3
-    `, sortBy, order)
+        SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
+               a.no_telepon, a.alamat, a.created_at, a.updated_at,
+               %s, %s::text
+        FROM alumni a
+        WHERE %s%s
+    `, alumniSearch.columns(), sortColumn, alumniSearch.condition(), tail)
     
-    rows, err := r.db.Query(query, "%"+search+"%", limit, offset)
+    rows, err := r.db.Query(query, args...)
     if err != nil {
-        return nil, err
+        return nil, nil, err
     }
     defer rows.Close()
 
     var alumniList []models.Alumni
+    var keys []Keyset
     for rows.Next() {
         var alumni models.Alumni
-        err := rows.Scan(
+        var score float64
+        var sortValue sql.NullString
+        headlines := make([]sql.NullString, len(alumniSearch.highlights))
+        err := rows.Scan(append(append([]interface{}{
             &alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
             &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
             &alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
-        )
+        }, alumniSearch.scanTargets(&score, headlines)...), &sortValue)...)
         if err != nil {
-            return nil, err
+            return nil, nil, err
+        }
+        if search != "" {
+            alumni.Score = &score
+            alumni.Highlights = alumniSearch.highlightMap(headlines)
         }
         alumniList = append(alumniList, alumni)
+        keys = append(keys, newKeyset(sortValue, alumni.ID))
     }
 
-    return alumniList, nil
+    return alumniList, keys, nil
 }
 
 // CountAlumni - hitung total data alumni untuk pagination
-func (r *alumniRepository) CountAlumni(search string) (int, error) {
+func (r *alumniRepository) CountAlumni(search string, filter models.AlumniFilter) (int, error) {
     var total int
+    where, args := buildAlumniFilter(filter, []interface{}{search})
     countQuery := `
-        SELECT COUNT(*) FROM alumni 
-        WHERE nama ILIKE $1 OR email ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1
-    `
-    err := r.db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+        SELECT COUNT(*) FROM alumni a
+        WHERE ` + alumniSearch.condition() + where
+    err := r.db.QueryRow(countQuery, args...).Scan(&total)
     if err != nil && err != sql.ErrNoRows {
         return 0, err
     }
@@ -178,6 +290,7 @@ func (r *alumniRepository) Create(req *models.CreateAlumniRequest) (*models.Alum
     if err != nil {
         return nil, err
     }
+    invalidateSuggestions("alumni")
 
     // Set data dari request
     alumni.NIM = req.NIM
@@ -192,18 +305,18 @@ func (r *alumniRepository) Create(req *models.CreateAlumniRequest) (*models.Alum
     return &alumni, nil
 }
 
-func (r *alumniRepository) Update(id int, req *models.UpdateAlumniRequest) (*models.Alumni, error) {
+func (r *alumniRepository) Update(id int, req *models.UpdateAlumniRequest, updatedAt time.Time) (*models.Alumni, error) {
     query := `
         UPDATE alumni 
         SET nama = $1, jurusan = $2, angkatan = $3, tahun_lulus = $4, 
             email = $5, no_telepon = $6, alamat = $7, updated_at = $8
-        WHERE id = $9
+        WHERE id = $9 AND updated_at = $10
     `
     
     now := time.Now()
     result, err := r.db.Exec(
         query, req.Nama, req.Jurusan, req.Angkatan, req.TahunLulus,
-        req.Email, req.NoTelepon, req.Alamat, now, id,
+        req.Email, req.NoTelepon, req.Alamat, now, id, updatedAt,
     )
     
     if err != nil {
@@ -212,55 +325,48 @@ func (r *alumniRepository) Update(id int, req *models.UpdateAlumniRequest) (*mod
 
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return nil, nil
+        return nil, staleOrMissing(r.db, "alumni", id, nil)
     }
+    invalidateSuggestions("alumni")
 
     // Get updated data
     return r.GetByID(id)
 }
 
-func (r *alumniRepository) Delete(id int) error {
-    query := "DELETE FROM alumni WHERE id = $1"
-    result, err := r.db.Exec(query, id)
+// Patch - UPDATE hanya kolom yang berubah (lihat alumniPatchColumns). Return nil jika alumni tidak ada.
+func (r *alumniRepository) Patch(id int, changes map[string]interface{}, updatedAt time.Time) (*models.Alumni, error) {
+    query, args, err := patchQuery("alumni", alumniPatchColumns, id, updatedAt, changes)
     if err != nil {
-        return err
+        return nil, err
+    }
+
+    result, err := r.db.Exec(query, args...)
+    if err != nil {
+        return nil, err
     }
 
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return sql.ErrNoRows
+        return nil, staleOrMissing(r.db, "alumni", id, nil)
     }
+    invalidateSuggestions("alumni")
 
-    return nil
+    return r.GetByID(id)
 }
 
-func (r *alumniRepository) GetAlumniWithoutPekerjaan() ([]models.Alumni, error) {
-    query := `
-        SELECT a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, 
-               a.no_telepon, a.alamat, a.created_at, a.updated_at 
-        FROM alumni a
-        LEFT JOIN pekerjaan_alumni pa ON a.id = pa.alumni_id
-        WHERE pa.alumni_id IS NULL
-        ORDER BY a.created_at DESC
-    `
-    
-    rows, err := r.db.Query(query)
+func (r *alumniRepository) Delete(id int, updatedAt time.Time) error {
+    query := "DELETE FROM alumni WHERE id = $1 AND updated_at = $2"
+    result, err := r.db.Exec(query, id, updatedAt)
     if err != nil {
-        return nil, err
+        return err
     }
-    defer rows.Close()
-    var alumniList []models.Alumni
-    for rows.Next() {
-        var alumni models.Alumni
-        err := rows.Scan(
-            &alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
-            &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
-            &alumni.NoTelepon, &alumni.Alamat, &alumni.CreatedAt, &alumni.UpdatedAt,
-        )
-        if err != nil {
-            return nil, err
-        }
-        alumniList = append(alumniList, alumni)
+
+    rowsAffected, _ := result.RowsAffected()
+    if rowsAffected == 0 {
+        return staleOrMissing(r.db, "alumni", id, sql.ErrNoRows)
     }
-    return alumniList, nil
-}
\ No newline at end of file
+    // Pekerjaan milik alumni ini bisa ikut terhapus oleh foreign key
+    invalidateSuggestions("alumni", "pekerjaan_alumni")
+
+    return nil
+}
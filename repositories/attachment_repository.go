@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+)
+
+type AttachmentRepository interface {
+	Create(attachment *models.Attachment) error // isi ID dan CreatedAt
+	// ReplacePhoto - simpan foto baru alumni dan hapus baris foto lama, return foto lama (nil jika belum ada)
+	ReplacePhoto(attachment *models.Attachment) (*models.Attachment, error)
+	GetByID(id int) (*models.Attachment, error)
+	GetPhotoByAlumniID(alumniID int) (*models.Attachment, error)
+	GetByPekerjaanID(pekerjaanID int) ([]models.Attachment, error)
+	Delete(id int) error
+	// GetOrphans - attachment yang alumni/pekerjaan-nya sudah dihapus permanen (file belum dibersihkan)
+	GetOrphans(limit int) ([]models.Attachment, error)
+}
+
+type attachmentRepository struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepository() AttachmentRepository {
+	return &attachmentRepository{db: config.DB}
+}
+
+const attachmentColumns = `id, alumni_id, pekerjaan_id, kind, file_name, content_type, size_bytes,
+	storage_key, thumbnail_key, uploaded_by, created_at`
+
+// rowScanner - *sql.Row atau *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// rowQuerier - *sql.DB atau *sql.Tx
+type rowQuerier interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func scanAttachment(row rowScanner) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := row.Scan(
+		&attachment.ID, &attachment.AlumniID, &attachment.PekerjaanID, &attachment.Kind,
+		&attachment.FileName, &attachment.ContentType, &attachment.Size,
+		&attachment.StorageKey, &attachment.ThumbnailKey, &attachment.UploadedBy, &attachment.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) Create(attachment *models.Attachment) error {
+	return insertAttachment(r.db, attachment)
+}
+
+func insertAttachment(db rowQuerier, attachment *models.Attachment) error {
+	return db.QueryRow(`
+		INSERT INTO attachments (alumni_id, pekerjaan_id, kind, file_name, content_type, size_bytes,
+		                         storage_key, thumbnail_key, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`, attachment.AlumniID, attachment.PekerjaanID, attachment.Kind, attachment.FileName, attachment.ContentType,
+		attachment.Size, attachment.StorageKey, attachment.ThumbnailKey, attachment.UploadedBy,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+}
+
+// ReplacePhoto - baris alumni dikunci agar dua upload bersamaan tidak bentrok di index unik foto
+func (r *attachmentRepository) ReplacePhoto(attachment *models.Attachment) (*models.Attachment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT id FROM alumni WHERE id = $1 FOR UPDATE`, attachment.AlumniID); err != nil {
+		return nil, err
+	}
+	old, err := scanAttachment(tx.QueryRow(`
+		DELETE FROM attachments WHERE alumni_id = $1 AND kind = $2
+		RETURNING `+attachmentColumns, attachment.AlumniID, models.AttachmentKindPhoto))
+	if err != nil {
+		return nil, err
+	}
+	if err := insertAttachment(tx, attachment); err != nil {
+		return nil, err
+	}
+	return old, tx.Commit()
+}
+
+func (r *attachmentRepository) GetByID(id int) (*models.Attachment, error) {
+	return scanAttachment(r.db.QueryRow(`SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, id))
+}
+
+func (r *attachmentRepository) GetPhotoByAlumniID(alumniID int) (*models.Attachment, error) {
+	return scanAttachment(r.db.QueryRow(`SELECT `+attachmentColumns+` FROM attachments WHERE alumni_id = $1 AND kind = $2`,
+		alumniID, models.AttachmentKindPhoto))
+}
+
+func (r *attachmentRepository) GetByPekerjaanID(pekerjaanID int) ([]models.Attachment, error) {
+	return r.list(`SELECT `+attachmentColumns+` FROM attachments WHERE pekerjaan_id = $1 ORDER BY created_at, id`, pekerjaanID)
+}
+
+func (r *attachmentRepository) Delete(id int) error {
+	_, err := r.db.Exec(`DELETE FROM attachments WHERE id = $1`, id)
+	return err
+}
+
+func (r *attachmentRepository) GetOrphans(limit int) ([]models.Attachment, error) {
+	return r.list(`SELECT `+attachmentColumns+` FROM attachments WHERE alumni_id IS NULL AND pekerjaan_id IS NULL ORDER BY id LIMIT $1`, limit)
+}
+
+func (r *attachmentRepository) list(query string, args ...interface{}) ([]models.Attachment, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, rows.Err()
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuditRepository - sengaja tidak punya Update/Delete, audit trail hanya bisa ditambah
+type AuditRepository interface {
+	Create(entry *models.AuditLog) error
+	GetAllPaginated(filter models.AuditFilter, order string, limit, offset int) ([]models.AuditLog, error)
+	CountAudit(filter models.AuditFilter) (int, error)
+}
+
+type auditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository() AuditRepository {
+	return &auditRepository{db: config.DB}
+}
+
+// Create - simpan satu entry audit
+func (r *auditRepository) Create(entry *models.AuditLog) error {
+	query := `
+        INSERT INTO audit_logs (actor_user_id, actor_role, action, entity, entity_id,
+                                before_data, after_data, changes, ip_address, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id
+    `
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	return r.db.QueryRow(
+		query, entry.ActorUserID, entry.ActorRole, entry.Action, entry.Entity, entry.EntityID,
+		nullableJSON(entry.Before), nullableJSON(entry.After), nullableJSON(entry.Changes),
+		entry.IPAddress, entry.CreatedAt,
+	).Scan(&entry.ID)
+}
+
+// GetAllPaginated - ambil audit log dengan filter dan pagination (urut created_at)
+func (r *auditRepository) GetAllPaginated(filter models.AuditFilter, order string, limit, offset int) ([]models.AuditLog, error) {
+	where, args := buildAuditWhere(filter)
+	args = append(args, limit, offset)
+
+	query := fmt.Sprintf(`
+        SELECT id, actor_user_id, actor_role, action, entity, entity_id,
+               before_data, after_data, changes, ip_address, created_at
+        FROM audit_logs
+        %s
+        ORDER BY created_at %s, id %s
+        LIMIT $%d OFFSET $%d
+    `, where, order, order, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []models.AuditLog
+	for rows.Next() {
+		var entry models.AuditLog
+		var before, after, changes []byte
+		err := rows.Scan(
+			&entry.ID, &entry.ActorUserID, &entry.ActorRole, &entry.Action, &entry.Entity, &entry.EntityID,
+			&before, &after, &changes, &entry.IPAddress, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.Before = before
+		entry.After = after
+		entry.Changes = changes
+		logs = append(logs, entry)
+	}
+
+	return logs, nil
+}
+
+// CountAudit - hitung total audit log untuk pagination
+func (r *auditRepository) CountAudit(filter models.AuditFilter) (int, error) {
+	where, args := buildAuditWhere(filter)
+
+	var total int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM audit_logs "+where, args...).Scan(&total)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return total, nil
+}
+
+// buildAuditWhere - susun klausa WHERE dengan parameter placeholder
+func buildAuditWhere(filter models.AuditFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorUserID != nil {
+		add("actor_user_id = $%d", *filter.ActorUserID)
+	}
+	if filter.ActorRole != "" {
+		add("actor_role = $%d", filter.ActorRole)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Entity != "" {
+		add("entity = $%d", filter.Entity)
+	}
+	if filter.EntityID != nil {
+		add("entity_id = $%d", *filter.EntityID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at <= $%d", *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,450 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"alumni-management-system/utils"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CompanyRepository interface {
+	GetAllPaginated(search, sortBy, order string, limit, offset int) ([]models.Company, error)
+	CountCompanies(search string) (int, error)
+	GetByID(id int) (*models.Company, error)
+	FindByName(name string) (*models.Company, string, error)
+	FindSimilar(name string, limit int) ([]models.CompanyMatch, error)
+	Create(req *models.CreateCompanyRequest) (*models.Company, error)
+	Update(id int, req *models.UpdateCompanyRequest) (*models.Company, error)
+	Delete(id int) error
+	AddAlias(companyID int, alias string) (*models.CompanyAlias, error)
+	DeleteAlias(companyID, aliasID int) error
+	Merge(targetID int, sourceIDs []int) (int64, error)
+	GetAlumni(companyID int, status string, limit, offset int) ([]models.PekerjaanAlumni, error)
+	CountAlumni(companyID int, status string) (int, error)
+}
+
+type companyRepository struct {
+	db *sql.DB
+}
+
+func NewCompanyRepository() CompanyRepository {
+	return &companyRepository{db: config.DB}
+}
+
+// jumlah_alumni dihitung dari pekerjaan yang belum dihapus
+const companySelect = `
+        SELECT c.id, c.nama, c.bidang_industri, c.lokasi, c.created_at, c.updated_at,
+               (SELECT COUNT(DISTINCT p.alumni_id) FROM pekerjaan_alumni p
+                JOIN alumni a ON a.id = p.alumni_id
+                WHERE p.company_id = c.id AND p.is_deleted = FALSE AND a.is_deleted = FALSE) AS jumlah_alumni
+        FROM companies c
+`
+
+func scanCompany(scanner interface{ Scan(...interface{}) error }) (models.Company, error) {
+	var company models.Company
+	err := scanner.Scan(
+		&company.ID, &company.Nama, &company.BidangIndustri, &company.Lokasi,
+		&company.CreatedAt, &company.UpdatedAt, &company.JumlahAlumni,
+	)
+	company.Aliases = []models.CompanyAlias{}
+	return company, err
+}
+
+// GetAllPaginated - daftar perusahaan, search mencakup nama dan alias
+func (r *companyRepository) GetAllPaginated(search, sortBy, order string, limit, offset int) ([]models.Company, error) {
+	query := fmt.Sprintf(companySelect+`
+        WHERE c.nama ILIKE $1 OR EXISTS (
+            SELECT 1 FROM company_aliases ca WHERE ca.company_id = c.id AND ca.alias ILIKE $1)
+        ORDER BY %s %s
+        LIMIT $2 OFFSET $3
+    `, sortBy, order)
+
+	rows, err := r.db.Query(query, "%"+search+"%", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	companies := []models.Company{}
+	for rows.Next() {
+		company, err := scanCompany(rows)
+		if err != nil {
+			return nil, err
+		}
+		companies = append(companies, company)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachAliases(companies); err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+// CountCompanies - hitung total perusahaan untuk pagination
+func (r *companyRepository) CountCompanies(search string) (int, error) {
+	var total int
+	query := `
+        SELECT COUNT(*) FROM companies c
+        WHERE c.nama ILIKE $1 OR EXISTS (
+            SELECT 1 FROM company_aliases ca WHERE ca.company_id = c.id AND ca.alias ILIKE $1)
+    `
+	if err := r.db.QueryRow(query, "%"+search+"%").Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *companyRepository) GetByID(id int) (*models.Company, error) {
+	company, err := scanCompany(r.db.QueryRow(companySelect+" WHERE c.id = $1", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	companies := []models.Company{company}
+	if err := r.attachAliases(companies); err != nil {
+		return nil, err
+	}
+	return &companies[0], nil
+}
+
+// attachAliases - isi Aliases untuk daftar perusahaan dengan satu query
+func (r *companyRepository) attachAliases(companies []models.Company) error {
+	if len(companies) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(companies))
+	index := make(map[int]int, len(companies))
+	for i, company := range companies {
+		ids[i] = int64(company.ID)
+		index[company.ID] = i
+	}
+
+	rows, err := r.db.Query(`
+        SELECT id, company_id, alias, created_at
+        FROM company_aliases
+        WHERE company_id = ANY($1)
+        ORDER BY alias
+    `, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var alias models.CompanyAlias
+		if err := rows.Scan(&alias.ID, &alias.CompanyID, &alias.Alias, &alias.CreatedAt); err != nil {
+			return err
+		}
+		i := index[alias.CompanyID]
+		companies[i].Aliases = append(companies[i].Aliases, alias)
+	}
+	return rows.Err()
+}
+
+// FindByName - cocokkan nama ternormalisasi dengan nama perusahaan atau alias.
+// Mengembalikan method models.CompanyMatchExact / models.CompanyMatchAlias, nil jika tidak ada.
+func (r *companyRepository) FindByName(name string) (*models.Company, string, error) {
+	normal := utils.NormalizeCompanyName(name)
+	query := `
+        SELECT id, 'exact' FROM companies WHERE nama_normal = $1
+        UNION ALL
+        SELECT company_id, 'alias' FROM company_aliases WHERE alias_normal = $1
+        LIMIT 1
+    `
+
+	var id int
+	var method string
+	if err := r.db.QueryRow(query, normal).Scan(&id, &method); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+
+	company, err := r.GetByID(id)
+	if err != nil || company == nil {
+		return nil, "", err
+	}
+	return company, method, nil
+}
+
+// FindSimilar - kandidat perusahaan berdasarkan kemiripan trigram (pg_trgm) terhadap nama dan alias.
+// Skor adalah rata-rata similarity dan word_similarity sehingga "telkom" tetap dekat ke "telkom indonesia".
+func (r *companyRepository) FindSimilar(name string, limit int) ([]models.CompanyMatch, error) {
+	normal := utils.NormalizeCompanyName(name)
+	query := `
+        WITH names AS (
+            SELECT id AS company_id, nama_normal AS name FROM companies
+            UNION ALL
+            SELECT company_id, alias_normal FROM company_aliases
+        )
+        SELECT company_id,
+               MAX((similarity(name, $1) + GREATEST(word_similarity($1, name), word_similarity(name, $1))) / 2) AS score
+        FROM names
+        WHERE name % $1 OR $1 <% name OR name <% $1
+        GROUP BY company_id
+        ORDER BY score DESC, company_id
+        LIMIT $2
+    `
+
+	rows, err := r.db.Query(query, normal, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		id    int
+		score float64
+	}
+	var candidates []candidate
+	for rows.Next() {
+		var c candidate
+		if err := rows.Scan(&c.id, &c.score); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	matches := []models.CompanyMatch{}
+	for _, c := range candidates {
+		company, err := r.GetByID(c.id)
+		if err != nil {
+			return nil, err
+		}
+		if company != nil {
+			matches = append(matches, models.CompanyMatch{Company: company, Method: models.CompanyMatchFuzzy, Score: c.score})
+		}
+	}
+	return matches, nil
+}
+
+// Create - simpan perusahaan beserta alias-nya dalam satu transaksi
+func (r *companyRepository) Create(req *models.CreateCompanyRequest) (*models.Company, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	now := time.Now()
+	err = tx.QueryRow(`
+        INSERT INTO companies (nama, nama_normal, bidang_industri, lokasi, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $5)
+        RETURNING id
+    `, req.Nama, utils.NormalizeCompanyName(req.Nama), req.BidangIndustri, req.Lokasi, now).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range req.Aliases {
+		_, err := tx.Exec(`
+            INSERT INTO company_aliases (company_id, alias, alias_normal, created_at)
+            VALUES ($1, $2, $3, $4)
+        `, id, alias, utils.NormalizeCompanyName(alias), now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+func (r *companyRepository) Update(id int, req *models.UpdateCompanyRequest) (*models.Company, error) {
+	result, err := r.db.Exec(`
+        UPDATE companies
+        SET nama = $1, nama_normal = $2, bidang_industri = $3, lokasi = $4, updated_at = $5
+        WHERE id = $6
+    `, req.Nama, utils.NormalizeCompanyName(req.Nama), req.BidangIndustri, req.Lokasi, time.Now(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+	return r.GetByID(id)
+}
+
+// Delete - hapus perusahaan, company_id di pekerjaan_alumni otomatis menjadi NULL
+func (r *companyRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM companies WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *companyRepository) AddAlias(companyID int, alias string) (*models.CompanyAlias, error) {
+	created := models.CompanyAlias{CompanyID: companyID, Alias: alias}
+	err := r.db.QueryRow(`
+        INSERT INTO company_aliases (company_id, alias, alias_normal, created_at)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id, created_at
+    `, companyID, alias, utils.NormalizeCompanyName(alias), time.Now()).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *companyRepository) DeleteAlias(companyID, aliasID int) error {
+	result, err := r.db.Exec("DELETE FROM company_aliases WHERE id = $1 AND company_id = $2", aliasID, companyID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Merge - pindahkan pekerjaan dan alias dari sourceIDs ke targetID, nama sumber disimpan sebagai alias,
+// lalu hapus perusahaan sumber. Mengembalikan jumlah pekerjaan yang dipindahkan.
+func (r *companyRepository) Merge(targetID int, sourceIDs []int) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, len(sourceIDs))
+	for i, id := range sourceIDs {
+		ids[i] = int64(id)
+	}
+	now := time.Now()
+
+	result, err := tx.Exec(`
+        UPDATE pekerjaan_alumni SET company_id = $1, updated_at = $2
+        WHERE company_id = ANY($3)
+    `, targetID, now, pq.Array(ids))
+	if err != nil {
+		return 0, err
+	}
+	moved, _ := result.RowsAffected()
+
+	if _, err := tx.Exec(`UPDATE company_aliases SET company_id = $1 WHERE company_id = ANY($2)`, targetID, pq.Array(ids)); err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(`
+        INSERT INTO company_aliases (company_id, alias, alias_normal, created_at)
+        SELECT $1, nama, nama_normal, $2 FROM companies WHERE id = ANY($3)
+        ON CONFLICT (alias_normal) DO NOTHING
+    `, targetID, now, pq.Array(ids))
+	if err != nil {
+		return 0, err
+	}
+
+	// Lengkapi data industri/lokasi tujuan yang masih kosong dari perusahaan sumber
+	_, err = tx.Exec(`
+        UPDATE companies t
+        SET bidang_industri = COALESCE(t.bidang_industri, (SELECT s.bidang_industri FROM companies s
+                                  WHERE s.id = ANY($2) AND s.bidang_industri IS NOT NULL ORDER BY s.id LIMIT 1)),
+            lokasi = COALESCE(t.lokasi, (SELECT s.lokasi FROM companies s
+                         WHERE s.id = ANY($2) AND s.lokasi IS NOT NULL ORDER BY s.id LIMIT 1)),
+            updated_at = $3
+        WHERE t.id = $1
+    `, targetID, pq.Array(ids), now)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM companies WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+// GetAlumni - pekerjaan (beserta data alumni) di perusahaan tertentu, opsional filter status_pekerjaan
+func (r *companyRepository) GetAlumni(companyID int, status string, limit, offset int) ([]models.PekerjaanAlumni, error) {
+	query := `
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan,
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
+               p.tanggal_mulai_kerja, p.tanggal_selesai_kerja,
+               p.status_pekerjaan, p.deskripsi_pekerjaan,
+               p.created_at, p.updated_at,
+               a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email
+        FROM pekerjaan_alumni p
+        JOIN alumni a ON p.alumni_id = a.id
+        WHERE p.company_id = $1 AND p.is_deleted = FALSE AND a.is_deleted = FALSE
+          AND ($2 = '' OR p.status_pekerjaan = $2)
+        ORDER BY p.tanggal_mulai_kerja DESC, p.id
+        LIMIT $3 OFFSET $4
+    `
+
+	rows, err := r.db.Query(query, companyID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pekerjaanList := []models.PekerjaanAlumni{}
+	for rows.Next() {
+		var pekerjaan models.PekerjaanAlumni
+		var alumni models.Alumni
+		err := rows.Scan(
+			&pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
+			&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+			&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+			&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+			&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
+			&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+			&alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
+			&alumni.TahunLulus, &alumni.Email,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		alumni.ID = pekerjaan.AlumniID
+		pekerjaan.Alumni = &alumni
+		pekerjaanList = append(pekerjaanList, pekerjaan)
+	}
+
+	return pekerjaanList, rows.Err()
+}
+
+func (r *companyRepository) CountAlumni(companyID int, status string) (int, error) {
+	var total int
+	query := `
+        SELECT COUNT(*) FROM pekerjaan_alumni p
+        JOIN alumni a ON p.alumni_id = a.id
+        WHERE p.company_id = $1 AND p.is_deleted = FALSE AND a.is_deleted = FALSE
+          AND ($2 = '' OR p.status_pekerjaan = $2)
+    `
+	if err := r.db.QueryRow(query, companyID, status).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"time"
+)
+
+// HistoryRepository - baca riwayat versi yang diisi trigger database (lihat migrations/003)
+type HistoryRepository interface {
+	GetAlumniHistory(alumniID int) ([]models.RecordVersion, error)
+	GetAlumniVersion(alumniID, version int) (*models.Alumni, error)
+	GetAlumniAsOf(alumniID int, asOf time.Time) (*models.Alumni, error)
+	GetPekerjaanHistory(pekerjaanID int) ([]models.RecordVersion, error)
+	GetPekerjaanVersion(pekerjaanID, version int) (*models.PekerjaanAlumni, error)
+	GetPekerjaanAsOf(pekerjaanID int, asOf time.Time) (*models.PekerjaanAlumni, error)
+}
+
+type historyRepository struct {
+	db *sql.DB
+}
+
+func NewHistoryRepository() HistoryRepository {
+	return &historyRepository{db: config.DB}
+}
+
+const alumniVersionSelect = `
+        SELECT v.operation, r.id, r.nim, r.nama, r.jurusan, r.angkatan, r.tahun_lulus, r.email,
+               r.no_telepon, r.alamat, r.user_id, r.is_deleted, r.created_at, r.updated_at
+        FROM alumni_versions v
+        CROSS JOIN LATERAL jsonb_populate_record(NULL::alumni, v.data) r
+`
+
+const pekerjaanVersionSelect = `
+        SELECT v.operation, r.id, r.alumni_id, r.nama_perusahaan, r.company_id, r.posisi_jabatan,
+               r.bidang_industri, r.lokasi_kerja, r.gaji_range, r.gaji_min, r.gaji_max, r.gaji_currency,
+               r.tanggal_mulai_kerja, r.tanggal_selesai_kerja,
+               r.status_pekerjaan, r.deskripsi_pekerjaan,
+               r.is_deleted, r.created_at, r.updated_at
+        FROM pekerjaan_alumni_versions v
+        CROSS JOIN LATERAL jsonb_populate_record(NULL::pekerjaan_alumni, v.data) r
+`
+
+// GetAlumniHistory - semua versi alumni, urut dari yang paling lama
+func (r *historyRepository) GetAlumniHistory(alumniID int) ([]models.RecordVersion, error) {
+	query := `
+        SELECT version, operation, data, recorded_at
+        FROM alumni_versions
+        WHERE alumni_id = $1
+        ORDER BY version ASC
+    `
+	return r.scanVersions(query, alumniID)
+}
+
+// GetAlumniVersion - state alumni pada nomor versi tertentu
+func (r *historyRepository) GetAlumniVersion(alumniID, version int) (*models.Alumni, error) {
+	query := alumniVersionSelect + `
+        WHERE v.alumni_id = $1 AND v.version = $2
+    `
+	return r.scanAlumni(r.db.QueryRow(query, alumniID, version))
+}
+
+// GetAlumniAsOf - state alumni yang berlaku pada waktu asOf (nil jika belum ada atau sudah dihapus)
+func (r *historyRepository) GetAlumniAsOf(alumniID int, asOf time.Time) (*models.Alumni, error) {
+	query := alumniVersionSelect + `
+        WHERE v.alumni_id = $1 AND v.recorded_at <= $2
+        ORDER BY v.version DESC
+        LIMIT 1
+    `
+	return r.scanAlumni(r.db.QueryRow(query, alumniID, asOf))
+}
+
+// GetPekerjaanHistory - semua versi pekerjaan, urut dari yang paling lama
+func (r *historyRepository) GetPekerjaanHistory(pekerjaanID int) ([]models.RecordVersion, error) {
+	query := `
+        SELECT version, operation, data, recorded_at
+        FROM pekerjaan_alumni_versions
+        WHERE pekerjaan_id = $1
+        ORDER BY version ASC
+    `
+	return r.scanVersions(query, pekerjaanID)
+}
+
+// GetPekerjaanVersion - state pekerjaan pada nomor versi tertentu
+func (r *historyRepository) GetPekerjaanVersion(pekerjaanID, version int) (*models.PekerjaanAlumni, error) {
+	query := pekerjaanVersionSelect + `
+        WHERE v.pekerjaan_id = $1 AND v.version = $2
+    `
+	return r.scanPekerjaan(r.db.QueryRow(query, pekerjaanID, version))
+}
+
+// GetPekerjaanAsOf - state pekerjaan yang berlaku pada waktu asOf (nil jika belum ada atau sudah dihapus)
+func (r *historyRepository) GetPekerjaanAsOf(pekerjaanID int, asOf time.Time) (*models.PekerjaanAlumni, error) {
+	query := pekerjaanVersionSelect + `
+        WHERE v.pekerjaan_id = $1 AND v.recorded_at <= $2
+        ORDER BY v.version DESC
+        LIMIT 1
+    `
+	return r.scanPekerjaan(r.db.QueryRow(query, pekerjaanID, asOf))
+}
+
+func (r *historyRepository) scanVersions(query string, id int) ([]models.RecordVersion, error) {
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []models.RecordVersion
+	for rows.Next() {
+		var version models.RecordVersion
+		var data []byte
+		if err := rows.Scan(&version.Version, &version.Operation, &data, &version.RecordedAt); err != nil {
+			return nil, err
+		}
+		version.Data = data
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (r *historyRepository) scanAlumni(row *sql.Row) (*models.Alumni, error) {
+	var operation string
+	var alumni models.Alumni
+	err := row.Scan(
+		&operation, &alumni.ID, &alumni.NIM, &alumni.Nama, &alumni.Jurusan,
+		&alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+		&alumni.NoTelepon, &alumni.Alamat, &alumni.UserID, &alumni.IsDeleted,
+		&alumni.CreatedAt, &alumni.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if operation == "DELETE" {
+		return nil, nil
+	}
+	return &alumni, nil
+}
+
+func (r *historyRepository) scanPekerjaan(row *sql.Row) (*models.PekerjaanAlumni, error) {
+	var operation string
+	var pekerjaan models.PekerjaanAlumni
+	err := row.Scan(
+		&operation, &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
+		&pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+		&pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+		&pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+		&pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDeleted,
+		&pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if operation == "DELETE" {
+		return nil, nil
+	}
+	return &pekerjaan, nil
+}
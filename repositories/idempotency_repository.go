@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	// Claim - daftarkan key untuk diproses. Jika key sudah ada dan belum kedaluwarsa, return record yang ada.
+	Claim(userID int, key, fingerprint string, ttl, lockTimeout time.Duration) (claimed bool, existing *models.IdempotencyRecord, err error)
+	Complete(userID int, key string, statusCode int, contentType string, body []byte) error
+	Release(userID int, key string) error
+	DeleteExpired() (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepository() IdempotencyRepository {
+	return &idempotencyRepository{db: config.DB}
+}
+
+// Claim - INSERT atomik; baris lama hanya ditimpa jika sudah kedaluwarsa atau request pertamanya tidak pernah
+// selesai dalam lockTimeout (proses mati di tengah jalan), sehingga dua request paralel tidak bisa sama-sama lolos.
+func (r *idempotencyRepository) Claim(userID int, key, fingerprint string, ttl, lockTimeout time.Duration) (bool, *models.IdempotencyRecord, error) {
+	now := time.Now()
+	var claimed bool
+	err := r.db.QueryRow(`
+		INSERT INTO idempotency_keys (user_id, idempotency_key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, idempotency_key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, content_type = NULL, response_body = NULL,
+		    created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < $4
+		   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < $6)
+		RETURNING TRUE
+	`, userID, key, fingerprint, now, now.Add(ttl), now.Add(-lockTimeout)).Scan(&claimed)
+	if err == nil {
+		return true, nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, nil, err
+	}
+
+	record := models.IdempotencyRecord{UserID: userID, Key: key}
+	var contentType sql.NullString
+	err = r.db.QueryRow(`
+		SELECT fingerprint, status_code, content_type, response_body, created_at, expires_at
+		FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2
+	`, userID, key).Scan(&record.Fingerprint, &record.StatusCode, &contentType, &record.ResponseBody, &record.CreatedAt, &record.ExpiresAt)
+	if err == sql.ErrNoRows {
+		// Baru saja dilepas oleh request pertama yang gagal; perlakukan seperti masih diproses
+		return false, &record, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	record.ContentType = contentType.String
+	return false, &record, nil
+}
+
+// Complete - simpan response request pertama
+func (r *idempotencyRepository) Complete(userID int, key string, statusCode int, contentType string, body []byte) error {
+	_, err := r.db.Exec(`
+		UPDATE idempotency_keys SET status_code = $3, content_type = $4, response_body = $5
+		WHERE user_id = $1 AND idempotency_key = $2
+	`, userID, key, statusCode, contentType, body)
+	return err
+}
+
+// Release - hapus klaim yang request-nya gagal agar client bisa mencoba lagi dengan key yang sama
+func (r *idempotencyRepository) Release(userID int, key string) error {
+	_, err := r.db.Exec("DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND status_code IS NULL", userID, key)
+	return err
+}
+
+func (r *idempotencyRepository) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec("DELETE FROM idempotency_keys WHERE expires_at < $1", time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Keyset - posisi satu baris pada urutan (sortBy, id); isi dari cursor next/prev
+type Keyset struct {
+	Value *string `json:"v"`  // nilai kolom sortBy sebagai teks (::text), nil = NULL
+	ID    int     `json:"id"` // penentu urutan jika nilai sortBy sama
+}
+
+// KeysetPage - permintaan satu halaman keyset
+type KeysetPage struct {
+	After  *Keyset // posisi acuan; nil = halaman pertama
+	Before bool    // true: ambil baris sebelum After (cursor prev); baris datang terbalik, pemanggil yang membalik
+	Limit  int     // query mengambil Limit+1 baris untuk mengetahui apakah masih ada halaman berikutnya
+}
+
+// clause - kondisi " AND ..." setelah posisi acuan, ORDER BY, dan LIMIT. Parameter nilai dikirim sebagai teks
+// dan tipenya disimpulkan PostgreSQL dari kolom. Untuk kolom nullable, NULL berada di akhir urutan ASC dan
+// di awal urutan DESC (default PostgreSQL), sehingga membalik arah untuk prev tetap konsisten.
+func (p KeysetPage) clause(column, idColumn string, nullable bool, order string, args []interface{}) (string, []interface{}) {
+	desc := (order == "desc") != p.Before
+	op, direction := ">", "ASC"
+	if desc {
+		op, direction = "<", "DESC"
+	}
+
+	condition := ""
+	if p.After != nil {
+		args = append(args, p.After.ID)
+		id := len(args)
+		switch {
+		case p.After.Value == nil && !desc:
+			condition = fmt.Sprintf(" AND %s IS NULL AND %s > $%d", column, idColumn, id)
+		case p.After.Value == nil:
+			condition = fmt.Sprintf(" AND (%s IS NOT NULL OR %s < $%d)", column, idColumn, id)
+		default:
+			args = append(args, *p.After.Value)
+			condition = fmt.Sprintf(" AND ((%s, %s) %s ($%d, $%d)", column, idColumn, op, len(args), id)
+			if nullable && !desc {
+				condition += fmt.Sprintf(" OR %s IS NULL", column)
+			}
+			condition += ")"
+		}
+	}
+
+	args = append(args, p.Limit+1)
+	return fmt.Sprintf("%s ORDER BY %s %s, %s %s LIMIT $%d", condition, column, direction, idColumn, direction, len(args)), args
+}
+
+// newKeyset - posisi baris dari nilai sortBy (::text) dan id
+func newKeyset(value sql.NullString, id int) Keyset {
+	if !value.Valid {
+		return Keyset{ID: id}
+	}
+	return Keyset{Value: &value.String, ID: id}
+}
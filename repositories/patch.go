@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrStaleRecord - baris masih ada tetapi updated_at sudah berbeda dari yang diharapkan: data diubah pengguna lain
+// di antara pemeriksaan If-Match dan UPDATE/DELETE (optimistic concurrency)
+var ErrStaleRecord = errors.New("record was modified concurrently")
+
+// Kolom yang boleh diubah lewat Patch. Nama kolom di UPDATE hanya berasal dari daftar ini, nilai selalu placeholder.
+var (
+	alumniPatchColumns = map[string]bool{
+		"nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true,
+		"email": true, "no_telepon": true, "alamat": true,
+	}
+	pekerjaanPatchColumns = map[string]bool{
+		"nama_perusahaan": true, "company_id": true, "posisi_jabatan": true, "bidang_industri": true,
+		"lokasi_kerja": true, "gaji_range": true, "gaji_min": true, "gaji_max": true, "gaji_currency": true,
+		"tanggal_mulai_kerja": true, "tanggal_selesai_kerja": true, "status_pekerjaan": true, "deskripsi_pekerjaan": true,
+	}
+)
+
+// patchQuery - UPDATE table SET kolom = $n, ..., updated_at WHERE id AND updated_at = expected untuk kolom di
+// changes. Kolom diurutkan agar query yang sama selalu menghasilkan teks yang sama (prepared statement, log).
+func patchQuery(table string, columns map[string]bool, id int, expected time.Time, changes map[string]interface{}) (string, []interface{}, error) {
+	if len(changes) == 0 {
+		return "", nil, errors.New("patch has no changes")
+	}
+	names := make([]string, 0, len(changes))
+	for name := range changes {
+		if !columns[name] {
+			return "", nil, fmt.Errorf("column %q cannot be patched", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	assignments := make([]string, len(names))
+	args := make([]interface{}, 0, len(names)+3)
+	for i, name := range names {
+		args = append(args, changes[name])
+		assignments[i] = fmt.Sprintf("%s = $%d", name, len(args))
+	}
+	args = append(args, time.Now(), id, expected)
+	query := fmt.Sprintf("UPDATE %s SET %s, updated_at = $%d WHERE id = $%d AND updated_at = $%d",
+		table, strings.Join(assignments, ", "), len(args)-2, len(args)-1, len(args))
+	return query, args, nil
+}
+
+// staleOrMissing - alasan UPDATE/DELETE dengan syarat updated_at tidak mengenai baris: ErrStaleRecord jika
+// baris id masih ada, missing jika tidak
+func staleOrMissing(db *sql.DB, table string, id int, missing error) error {
+	var exists bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return ErrStaleRecord
+	}
+	return missing
+}
@@ -5,24 +5,37 @@ import (
 	"alumni-management-system/models"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type PekerjaanRepository interface {
     GetAll() ([]models.PekerjaanAlumni, error)
-    GetAllPaginated(search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) // New
-    CountPekerjaan(search string) (int, error) // New
+    GetAllPaginated(search, sortBy, order string, filter models.PekerjaanFilter, limit, offset int) ([]models.PekerjaanAlumni, error) // New
+    CountPekerjaan(search string, filter models.PekerjaanFilter) (int, error) // New
+    GetAllKeyset(search, sortBy, order string, filter models.PekerjaanFilter, page KeysetPage) ([]models.PekerjaanAlumni, []Keyset, error)
     GetByID(id int) (*models.PekerjaanAlumni, error)
+    GetTrashedByID(id int) (*models.PekerjaanAlumni, error)
     GetByAlumniID(alumniID int) ([]models.PekerjaanAlumni, error)
+    GetNonDeletedByAlumniID(alumniID int) ([]models.PekerjaanAlumni, error)
+    GetActiveIDsByAlumniID(alumniID, excludeID int) ([]int, error)
+    EndJob(id int, status string, tanggalSelesai time.Time) (*models.PekerjaanAlumni, error)
     Create(pekerjaan *models.CreatePekerjaanRequest) (*models.PekerjaanAlumni, error)
-    Update(id int, pekerjaan *models.UpdatePekerjaanRequest) (*models.PekerjaanAlumni, error)
-    Delete(id int) error
-    SoftDelete(id int) error
+    // Update, Patch, Delete, dan SoftDelete hanya menulis jika updated_at masih sama dengan updatedAt
+    // (ErrStaleRecord jika tidak)
+    Update(id int, pekerjaan *models.UpdatePekerjaanRequest, updatedAt time.Time) (*models.PekerjaanAlumni, error)
+    Patch(id int, changes map[string]interface{}, updatedAt time.Time) (*models.PekerjaanAlumni, error) // kunci changes = nama kolom
+    Delete(id int, updatedAt time.Time) error
+    SoftDelete(id int, updatedAt time.Time) error
     GetAllNon() ([]models.PekerjaanAlumni, error)
-    GetTrashedPaginated(search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) 
-    CountTrashed(search string) (int, error)
-    HardDeleteTrashed(id int) error
-    RestoreTrashed(id int) (*models.PekerjaanAlumni, error) 
+    GetTrashedPaginated(search, sortBy, order string, filter models.PekerjaanFilter, limit, offset int) ([]models.PekerjaanAlumni, error)
+    GetTrashedKeyset(search, sortBy, order string, filter models.PekerjaanFilter, page KeysetPage) ([]models.PekerjaanAlumni, []Keyset, error)
+    CountTrashed(search string, filter models.PekerjaanFilter) (int, error)
+    // HardDeleteTrashed dan RestoreTrashed juga dijaga updated_at seperti Delete
+    HardDeleteTrashed(id int, updatedAt time.Time) error
+    RestoreTrashed(id int, updatedAt time.Time) (*models.PekerjaanAlumni, error) 
 }
 
     
@@ -40,8 +53,8 @@ func NewPekerjaanRepository() PekerjaanRepository {
 
 func (r *pekerjaanRepository) GetAll() ([]models.PekerjaanAlumni, error) {
     query := `
-        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, 
-               p.bidang_industri, p.lokasi_kerja, p.gaji_range, 
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan, 
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
                p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
                p.status_pekerjaan, p.deskripsi_pekerjaan, 
                p.is_deleted, p.created_at, p.updated_at,
@@ -64,9 +77,10 @@ func (r *pekerjaanRepository) GetAll() ([]models.PekerjaanAlumni, error) {
         var alumni models.Alumni
         
         err := rows.Scan(
-             &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+             &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
              &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-             &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+             &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+             &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
              &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDeleted,
              &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
              &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
@@ -83,76 +97,169 @@ func (r *pekerjaanRepository) GetAll() ([]models.PekerjaanAlumni, error) {
 
     return pekerjaanList, nil
 }
-func (r *pekerjaanRepository) SoftDelete(id int) error {
-    query := `UPDATE pekerjaan_alumni SET is_deleted = TRUE, updated_at = $1 WHERE id = $2 AND is_deleted = FALSE`
-    result, err := r.db.Exec(query, time.Now(), id)
+func (r *pekerjaanRepository) SoftDelete(id int, updatedAt time.Time) error {
+    query := `UPDATE pekerjaan_alumni SET is_deleted = TRUE, updated_at = $1 WHERE id = $2 AND is_deleted = FALSE AND updated_at = $3`
+    result, err := r.db.Exec(query, time.Now(), id, updatedAt)
     if err != nil {
         return err
     }
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return sql.ErrNoRows
+        return staleOrMissing(r.db, "pekerjaan_alumni", id, sql.ErrNoRows)
     }
+    invalidateSuggestions("pekerjaan_alumni")
     return nil
 }
 
-// GetAllPaginated - ambil data pekerjaan dengan pagination, search, dan sorting
-func (r *pekerjaanRepository) GetAllPaginated(search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) {
+// buildPekerjaanFilter - kondisi tambahan dari PekerjaanFilter, placeholder dimulai setelah args yang sudah ada
+func buildPekerjaanFilter(filter models.PekerjaanFilter, args []interface{}) (string, []interface{}) {
+    var conditions []string
+    add := func(condition string, value interface{}) {
+        args = append(args, value)
+        conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+    }
+
+    if filter.GajiCurrency != "" {
+        add("p.gaji_currency = $%d", filter.GajiCurrency)
+    }
+    // Rentang gaji pekerjaan harus beririsan dengan band yang diminta
+    if filter.GajiMin != nil {
+        add("COALESCE(p.gaji_max, p.gaji_min) >= $%d", *filter.GajiMin)
+    }
+    if filter.GajiMax != nil {
+        add("COALESCE(p.gaji_min, p.gaji_max) <= $%d", *filter.GajiMax)
+    }
+    if len(filter.StatusPekerjaan) > 0 {
+        add("p.status_pekerjaan = ANY($%d)", pq.Array(filter.StatusPekerjaan))
+    }
+    if len(filter.BidangIndustri) > 0 {
+        add("LOWER(TRIM(p.bidang_industri)) = ANY($%d)", pq.Array(lowerAll(filter.BidangIndustri)))
+    }
+    if len(filter.LokasiKerja) > 0 {
+        add("LOWER(TRIM(p.lokasi_kerja)) = ANY($%d)", pq.Array(lowerAll(filter.LokasiKerja)))
+    }
+    if filter.TanggalMulaiFrom != nil {
+        add("p.tanggal_mulai_kerja >= $%d", *filter.TanggalMulaiFrom)
+    }
+    if filter.TanggalMulaiTo != nil {
+        add("p.tanggal_mulai_kerja <= $%d", *filter.TanggalMulaiTo)
+    }
+    if filter.TanggalSelesaiFrom != nil {
+        add("p.tanggal_selesai_kerja >= $%d", *filter.TanggalSelesaiFrom)
+    }
+    if filter.TanggalSelesaiTo != nil {
+        add("p.tanggal_selesai_kerja <= $%d", *filter.TanggalSelesaiTo)
+    }
+    if len(filter.Jurusan) > 0 {
+        add("LOWER(TRIM(a.jurusan)) = ANY($%d)", pq.Array(lowerAll(filter.Jurusan)))
+    }
+    if filter.AngkatanFrom != nil {
+        add("a.angkatan >= $%d", *filter.AngkatanFrom)
+    }
+    if filter.AngkatanTo != nil {
+        add("a.angkatan <= $%d", *filter.AngkatanTo)
+    }
+    // Sedang bekerja pada tanggal X: sudah mulai dan belum selesai (tanpa tanggal selesai = masih berjalan)
+    if filter.EmployedAt != nil {
+        add("p.tanggal_mulai_kerja <= $%d", *filter.EmployedAt)
+        add("(p.tanggal_selesai_kerja IS NULL OR p.tanggal_selesai_kerja >= $%d)", *filter.EmployedAt)
+    }
+
+    if len(conditions) == 0 {
+        return "", args
+    }
+    return " AND " + strings.Join(conditions, " AND "), args
+}
+
+// GetAllPaginated - ambil data pekerjaan dengan pagination, search (full-text + trigram), sorting, dan filter.
+// Jika search diisi, setiap pekerjaan membawa skor relevansi dan cuplikan yang cocok.
+func (r *pekerjaanRepository) GetAllPaginated(search, sortBy, order string, filter models.PekerjaanFilter, limit, offset int) ([]models.PekerjaanAlumni, error) {
+    where, args := buildPekerjaanFilter(filter, []interface{}{search})
+    args = append(args, limit, offset)
+    tail := fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", searchOrder(sortBy, order, "p."+sortBy, "p.id"), len(args)-1, len(args))
+    pekerjaanList, _, err := r.queryList(search, "p.id", "", where+tail, args)
+    return pekerjaanList, err
+}
+
+// GetAllKeyset - sama seperti GetAllPaginated tetapi dengan keyset (cursor) dan tanpa OFFSET.
+// Mengembalikan sampai page.Limit+1 baris beserta posisi keyset tiap baris.
+func (r *pekerjaanRepository) GetAllKeyset(search, sortBy, order string, filter models.PekerjaanFilter, page KeysetPage) ([]models.PekerjaanAlumni, []Keyset, error) {
+    where, args := buildPekerjaanFilter(filter, []interface{}{search})
+    tail, args := page.clause("p."+sortBy, "p.id", nullablePekerjaanSort[sortBy], order, args)
+    return r.queryList(search, "p."+sortBy, "", where+tail, args)
+}
+
+// Kolom sortBy pekerjaan yang boleh NULL (perlu perlakuan khusus di keyset)
+var nullablePekerjaanSort = map[string]bool{"gaji_min": true, "gaji_max": true}
+
+// queryList - SELECT daftar pekerjaan + alumni dengan kolom pencarian. scope adalah kondisi awal
+// (mis. "p.is_deleted = TRUE AND "), tail berisi kondisi tambahan, ORDER BY, dan LIMIT.
+// Nilai sortColumn ikut diambil sebagai teks untuk membentuk Keyset tiap baris.
+func (r *pekerjaanRepository) queryList(search, sortColumn, scope, tail string, args []interface{}) ([]models.PekerjaanAlumni, []Keyset, error) {
     query := fmt.Sprintf(`
-        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, 
-               p.bidang_industri, p.lokasi_kerja, p.gaji_range, 
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan, 
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
                p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
                p.status_pekerjaan, p.deskripsi_pekerjaan, 
                p.created_at, p.updated_at,
-               a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email
+               a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email,
+               %s, %s::text
         FROM pekerjaan_alumni p
         JOIN alumni a ON p.alumni_id = a.id
-        WHERE p.nama_perusahaan ILIKE $1 OR p.posisi_jabatan ILIKE $1 OR a.nama ILIKE $1
-        ORDER BY %s %s
-        LIMIT $2 OFFSET $3
-    `, sortBy, order)
+        WHERE %s%s%s
+    `, pekerjaanSearch.columns(), sortColumn, scope, pekerjaanSearch.condition(), tail)
     
-    rows, err := r.db.Query(query, "%"+search+"%", limit, offset)
+    rows, err := r.db.Query(query, args...)
     if err != nil {
-        return nil, err
+        return nil, nil, err
     }
     defer rows.Close()
 
     var pekerjaanList []models.PekerjaanAlumni
+    var keys []Keyset
     for rows.Next() {
         var pekerjaan models.PekerjaanAlumni
         var alumni models.Alumni
+        var score float64
+        var sortValue sql.NullString
+        headlines := make([]sql.NullString, len(pekerjaanSearch.highlights))
         
-        err := rows.Scan(
-            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+        err := rows.Scan(append(append([]interface{}{
+            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
             &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-            &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+            &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+            &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
             &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
             &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
             &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
             &alumni.TahunLulus, &alumni.Email,
-        )
+        }, pekerjaanSearch.scanTargets(&score, headlines)...), &sortValue)...)
         if err != nil {
-            return nil, err
+            return nil, nil, err
+        }
+        if search != "" {
+            pekerjaan.Score = &score
+            pekerjaan.Highlights = pekerjaanSearch.highlightMap(headlines)
         }
         
         alumni.ID = pekerjaan.AlumniID
         pekerjaan.Alumni = &alumni
         pekerjaanList = append(pekerjaanList, pekerjaan)
+        keys = append(keys, newKeyset(sortValue, pekerjaan.ID))
     }
 
-    return pekerjaanList, nil
+    return pekerjaanList, keys, nil
 }
 
 // CountPekerjaan - hitung total data pekerjaan untuk pagination
-func (r *pekerjaanRepository) CountPekerjaan(search string) (int, error) {
+func (r *pekerjaanRepository) CountPekerjaan(search string, filter models.PekerjaanFilter) (int, error) {
     var total int
+    where, args := buildPekerjaanFilter(filter, []interface{}{search})
     countQuery := `
         SELECT COUNT(*) FROM pekerjaan_alumni p
         JOIN alumni a ON p.alumni_id = a.id
-        WHERE p.nama_perusahaan ILIKE $1 OR p.posisi_jabatan ILIKE $1 OR a.nama ILIKE $1
-    `
-    err := r.db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+        WHERE ` + pekerjaanSearch.condition() + where
+    err := r.db.QueryRow(countQuery, args...).Scan(&total)
     if err != nil && err != sql.ErrNoRows {
         return 0, err
     }
@@ -162,8 +269,8 @@ func (r *pekerjaanRepository) CountPekerjaan(search string) (int, error) {
 
 func (r *pekerjaanRepository) GetAllNon() ([]models.PekerjaanAlumni, error) {
     query := `
-        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, 
-               p.bidang_industri, p.lokasi_kerja, p.gaji_range, 
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan, 
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
                p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
                p.status_pekerjaan, p.deskripsi_pekerjaan, 
                p.created_at, p.updated_at,
@@ -185,9 +292,10 @@ func (r *pekerjaanRepository) GetAllNon() ([]models.PekerjaanAlumni, error) {
         var alumni models.Alumni
         
         err := rows.Scan(
-            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
             &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-            &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+            &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+            &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
             &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
             &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
             &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
@@ -208,8 +316,8 @@ func (r *pekerjaanRepository) GetAllNon() ([]models.PekerjaanAlumni, error) {
 
 func (r *pekerjaanRepository) GetByID(id int) (*models.PekerjaanAlumni, error) {
     query := `
-        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, 
-               p.bidang_industri, p.lokasi_kerja, p.gaji_range, 
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan, 
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
                p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
                p.status_pekerjaan, p.deskripsi_pekerjaan, 
                p.is_deleted, p.created_at, p.updated_at,
@@ -224,9 +332,51 @@ func (r *pekerjaanRepository) GetByID(id int) (*models.PekerjaanAlumni, error) {
     row := r.db.QueryRow(query, id)
     
     err := row.Scan(
-        &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+        &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
+        &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+        &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+        &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+        &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDeleted,
+        &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+        &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
+        &alumni.TahunLulus, &alumni.Email, &alumni.UserID,
+    )
+    
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return nil, nil
+        }
+        return nil, err
+    }
+
+    alumni.ID = pekerjaan.AlumniID
+    pekerjaan.Alumni = &alumni
+    return &pekerjaan, nil
+}
+
+// GetTrashedByID - ambil satu data pekerjaan yang ada di trash (is_deleted = TRUE)
+func (r *pekerjaanRepository) GetTrashedByID(id int) (*models.PekerjaanAlumni, error) {
+    query := `
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan, 
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
+               p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
+               p.status_pekerjaan, p.deskripsi_pekerjaan, 
+               p.is_deleted, p.created_at, p.updated_at,
+               a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, a.user_id
+        FROM pekerjaan_alumni p
+        JOIN alumni a ON p.alumni_id = a.id
+        WHERE p.id = $1 AND p.is_deleted = TRUE
+    `
+    
+    var pekerjaan models.PekerjaanAlumni
+    var alumni models.Alumni
+    row := r.db.QueryRow(query, id)
+    
+    err := row.Scan(
+        &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
         &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-        &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+        &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+        &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
         &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDeleted,
         &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
         &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
@@ -247,8 +397,8 @@ func (r *pekerjaanRepository) GetByID(id int) (*models.PekerjaanAlumni, error) {
 
 func (r *pekerjaanRepository) GetByAlumniID(alumniID int) ([]models.PekerjaanAlumni, error) {
     query := `
-        SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, 
-               bidang_industri, lokasi_kerja, gaji_range, 
+        SELECT id, alumni_id, nama_perusahaan, company_id, posisi_jabatan, 
+               bidang_industri, lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency,
                tanggal_mulai_kerja, tanggal_selesai_kerja, 
                status_pekerjaan, deskripsi_pekerjaan, 
                created_at, updated_at
@@ -267,9 +417,10 @@ func (r *pekerjaanRepository) GetByAlumniID(alumniID int) ([]models.PekerjaanAlu
     for rows.Next() {
         var pekerjaan models.PekerjaanAlumni
         err := rows.Scan(
-            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
             &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-            &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+            &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+            &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
             &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
             &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
         )
@@ -282,14 +433,97 @@ func (r *pekerjaanRepository) GetByAlumniID(alumniID int) ([]models.PekerjaanAlu
     return pekerjaanList, nil
 }
 
+// GetNonDeletedByAlumniID - pekerjaan alumni yang belum di-soft delete, urut dari yang paling awal dimulai
+func (r *pekerjaanRepository) GetNonDeletedByAlumniID(alumniID int) ([]models.PekerjaanAlumni, error) {
+    query := `
+        SELECT id, alumni_id, nama_perusahaan, company_id, posisi_jabatan, 
+               bidang_industri, lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency,
+               tanggal_mulai_kerja, tanggal_selesai_kerja, 
+               status_pekerjaan, deskripsi_pekerjaan, 
+               is_deleted, created_at, updated_at
+        FROM pekerjaan_alumni
+        WHERE alumni_id = $1 AND is_deleted = FALSE
+        ORDER BY tanggal_mulai_kerja ASC, id ASC
+    `
+    
+    rows, err := r.db.Query(query, alumniID)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    pekerjaanList := []models.PekerjaanAlumni{}
+    for rows.Next() {
+        var pekerjaan models.PekerjaanAlumni
+        err := rows.Scan(
+            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
+            &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
+            &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+            &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+            &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDeleted,
+            &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
+        )
+        if err != nil {
+            return nil, err
+        }
+        pekerjaanList = append(pekerjaanList, pekerjaan)
+    }
+
+    return pekerjaanList, rows.Err()
+}
+
+// GetActiveIDsByAlumniID - ID pekerjaan aktif (belum dihapus) milik alumni, kecuali excludeID
+func (r *pekerjaanRepository) GetActiveIDsByAlumniID(alumniID, excludeID int) ([]int, error) {
+    query := `
+        SELECT id FROM pekerjaan_alumni
+        WHERE alumni_id = $1 AND id <> $2 AND is_deleted = FALSE AND status_pekerjaan = 'aktif'
+        ORDER BY tanggal_mulai_kerja ASC, id ASC
+    `
+    rows, err := r.db.Query(query, alumniID, excludeID)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    ids := []int{}
+    for rows.Next() {
+        var id int
+        if err := rows.Scan(&id); err != nil {
+            return nil, err
+        }
+        ids = append(ids, id)
+    }
+    return ids, rows.Err()
+}
+
+// EndJob - ubah pekerjaan aktif menjadi selesai/resigned dengan tanggal selesai. Return nil jika
+// pekerjaan tidak ada atau sudah tidak aktif.
+func (r *pekerjaanRepository) EndJob(id int, status string, tanggalSelesai time.Time) (*models.PekerjaanAlumni, error) {
+    query := `
+        UPDATE pekerjaan_alumni
+        SET status_pekerjaan = $1, tanggal_selesai_kerja = $2, updated_at = $3
+        WHERE id = $4 AND is_deleted = FALSE AND status_pekerjaan = 'aktif'
+    `
+    result, err := r.db.Exec(query, status, tanggalSelesai, time.Now(), id)
+    if err != nil {
+        return nil, err
+    }
+
+    rowsAffected, _ := result.RowsAffected()
+    if rowsAffected == 0 {
+        return nil, nil
+    }
+    return r.GetByID(id)
+}
+
 func (r *pekerjaanRepository) Create(req *models.CreatePekerjaanRequest) (*models.PekerjaanAlumni, error) {
     query := `
-        INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, posisi_jabatan, 
-                                    bidang_industri, lokasi_kerja, gaji_range, 
+        INSERT INTO pekerjaan_alumni (alumni_id, nama_perusahaan, company_id, posisi_jabatan, 
+                                    bidang_industri, lokasi_kerja, gaji_range, gaji_min, gaji_max, gaji_currency,
                                     tanggal_mulai_kerja, tanggal_selesai_kerja, 
                                     status_pekerjaan, deskripsi_pekerjaan, 
                                     created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
         RETURNING id, created_at, updated_at
     `
     
@@ -297,8 +531,9 @@ func (r *pekerjaanRepository) Create(req *models.CreatePekerjaanRequest) (*model
     var pekerjaan models.PekerjaanAlumni
     
     err := r.db.QueryRow(
-        query, req.AlumniID, req.NamaPerusahaan, req.PosisiJabatan,
+        query, req.AlumniID, req.NamaPerusahaan, req.CompanyID, req.PosisiJabatan,
         req.BidangIndustri, req.LokasiKerja, req.GajiRange,
+        req.GajiMin, req.GajiMax, req.GajiCurrency,
         req.TanggalMulaiKerja, req.TanggalSelesaiKerja, req.StatusPekerjaan,
         req.DeskripsiPekerjaan, now, now,
     ).Scan(&pekerjaan.ID, &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt)
@@ -306,14 +541,19 @@ func (r *pekerjaanRepository) Create(req *models.CreatePekerjaanRequest) (*model
     if err != nil {
         return nil, err
     }
+    invalidateSuggestions("pekerjaan_alumni")
 
     // Set data dari request
     pekerjaan.AlumniID = req.AlumniID
     pekerjaan.NamaPerusahaan = req.NamaPerusahaan
+    pekerjaan.CompanyID = req.CompanyID
     pekerjaan.PosisiJabatan = req.PosisiJabatan
     pekerjaan.BidangIndustri = req.BidangIndustri
     pekerjaan.LokasiKerja = req.LokasiKerja
     pekerjaan.GajiRange = req.GajiRange
+    pekerjaan.GajiMin = req.GajiMin
+    pekerjaan.GajiMax = req.GajiMax
+    pekerjaan.GajiCurrency = req.GajiCurrency
     pekerjaan.TanggalMulaiKerja = req.TanggalMulaiKerja
     pekerjaan.TanggalSelesaiKerja = req.TanggalSelesaiKerja
     pekerjaan.StatusPekerjaan = req.StatusPekerjaan
@@ -322,22 +562,24 @@ func (r *pekerjaanRepository) Create(req *models.CreatePekerjaanRequest) (*model
     return &pekerjaan, nil
 }
 
-func (r *pekerjaanRepository) Update(id int, req *models.UpdatePekerjaanRequest) (*models.PekerjaanAlumni, error) {
+func (r *pekerjaanRepository) Update(id int, req *models.UpdatePekerjaanRequest, updatedAt time.Time) (*models.PekerjaanAlumni, error) {
     query := `
         UPDATE pekerjaan_alumni 
         SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3,
-            lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6,
-            tanggal_selesai_kerja = $7, status_pekerjaan = $8, 
-            deskripsi_pekerjaan = $9, updated_at = $10
-        WHERE id = $11
+            lokasi_kerja = $4, gaji_range = $5, gaji_min = $6, gaji_max = $7,
+            gaji_currency = $8, tanggal_mulai_kerja = $9,
+            tanggal_selesai_kerja = $10, status_pekerjaan = $11, 
+            deskripsi_pekerjaan = $12, updated_at = $13, company_id = $14
+        WHERE id = $15 AND updated_at = $16
     `
     
     now := time.Now()
     result, err := r.db.Exec(
         query, req.NamaPerusahaan, req.PosisiJabatan, req.BidangIndustri,
-        req.LokasiKerja, req.GajiRange, req.TanggalMulaiKerja,
+        req.LokasiKerja, req.GajiRange, req.GajiMin, req.GajiMax,
+        req.GajiCurrency, req.TanggalMulaiKerja,
         req.TanggalSelesaiKerja, req.StatusPekerjaan, req.DeskripsiPekerjaan,
-        now, id,
+        now, req.CompanyID, id, updatedAt,
     )
     
     if err != nil {
@@ -346,84 +588,75 @@ func (r *pekerjaanRepository) Update(id int, req *models.UpdatePekerjaanRequest)
 
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return nil, nil
+        return nil, staleOrMissing(r.db, "pekerjaan_alumni", id, nil)
     }
+    invalidateSuggestions("pekerjaan_alumni")
 
     // Get updated data
     return r.GetByID(id)
 }
 
-func (r *pekerjaanRepository) Delete(id int) error {
-    query := "DELETE FROM pekerjaan_alumni WHERE id = $1"
-    result, err := r.db.Exec(query, id)
+// Patch - UPDATE hanya kolom yang berubah (lihat pekerjaanPatchColumns). Return nil jika pekerjaan tidak ada.
+func (r *pekerjaanRepository) Patch(id int, changes map[string]interface{}, updatedAt time.Time) (*models.PekerjaanAlumni, error) {
+    query, args, err := patchQuery("pekerjaan_alumni", pekerjaanPatchColumns, id, updatedAt, changes)
     if err != nil {
-        return err
+        return nil, err
+    }
+
+    result, err := r.db.Exec(query, args...)
+    if err != nil {
+        return nil, err
     }
 
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return sql.ErrNoRows
+        return nil, staleOrMissing(r.db, "pekerjaan_alumni", id, nil)
     }
+    invalidateSuggestions("pekerjaan_alumni")
 
-    return nil
+    return r.GetByID(id)
 }
-// GetTrashedPaginated - ambil data pekerjaan yang di-soft delete dengan pagination, search, dan sorting
-func (r *pekerjaanRepository) GetTrashedPaginated(search, sortBy, order string, limit, offset int) ([]models.PekerjaanAlumni, error) {
-    query := fmt.Sprintf(`
-        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, 
-               p.bidang_industri, p.lokasi_kerja, p.gaji_range, 
-               p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
-               p.status_pekerjaan, p.deskripsi_pekerjaan, 
-               p.created_at, p.updated_at,
-               a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email
-        FROM pekerjaan_alumni p
-        JOIN alumni a ON p.alumni_id = a.id
-        WHERE p.is_deleted = TRUE AND (p.nama_perusahaan ILIKE $1 OR p.posisi_jabatan ILIKE $1 OR a.nama ILIKE $1)
-        ORDER BY %s %s
-        LIMIT $2 OFFSET $3
-    `, sortBy, order)
-    
-    rows, err := r.db.Query(query, "%"+search+"%", limit, offset)
+
+func (r *pekerjaanRepository) Delete(id int, updatedAt time.Time) error {
+    query := "DELETE FROM pekerjaan_alumni WHERE id = $1 AND updated_at = $2"
+    result, err := r.db.Exec(query, id, updatedAt)
     if err != nil {
-        return nil, err
+        return err
     }
-    defer rows.Close()
 
-    var pekerjaanList []models.PekerjaanAlumni
-    for rows.Next() {
-        var pekerjaan models.PekerjaanAlumni
-        var alumni models.Alumni
-        
-        err := rows.Scan(
-            &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
-            &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-            &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
-            &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan,
-            &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
-            &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
-            &alumni.TahunLulus, &alumni.Email,
-        )
-        if err != nil {
-            return nil, err
-        }
-        
-        alumni.ID = pekerjaan.AlumniID
-        pekerjaan.Alumni = &alumni
-        pekerjaanList = append(pekerjaanList, pekerjaan)
+    rowsAffected, _ := result.RowsAffected()
+    if rowsAffected == 0 {
+        return staleOrMissing(r.db, "pekerjaan_alumni", id, sql.ErrNoRows)
     }
+    invalidateSuggestions("pekerjaan_alumni")
 
-    return pekerjaanList, nil
+    return nil
+}
+// GetTrashedPaginated - ambil data pekerjaan yang di-soft delete dengan pagination, search, filter, dan sorting
+func (r *pekerjaanRepository) GetTrashedPaginated(search, sortBy, order string, filter models.PekerjaanFilter, limit, offset int) ([]models.PekerjaanAlumni, error) {
+    where, args := buildPekerjaanFilter(filter, []interface{}{search})
+    args = append(args, limit, offset)
+    tail := fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", searchOrder(sortBy, order, "p."+sortBy, "p.id"), len(args)-1, len(args))
+    pekerjaanList, _, err := r.queryList(search, "p.id", "p.is_deleted = TRUE AND ", where+tail, args)
+    return pekerjaanList, err
+}
+
+// GetTrashedKeyset - GetTrashedPaginated dengan keyset (cursor), lihat GetAllKeyset
+func (r *pekerjaanRepository) GetTrashedKeyset(search, sortBy, order string, filter models.PekerjaanFilter, page KeysetPage) ([]models.PekerjaanAlumni, []Keyset, error) {
+    where, args := buildPekerjaanFilter(filter, []interface{}{search})
+    tail, args := page.clause("p."+sortBy, "p.id", nullablePekerjaanSort[sortBy], order, args)
+    return r.queryList(search, "p."+sortBy, "p.is_deleted = TRUE AND ", where+tail, args)
 }
 
 // CountTrashed - hitung total data pekerjaan yang di-soft delete untuk pagination
-func (r *pekerjaanRepository) CountTrashed(search string) (int, error) {
+func (r *pekerjaanRepository) CountTrashed(search string, filter models.PekerjaanFilter) (int, error) {
     var total int
+    where, args := buildPekerjaanFilter(filter, []interface{}{search})
     countQuery := `
         SELECT COUNT(*) FROM pekerjaan_alumni p
         JOIN alumni a ON p.alumni_id = a.id
-        WHERE p.is_deleted = TRUE AND (p.nama_perusahaan ILIKE $1 OR p.posisi_jabatan ILIKE $1 OR a.nama ILIKE $1)
-    `
-    err := r.db.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+        WHERE p.is_deleted = TRUE AND ` + pekerjaanSearch.condition() + where
+    err := r.db.QueryRow(countQuery, args...).Scan(&total)
     if err != nil && err != sql.ErrNoRows {
         return 0, err
     }
@@ -432,37 +665,38 @@ func (r *pekerjaanRepository) CountTrashed(search string) (int, error) {
 
 
 // HardDeleteTrashed - hapus permanen data pekerjaan dari trash (hanya jika is_deleted = TRUE)
-func (r *pekerjaanRepository) HardDeleteTrashed(id int) error {
-    query := `DELETE FROM pekerjaan_alumni WHERE id = $1 AND is_deleted = TRUE`
-    result, err := r.db.Exec(query, id)
+func (r *pekerjaanRepository) HardDeleteTrashed(id int, updatedAt time.Time) error {
+    query := `DELETE FROM pekerjaan_alumni WHERE id = $1 AND is_deleted = TRUE AND updated_at = $2`
+    result, err := r.db.Exec(query, id, updatedAt)
     if err != nil {
         return err
     }
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return sql.ErrNoRows
+        return staleOrMissing(r.db, "pekerjaan_alumni", id, sql.ErrNoRows)
     }
     return nil
 }
 
 // RestoreTrashed - kembalikan data pekerjaan dari trash (set is_deleted = FALSE, return data updated)
-func (r *pekerjaanRepository) RestoreTrashed(id int) (*models.PekerjaanAlumni, error) {
+func (r *pekerjaanRepository) RestoreTrashed(id int, updatedAt time.Time) (*models.PekerjaanAlumni, error) {
    
-    updateQuery := `UPDATE pekerjaan_alumni SET is_deleted = FALSE, updated_at = $1 WHERE id = $2 AND is_deleted = TRUE`
+    updateQuery := `UPDATE pekerjaan_alumni SET is_deleted = FALSE, updated_at = $1 WHERE id = $2 AND is_deleted = TRUE AND updated_at = $3`
     now := time.Now()
-    result, err := r.db.Exec(updateQuery, now, id)
+    result, err := r.db.Exec(updateQuery, now, id, updatedAt)
     if err != nil {
         return nil, err
     }
     rowsAffected, _ := result.RowsAffected()
     if rowsAffected == 0 {
-        return nil, sql.ErrNoRows 
+        return nil, staleOrMissing(r.db, "pekerjaan_alumni", id, sql.ErrNoRows)
     }
+    invalidateSuggestions("pekerjaan_alumni")
 
     
     query := `
-        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.posisi_jabatan, 
-               p.bidang_industri, p.lokasi_kerja, p.gaji_range, 
+        SELECT p.id, p.alumni_id, p.nama_perusahaan, p.company_id, p.posisi_jabatan, 
+               p.bidang_industri, p.lokasi_kerja, p.gaji_range, p.gaji_min, p.gaji_max, p.gaji_currency,
                p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, 
                p.status_pekerjaan, p.deskripsi_pekerjaan, 
                p.is_deleted, p.created_at, p.updated_at,
@@ -477,9 +711,10 @@ func (r *pekerjaanRepository) RestoreTrashed(id int) (*models.PekerjaanAlumni, e
     row := r.db.QueryRow(query, id)
     
     err = row.Scan(
-        &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan,
+        &pekerjaan.ID, &pekerjaan.AlumniID, &pekerjaan.NamaPerusahaan, &pekerjaan.CompanyID,
         &pekerjaan.PosisiJabatan, &pekerjaan.BidangIndustri, &pekerjaan.LokasiKerja,
-        &pekerjaan.GajiRange, &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
+        &pekerjaan.GajiRange, &pekerjaan.GajiMin, &pekerjaan.GajiMax, &pekerjaan.GajiCurrency,
+        &pekerjaan.TanggalMulaiKerja, &pekerjaan.TanggalSelesaiKerja,
         &pekerjaan.StatusPekerjaan, &pekerjaan.DeskripsiPekerjaan, &pekerjaan.IsDeleted,
         &pekerjaan.CreatedAt, &pekerjaan.UpdatedAt,
         &alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan,
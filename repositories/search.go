@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// SortRelevance - nilai sortBy untuk mengurutkan hasil pencarian berdasarkan skor relevansi
+const SortRelevance = "relevance"
+
+// Kata kunci pencarian selalu parameter $1. Kata kunci kosong berarti tanpa filter
+// (semua baris lolos, skor 0, tanpa headline).
+const (
+	searchTSQuery         = "websearch_to_tsquery('simple', $1)"
+	searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+)
+
+// textSearch - definisi pencarian untuk satu query (lihat migrations/007_full_text_search.sql)
+type textSearch struct {
+	vector     string   // ekspresi tsvector, harus sama persis dengan expression index
+	fuzzy      []string // kolom nama yang dicocokkan dengan trigram (tahan salah ketik)
+	substring  []string // kolom yang tetap dicari dengan ILIKE '%kata%' (potongan NIM, email, ...)
+	highlights []string // kolom yang diberi cuplikan <mark> di response
+}
+
+var alumniSearch = textSearch{
+	vector:     "alumni_search_vector(a.nama, a.nim, a.email, a.jurusan)",
+	fuzzy:      []string{"a.nama"},
+	substring:  []string{"a.nama", "a.email", "a.nim", "a.jurusan"},
+	highlights: []string{"a.nama", "a.jurusan"},
+}
+
+var pekerjaanSearch = textSearch{
+	vector:     "pekerjaan_search_vector(p.nama_perusahaan, p.posisi_jabatan, p.bidang_industri, p.lokasi_kerja, p.deskripsi_pekerjaan)",
+	fuzzy:      []string{"p.nama_perusahaan", "p.posisi_jabatan", "a.nama"},
+	substring:  []string{"p.nama_perusahaan", "p.posisi_jabatan", "a.nama"},
+	highlights: []string{"p.nama_perusahaan", "p.posisi_jabatan", "p.deskripsi_pekerjaan"},
+}
+
+// condition - cocok jika lolos full-text, mirip secara trigram, atau mengandung kata kunci
+func (t textSearch) condition() string {
+	parts := []string{t.vector + " @@ " + searchTSQuery}
+	for _, column := range t.fuzzy {
+		parts = append(parts, "$1 <% "+column)
+	}
+	for _, column := range t.substring {
+		parts = append(parts, column+" ILIKE '%' || $1 || '%'")
+	}
+	return "($1 = '' OR " + strings.Join(parts, " OR ") + ")"
+}
+
+// columns - kolom tambahan SELECT: skor relevansi lalu satu headline per kolom highlights
+func (t textSearch) columns() string {
+	similarities := make([]string, len(t.fuzzy))
+	for i, column := range t.fuzzy {
+		similarities[i] = "word_similarity($1, " + column + ")"
+	}
+	columns := []string{fmt.Sprintf("CASE WHEN $1 = '' THEN 0 ELSE ts_rank_cd(%s, %s) + GREATEST(%s) END AS relevance",
+		t.vector, searchTSQuery, strings.Join(similarities, ", "))}
+	for _, column := range t.highlights {
+		columns = append(columns, fmt.Sprintf("CASE WHEN $1 = '' THEN NULL ELSE ts_headline('simple', %s, %s, '%s') END",
+			column, searchTSQuery, searchHeadlineOptions))
+	}
+	return strings.Join(columns, ", ")
+}
+
+// scanTargets - tujuan Scan untuk kolom dari columns()
+func (t textSearch) scanTargets(score *float64, headlines []sql.NullString) []interface{} {
+	targets := []interface{}{score}
+	for i := range headlines {
+		targets = append(targets, &headlines[i])
+	}
+	return targets
+}
+
+// highlightMap - cuplikan yang benar-benar mengandung kata kunci, kunci = nama kolom tanpa alias tabel
+func (t textSearch) highlightMap(headlines []sql.NullString) map[string]string {
+	var result map[string]string
+	for i, headline := range headlines {
+		if !headline.Valid || !strings.Contains(headline.String, "<mark>") {
+			continue
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		column := t.highlights[i]
+		result[column[strings.Index(column, ".")+1:]] = headline.String
+	}
+	return result
+}
+
+// searchOrder - ORDER BY kolom sortBy; relevance diurutkan berdasarkan skor dengan id sebagai penentu
+// urutan yang stabil
+func searchOrder(sortBy, order, column, idColumn string) string {
+	if sortBy == SortRelevance {
+		return fmt.Sprintf("relevance %s, %s", order, idColumn)
+	}
+	return column + " " + order
+}
@@ -0,0 +1,301 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Kolom alumni yang boleh dipakai untuk group_by (dipakai langsung di SQL, jadi harus whitelist)
+var statsGroupColumns = map[string]string{
+	"":            "'semua'",
+	"angkatan":    "a.angkatan::text",
+	"jurusan":     "a.jurusan",
+	"tahun_lulus": "a.tahun_lulus::text",
+}
+
+// Kolom pekerjaan yang boleh dipakai untuk distribusi
+var statsDistributionColumns = map[string]string{
+	"bidang_industri": "TRIM(p.bidang_industri)",
+	"lokasi_kerja":    "TRIM(p.lokasi_kerja)",
+}
+
+// Kelompok untuk statistik gaji: kolom alumni ditambah bidang industri pekerjaan
+var statsSalaryGroupColumns = map[string]string{
+	"":                "'semua'",
+	"angkatan":        "a.angkatan::text",
+	"jurusan":         "a.jurusan",
+	"tahun_lulus":     "a.tahun_lulus::text",
+	"bidang_industri": "TRIM(p.bidang_industri)",
+}
+
+type StatsRepository interface {
+	GetEmploymentRate(groupBy string, filter models.StatsFilter) ([]models.EmploymentRateStat, error)
+	GetTimeToFirstJob(groupBy string, filter models.StatsFilter) ([]models.TimeToFirstJobStat, error)
+	GetDistribution(by string, onlyActive bool, filter models.StatsFilter) ([]models.DistributionStat, error)
+	GetActiveShare(filter models.StatsFilter) (*models.ActiveShareStat, error)
+	GetSalary(groupBy, currency string, onlyActive bool, filter models.StatsFilter) ([]models.SalaryStat, error)
+}
+
+type statsRepository struct {
+	db *sql.DB
+}
+
+func NewStatsRepository() StatsRepository {
+	return &statsRepository{db: config.DB}
+}
+
+// IsValidStatsGroup - cek apakah group_by ada di whitelist
+func IsValidStatsGroup(groupBy string) bool {
+	_, ok := statsGroupColumns[groupBy]
+	return ok
+}
+
+// IsValidStatsDistribution - cek apakah kolom distribusi ada di whitelist
+func IsValidStatsDistribution(by string) bool {
+	_, ok := statsDistributionColumns[by]
+	return ok
+}
+
+// IsValidSalaryGroup - cek apakah group_by statistik gaji ada di whitelist
+func IsValidSalaryGroup(groupBy string) bool {
+	_, ok := statsSalaryGroupColumns[groupBy]
+	return ok
+}
+
+// buildStatsWhere - kondisi filter alumni (alias a) dengan parameter placeholder
+func buildStatsWhere(filter models.StatsFilter) (string, []interface{}) {
+	conditions := []string{"a.is_deleted = FALSE"}
+	var args []interface{}
+
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Jurusan != "" {
+		add("LOWER(a.jurusan) = LOWER($%d)", filter.Jurusan)
+	}
+	if filter.Angkatan != nil {
+		add("a.angkatan = $%d", *filter.Angkatan)
+	}
+	if filter.TahunLulus != nil {
+		add("a.tahun_lulus = $%d", *filter.TahunLulus)
+	}
+	if filter.TahunLulusFrom != nil {
+		add("a.tahun_lulus >= $%d", *filter.TahunLulusFrom)
+	}
+	if filter.TahunLulusTo != nil {
+		add("a.tahun_lulus <= $%d", *filter.TahunLulusTo)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// GetEmploymentRate - alumni yang pernah/sedang bekerja dibanding total alumni per kelompok
+func (r *statsRepository) GetEmploymentRate(groupBy string, filter models.StatsFilter) ([]models.EmploymentRateStat, error) {
+	where, args := buildStatsWhere(filter)
+	query := fmt.Sprintf(`
+        SELECT %s AS grp,
+               COUNT(*),
+               COUNT(*) FILTER (WHERE EXISTS (
+                   SELECT 1 FROM pekerjaan_alumni p
+                   WHERE p.alumni_id = a.id AND p.is_deleted = FALSE)),
+               COUNT(*) FILTER (WHERE EXISTS (
+                   SELECT 1 FROM pekerjaan_alumni p
+                   WHERE p.alumni_id = a.id AND p.is_deleted = FALSE AND p.status_pekerjaan = 'aktif'))
+        FROM alumni a
+        WHERE %s
+        GROUP BY grp
+        ORDER BY grp
+    `, statsGroupColumns[groupBy], where)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.EmploymentRateStat{}
+	for rows.Next() {
+		var stat models.EmploymentRateStat
+		if err := rows.Scan(&stat.Group, &stat.TotalAlumni, &stat.Employed, &stat.CurrentlyActive); err != nil {
+			return nil, err
+		}
+		if stat.TotalAlumni > 0 {
+			stat.EmploymentRate = float64(stat.Employed) / float64(stat.TotalAlumni)
+			stat.ActiveRate = float64(stat.CurrentlyActive) / float64(stat.TotalAlumni)
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// GetTimeToFirstJob - waktu tunggu dalam bulan, dihitung dari Januari tahun_lulus sampai
+// tanggal_mulai_kerja paling awal. Pekerjaan yang dimulai sebelum lulus dihitung 0 bulan.
+func (r *statsRepository) GetTimeToFirstJob(groupBy string, filter models.StatsFilter) ([]models.TimeToFirstJobStat, error) {
+	where, args := buildStatsWhere(filter)
+	query := fmt.Sprintf(`
+        WITH first_job AS (
+            SELECT a.id, %s AS grp, a.tahun_lulus, MIN(p.tanggal_mulai_kerja) AS first_start
+            FROM alumni a
+            JOIN pekerjaan_alumni p ON p.alumni_id = a.id AND p.is_deleted = FALSE
+            WHERE %s
+            GROUP BY a.id, grp, a.tahun_lulus
+        ), waits AS (
+            SELECT grp,
+                   ((EXTRACT(YEAR FROM first_start) - tahun_lulus) * 12
+                    + EXTRACT(MONTH FROM first_start) - 1)::float8 AS months
+            FROM first_job
+        )
+        SELECT grp, COUNT(*),
+               AVG(GREATEST(months, 0)),
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY GREATEST(months, 0)),
+               MIN(GREATEST(months, 0)),
+               MAX(GREATEST(months, 0)),
+               COUNT(*) FILTER (WHERE months < 0)
+        FROM waits
+        GROUP BY grp
+        ORDER BY grp
+    `, statsGroupColumns[groupBy], where)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.TimeToFirstJobStat{}
+	for rows.Next() {
+		var stat models.TimeToFirstJobStat
+		err := rows.Scan(
+			&stat.Group, &stat.Alumni, &stat.AvgMonths, &stat.MedianMonths,
+			&stat.MinMonths, &stat.MaxMonths, &stat.BeforeGraduation,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// GetDistribution - jumlah pekerjaan dan alumni per bidang_industri / lokasi_kerja
+func (r *statsRepository) GetDistribution(by string, onlyActive bool, filter models.StatsFilter) ([]models.DistributionStat, error) {
+	where, args := buildStatsWhere(filter)
+	if onlyActive {
+		where += " AND p.status_pekerjaan = 'aktif'"
+	}
+	column := statsDistributionColumns[by]
+	query := fmt.Sprintf(`
+        SELECT %s AS value, COUNT(*), COUNT(DISTINCT p.alumni_id)
+        FROM pekerjaan_alumni p
+        JOIN alumni a ON a.id = p.alumni_id
+        WHERE p.is_deleted = FALSE AND %s
+        GROUP BY value
+        ORDER BY COUNT(*) DESC, value ASC
+    `, column, where)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.DistributionStat{}
+	totalJobs := 0
+	for rows.Next() {
+		var stat models.DistributionStat
+		if err := rows.Scan(&stat.Value, &stat.Jobs, &stat.Alumni); err != nil {
+			return nil, err
+		}
+		totalJobs += stat.Jobs
+		stats = append(stats, stat)
+	}
+
+	for i := range stats {
+		if totalJobs > 0 {
+			stats[i].Share = float64(stats[i].Jobs) / float64(totalJobs)
+		}
+	}
+	return stats, nil
+}
+
+// GetActiveShare - porsi alumni yang saat ini punya pekerjaan berstatus aktif
+func (r *statsRepository) GetActiveShare(filter models.StatsFilter) (*models.ActiveShareStat, error) {
+	where, args := buildStatsWhere(filter)
+	query := fmt.Sprintf(`
+        SELECT COUNT(*),
+               COUNT(*) FILTER (WHERE EXISTS (
+                   SELECT 1 FROM pekerjaan_alumni p
+                   WHERE p.alumni_id = a.id AND p.is_deleted = FALSE AND p.status_pekerjaan = 'aktif'))
+        FROM alumni a
+        WHERE %s
+    `, where)
+
+	var stat models.ActiveShareStat
+	if err := r.db.QueryRow(query, args...).Scan(&stat.TotalAlumni, &stat.CurrentlyActive); err != nil {
+		return nil, err
+	}
+	if stat.TotalAlumni > 0 {
+		stat.Share = float64(stat.CurrentlyActive) / float64(stat.TotalAlumni)
+	}
+	return &stat, nil
+}
+
+// GetSalary - median dan persentil gaji per kelompok. Nilai gaji per pekerjaan adalah titik tengah
+// gaji_min..gaji_max (atau salah satunya jika hanya satu yang terisi); hanya satu mata uang per query.
+func (r *statsRepository) GetSalary(groupBy, currency string, onlyActive bool, filter models.StatsFilter) ([]models.SalaryStat, error) {
+	where, args := buildStatsWhere(filter)
+	if onlyActive {
+		where += " AND p.status_pekerjaan = 'aktif'"
+	}
+	args = append(args, currency)
+	query := fmt.Sprintf(`
+        WITH salaries AS (
+            SELECT %s AS grp, p.alumni_id,
+                   ((COALESCE(p.gaji_min, p.gaji_max) + COALESCE(p.gaji_max, p.gaji_min)) / 2.0)::float8 AS amount
+            FROM pekerjaan_alumni p
+            JOIN alumni a ON a.id = p.alumni_id
+            WHERE p.is_deleted = FALSE AND %s
+              AND p.gaji_currency = $%d
+              AND (p.gaji_min IS NOT NULL OR p.gaji_max IS NOT NULL)
+        )
+        SELECT grp, COUNT(*), COUNT(DISTINCT alumni_id),
+               MIN(amount),
+               percentile_cont(0.25) WITHIN GROUP (ORDER BY amount),
+               percentile_cont(0.5) WITHIN GROUP (ORDER BY amount),
+               percentile_cont(0.75) WITHIN GROUP (ORDER BY amount),
+               percentile_cont(0.9) WITHIN GROUP (ORDER BY amount),
+               MAX(amount),
+               AVG(amount)
+        FROM salaries
+        GROUP BY grp
+        ORDER BY grp
+    `, statsSalaryGroupColumns[groupBy], where, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.SalaryStat{}
+	for rows.Next() {
+		stat := models.SalaryStat{Currency: currency}
+		err := rows.Scan(
+			&stat.Group, &stat.Jobs, &stat.Alumni, &stat.Min, &stat.P25,
+			&stat.Median, &stat.P75, &stat.P90, &stat.Max, &stat.Avg,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
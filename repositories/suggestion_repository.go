@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SuggestionRepository interface {
+	Suggest(field, prefix string, limit int) ([]models.Suggestion, error)
+}
+
+// suggestionSource - asal nilai saran untuk satu field
+type suggestionSource struct {
+	table  string
+	column string
+}
+
+var suggestionSources = map[string]suggestionSource{
+	models.SuggestionJurusan:        {table: "alumni", column: "jurusan"},
+	models.SuggestionNamaPerusahaan: {table: "pekerjaan_alumni", column: "nama_perusahaan"},
+	models.SuggestionPosisiJabatan:  {table: "pekerjaan_alumni", column: "posisi_jabatan"},
+	models.SuggestionBidangIndustri: {table: "pekerjaan_alumni", column: "bidang_industri"},
+	models.SuggestionLokasiKerja:    {table: "pekerjaan_alumni", column: "lokasi_kerja"},
+}
+
+// Cache saran dipakai bersama semua repository di proses ini. Tulis ke alumni/pekerjaan_alumni lewat
+// alumniRepository/pekerjaanRepository langsung mengosongkan field tabel tersebut; TTL hanya pengaman
+// untuk perubahan dari luar proses (instance lain, migrasi manual).
+const (
+	suggestionCacheTTL       = 5 * time.Minute
+	suggestionCacheMaxPrefix = 500 // per field; jika penuh, cache field itu dikosongkan
+)
+
+var suggestions = &suggestionCache{entries: map[string]map[string]suggestionEntry{}, generations: map[string]int{}}
+
+type suggestionEntry struct {
+	values    []models.Suggestion
+	expiresAt time.Time
+}
+
+type suggestionCache struct {
+	mu          sync.RWMutex
+	entries     map[string]map[string]suggestionEntry // field -> prefix|limit -> hasil
+	generations map[string]int                        // naik setiap invalidasi field
+}
+
+// get - hasil cache; generation dipakai set agar hasil query yang selesai setelah invalidasi tidak disimpan
+func (c *suggestionCache) get(field, key string) (values []models.Suggestion, generation int, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[field][key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, c.generations[field], false
+	}
+	return entry.values, c.generations[field], true
+}
+
+func (c *suggestionCache) set(field, key string, generation int, values []models.Suggestion) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[field] != generation {
+		return
+	}
+	if len(c.entries[field]) >= suggestionCacheMaxPrefix {
+		delete(c.entries, field)
+	}
+	if c.entries[field] == nil {
+		c.entries[field] = map[string]suggestionEntry{}
+	}
+	c.entries[field][key] = suggestionEntry{values: values, expiresAt: time.Now().Add(suggestionCacheTTL)}
+}
+
+// invalidateSuggestions - kosongkan cache semua field yang nilainya berasal dari tabel-tabel ini
+func invalidateSuggestions(tables ...string) {
+	suggestions.mu.Lock()
+	defer suggestions.mu.Unlock()
+	for field, source := range suggestionSources {
+		for _, table := range tables {
+			if source.table == table {
+				delete(suggestions.entries, field)
+				suggestions.generations[field]++
+			}
+		}
+	}
+}
+
+type suggestionRepository struct {
+	db *sql.DB
+}
+
+func NewSuggestionRepository() SuggestionRepository {
+	return &suggestionRepository{db: config.DB}
+}
+
+// Suggest - nilai berbeda (tanpa membedakan huruf besar/kecil) yang diawali prefix atau punya kata yang
+// diawali prefix. Nilai yang diawali prefix didahulukan, lalu yang paling sering dipakai; untuk tiap
+// nilai ditampilkan penulisan yang paling sering.
+func (r *suggestionRepository) Suggest(field, prefix string, limit int) ([]models.Suggestion, error) {
+	source, ok := suggestionSources[field]
+	if !ok {
+		return nil, fmt.Errorf("unknown suggestion field %q", field)
+	}
+
+	prefix = strings.TrimSpace(prefix)
+	key := strings.ToLower(prefix) + "|" + strconv.Itoa(limit)
+	values, generation, ok := suggestions.get(field, key)
+	if ok {
+		return values, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT value, total FROM (
+			SELECT DISTINCT ON (lower(btrim(%[1]s))) btrim(%[1]s) AS value,
+			       SUM(COUNT(*)) OVER (PARTITION BY lower(btrim(%[1]s)))::int AS total
+			FROM %[2]s
+			WHERE is_deleted = FALSE AND btrim(%[1]s) <> ''
+			  AND (%[1]s ILIKE $1 || '%%' OR %[1]s ILIKE '%% ' || $1 || '%%')
+			GROUP BY btrim(%[1]s)
+			ORDER BY lower(btrim(%[1]s)), COUNT(*) DESC, btrim(%[1]s)
+		) s
+		ORDER BY value ILIKE $1 || '%%' DESC, total DESC, value
+		LIMIT $2
+	`, source.column, source.table)
+
+	rows, err := r.db.Query(query, escapeLike(prefix), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values = []models.Suggestion{}
+	for rows.Next() {
+		var suggestion models.Suggestion
+		if err := rows.Scan(&suggestion.Value, &suggestion.Count); err != nil {
+			return nil, err
+		}
+		values = append(values, suggestion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	suggestions.set(field, key, generation, values)
+	return values, nil
+}
+
+// escapeLike - prefix dicocokkan apa adanya, bukan sebagai pola LIKE
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
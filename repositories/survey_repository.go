@@ -0,0 +1,542 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type SurveyRepository interface {
+	Create(req *models.CreateSurveyRequest, createdBy *int) (*models.Survey, error)
+	GetAll(status string) ([]models.Survey, error)
+	GetByID(id int) (*models.Survey, error)
+	Update(id int, req *models.UpdateSurveyRequest) (*models.Survey, error)
+	UpdateStatus(id int, status string) error
+	Delete(id int) error
+	GetOpenForAlumni(angkatan int, jurusan string) ([]models.Survey, error)
+	GetResponse(surveyID, alumniID int) (*models.SurveyResponse, error)
+	SaveResponse(surveyID, alumniID int, answers map[int]json.RawMessage, submit bool) (*models.SurveyResponse, error)
+	GetCompletion(surveyID int) (*models.SurveyCompletion, error)
+	GetResponsesForExport(surveyID int, status string) ([]models.SurveyResponse, error)
+}
+
+type surveyRepository struct {
+	db *sql.DB
+}
+
+func NewSurveyRepository() SurveyRepository {
+	return &surveyRepository{db: config.DB}
+}
+
+const surveySelect = `
+        SELECT id, judul, deskripsi, status, target_angkatan, target_jurusan,
+               opens_at, closes_at, created_by, created_at, updated_at
+        FROM surveys
+`
+
+func scanSurvey(scanner interface{ Scan(...interface{}) error }) (models.Survey, error) {
+	var survey models.Survey
+	var angkatan pq.Int64Array
+	var jurusan pq.StringArray
+	err := scanner.Scan(
+		&survey.ID, &survey.Judul, &survey.Deskripsi, &survey.Status, &angkatan, &jurusan,
+		&survey.OpensAt, &survey.ClosesAt, &survey.CreatedBy, &survey.CreatedAt, &survey.UpdatedAt,
+	)
+	if err != nil {
+		return survey, err
+	}
+	survey.TargetAngkatan = make([]int, len(angkatan))
+	for i, v := range angkatan {
+		survey.TargetAngkatan[i] = int(v)
+	}
+	survey.TargetJurusan = []string(jurusan)
+	if survey.TargetJurusan == nil {
+		survey.TargetJurusan = []string{}
+	}
+	return survey, nil
+}
+
+// Create - simpan survey beserta pertanyaannya dalam satu transaksi
+func (r *surveyRepository) Create(req *models.CreateSurveyRequest, createdBy *int) (*models.Survey, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO surveys (judul, deskripsi, status, target_angkatan, target_jurusan,
+                             opens_at, closes_at, created_by, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+        RETURNING id
+    `
+
+	var id int
+	err = tx.QueryRow(
+		query, req.Judul, req.Deskripsi, models.SurveyStatusDraft,
+		pq.Array(targetAngkatan(req.TargetAngkatan)), pq.Array(targetJurusan(req.TargetJurusan)),
+		req.OpensAt, req.ClosesAt, createdBy, time.Now(),
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertSurveyQuestions(tx, id, req.Questions); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// GetAll - daftar survey (tanpa pertanyaan), opsional filter status
+func (r *surveyRepository) GetAll(status string) ([]models.Survey, error) {
+	query := surveySelect + `
+        WHERE $1 = '' OR status = $1
+        ORDER BY created_at DESC
+    `
+
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var surveys []models.Survey
+	for rows.Next() {
+		survey, err := scanSurvey(rows)
+		if err != nil {
+			return nil, err
+		}
+		surveys = append(surveys, survey)
+	}
+
+	return surveys, nil
+}
+
+// GetByID - ambil survey beserta pertanyaan (urut berdasarkan urutan)
+func (r *surveyRepository) GetByID(id int) (*models.Survey, error) {
+	survey, err := scanSurvey(r.db.QueryRow(surveySelect+` WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	questions, err := r.getQuestions(id)
+	if err != nil {
+		return nil, err
+	}
+	survey.Questions = questions
+
+	return &survey, nil
+}
+
+func (r *surveyRepository) getQuestions(surveyID int) ([]models.SurveyQuestion, error) {
+	query := `
+        SELECT id, survey_id, urutan, kode, pertanyaan, tipe, options,
+               min_value, max_value, wajib, show_if
+        FROM survey_questions
+        WHERE survey_id = $1
+        ORDER BY urutan ASC
+    `
+
+	rows, err := r.db.Query(query, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var questions []models.SurveyQuestion
+	for rows.Next() {
+		var question models.SurveyQuestion
+		var options, showIf []byte
+		err := rows.Scan(
+			&question.ID, &question.SurveyID, &question.Urutan, &question.Kode, &question.Pertanyaan,
+			&question.Tipe, &options, &question.MinValue, &question.MaxValue, &question.Wajib, &showIf,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(options) > 0 {
+			if err := json.Unmarshal(options, &question.Options); err != nil {
+				return nil, err
+			}
+		}
+		if len(showIf) > 0 {
+			question.ShowIf = &models.QuestionCondition{}
+			if err := json.Unmarshal(showIf, question.ShowIf); err != nil {
+				return nil, err
+			}
+		}
+		questions = append(questions, question)
+	}
+
+	return questions, nil
+}
+
+// Update - ubah data survey dan ganti seluruh pertanyaan (hanya dipakai saat status draft)
+func (r *surveyRepository) Update(id int, req *models.UpdateSurveyRequest) (*models.Survey, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE surveys
+        SET judul = $1, deskripsi = $2, target_angkatan = $3, target_jurusan = $4,
+            opens_at = $5, closes_at = $6, updated_at = $7
+        WHERE id = $8
+    `
+	result, err := tx.Exec(
+		query, req.Judul, req.Deskripsi,
+		pq.Array(targetAngkatan(req.TargetAngkatan)), pq.Array(targetJurusan(req.TargetJurusan)),
+		req.OpensAt, req.ClosesAt, time.Now(), id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	if _, err := tx.Exec(`DELETE FROM survey_questions WHERE survey_id = $1`, id); err != nil {
+		return nil, err
+	}
+	if err := insertSurveyQuestions(tx, id, req.Questions); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+func insertSurveyQuestions(tx *sql.Tx, surveyID int, questions []models.SurveyQuestionRequest) error {
+	query := `
+        INSERT INTO survey_questions (survey_id, urutan, kode, pertanyaan, tipe, options,
+                                      min_value, max_value, wajib, show_if)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+
+	for i, q := range questions {
+		options := q.Options
+		if options == nil {
+			options = []string{}
+		}
+		optionsJSON, err := json.Marshal(options)
+		if err != nil {
+			return err
+		}
+		var showIf interface{}
+		if q.ShowIf != nil {
+			data, err := json.Marshal(q.ShowIf)
+			if err != nil {
+				return err
+			}
+			showIf = string(data)
+		}
+
+		_, err = tx.Exec(
+			query, surveyID, i+1, q.Kode, q.Pertanyaan, q.Tipe, string(optionsJSON),
+			q.MinValue, q.MaxValue, q.Wajib, showIf,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateStatus - ubah status survey (draft/open/closed)
+func (r *surveyRepository) UpdateStatus(id int, status string) error {
+	query := `UPDATE surveys SET status = $1, updated_at = $2 WHERE id = $3`
+	result, err := r.db.Exec(query, status, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *surveyRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM surveys WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetOpenForAlumni - survey yang sedang dibuka untuk angkatan/jurusan alumni
+func (r *surveyRepository) GetOpenForAlumni(angkatan int, jurusan string) ([]models.Survey, error) {
+	query := surveySelect + `
+        WHERE status = 'open'
+          AND (opens_at IS NULL OR opens_at <= NOW())
+          AND (closes_at IS NULL OR closes_at > NOW())
+          AND (cardinality(target_angkatan) = 0 OR $1 = ANY(target_angkatan))
+          AND (cardinality(target_jurusan) = 0
+               OR EXISTS (SELECT 1 FROM unnest(target_jurusan) j WHERE LOWER(j) = LOWER($2)))
+        ORDER BY created_at DESC
+    `
+
+	rows, err := r.db.Query(query, angkatan, jurusan)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var surveys []models.Survey
+	for rows.Next() {
+		survey, err := scanSurvey(rows)
+		if err != nil {
+			return nil, err
+		}
+		surveys = append(surveys, survey)
+	}
+
+	return surveys, nil
+}
+
+// GetResponse - jawaban alumni untuk survey (nil jika belum pernah mengisi)
+func (r *surveyRepository) GetResponse(surveyID, alumniID int) (*models.SurveyResponse, error) {
+	query := `
+        SELECT id, survey_id, alumni_id, status, submitted_at, created_at, updated_at
+        FROM survey_responses
+        WHERE survey_id = $1 AND alumni_id = $2
+    `
+
+	var response models.SurveyResponse
+	err := r.db.QueryRow(query, surveyID, alumniID).Scan(
+		&response.ID, &response.SurveyID, &response.AlumniID, &response.Status,
+		&response.SubmittedAt, &response.CreatedAt, &response.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	answers, err := r.getAnswers(`WHERE sa.response_id = $1`, response.ID)
+	if err != nil {
+		return nil, err
+	}
+	response.Answers = answers[response.ID]
+	if response.Answers == nil {
+		response.Answers = map[string]json.RawMessage{}
+	}
+
+	return &response, nil
+}
+
+// getAnswers - jawaban dikelompokkan per response_id, key berupa kode pertanyaan
+func (r *surveyRepository) getAnswers(where string, arg interface{}) (map[int]map[string]json.RawMessage, error) {
+	query := `
+        SELECT sa.response_id, q.kode, sa.value
+        FROM survey_answers sa
+        JOIN survey_questions q ON q.id = sa.question_id
+        JOIN survey_responses sr ON sr.id = sa.response_id
+        ` + where
+
+	rows, err := r.db.Query(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	answers := map[int]map[string]json.RawMessage{}
+	for rows.Next() {
+		var responseID int
+		var kode string
+		var value []byte
+		if err := rows.Scan(&responseID, &kode, &value); err != nil {
+			return nil, err
+		}
+		if answers[responseID] == nil {
+			answers[responseID] = map[string]json.RawMessage{}
+		}
+		answers[responseID][kode] = value
+	}
+
+	return answers, nil
+}
+
+// SaveResponse - simpan (upsert) jawaban alumni; seluruh jawaban lama diganti dengan answers
+func (r *surveyRepository) SaveResponse(surveyID, alumniID int, answers map[int]json.RawMessage, submit bool) (*models.SurveyResponse, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	status := models.SurveyResponseInProgress
+	var submittedAt *time.Time
+	now := time.Now()
+	if submit {
+		status = models.SurveyResponseSubmitted
+		submittedAt = &now
+	}
+
+	query := `
+        INSERT INTO survey_responses (survey_id, alumni_id, status, submitted_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $5)
+        ON CONFLICT (survey_id, alumni_id)
+        DO UPDATE SET status = EXCLUDED.status, submitted_at = EXCLUDED.submitted_at, updated_at = EXCLUDED.updated_at
+        RETURNING id
+    `
+	var responseID int
+	if err := tx.QueryRow(query, surveyID, alumniID, status, submittedAt, now).Scan(&responseID); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM survey_answers WHERE response_id = $1`, responseID); err != nil {
+		return nil, err
+	}
+	for questionID, value := range answers {
+		_, err := tx.Exec(
+			`INSERT INTO survey_answers (response_id, question_id, value) VALUES ($1, $2, $3)`,
+			responseID, questionID, string(value),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return r.GetResponse(surveyID, alumniID)
+}
+
+// GetCompletion - rekap target vs jawaban, per jurusan dan angkatan
+func (r *surveyRepository) GetCompletion(surveyID int) (*models.SurveyCompletion, error) {
+	query := `
+        WITH target AS (
+            SELECT a.id, a.jurusan, a.angkatan
+            FROM alumni a
+            JOIN surveys s ON s.id = $1
+            WHERE a.is_deleted = FALSE
+              AND (cardinality(s.target_angkatan) = 0 OR a.angkatan = ANY(s.target_angkatan))
+              AND (cardinality(s.target_jurusan) = 0
+                   OR EXISTS (SELECT 1 FROM unnest(s.target_jurusan) j WHERE LOWER(j) = LOWER(a.jurusan)))
+        )
+        SELECT t.jurusan, t.angkatan, COUNT(*),
+               COUNT(*) FILTER (WHERE r.status = 'submitted'),
+               COUNT(*) FILTER (WHERE r.status = 'in_progress')
+        FROM target t
+        LEFT JOIN survey_responses r ON r.alumni_id = t.id AND r.survey_id = $1
+        GROUP BY t.jurusan, t.angkatan
+        ORDER BY t.jurusan, t.angkatan
+    `
+
+	rows, err := r.db.Query(query, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	completion := &models.SurveyCompletion{SurveyID: surveyID, Breakdown: []models.SurveyCompletionGroup{}}
+	for rows.Next() {
+		var group models.SurveyCompletionGroup
+		if err := rows.Scan(&group.Jurusan, &group.Angkatan, &group.TargetAlumni, &group.Submitted, &group.InProgress); err != nil {
+			return nil, err
+		}
+		if group.TargetAlumni > 0 {
+			group.CompletionRate = float64(group.Submitted) / float64(group.TargetAlumni)
+		}
+		completion.TargetAlumni += group.TargetAlumni
+		completion.Submitted += group.Submitted
+		completion.InProgress += group.InProgress
+		completion.Breakdown = append(completion.Breakdown, group)
+	}
+
+	completion.NotStarted = completion.TargetAlumni - completion.Submitted - completion.InProgress
+	if completion.TargetAlumni > 0 {
+		completion.CompletionRate = float64(completion.Submitted) / float64(completion.TargetAlumni)
+	}
+	return completion, nil
+}
+
+// GetResponsesForExport - semua jawaban survey beserta data alumni, opsional filter status
+func (r *surveyRepository) GetResponsesForExport(surveyID int, status string) ([]models.SurveyResponse, error) {
+	query := `
+        SELECT r.id, r.survey_id, r.alumni_id, r.status, r.submitted_at, r.created_at, r.updated_at,
+               a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email
+        FROM survey_responses r
+        JOIN alumni a ON a.id = r.alumni_id
+        WHERE r.survey_id = $1 AND ($2 = '' OR r.status = $2)
+        ORDER BY r.id ASC
+    `
+
+	rows, err := r.db.Query(query, surveyID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var responses []models.SurveyResponse
+	for rows.Next() {
+		var response models.SurveyResponse
+		var alumni models.Alumni
+		err := rows.Scan(
+			&response.ID, &response.SurveyID, &response.AlumniID, &response.Status,
+			&response.SubmittedAt, &response.CreatedAt, &response.UpdatedAt,
+			&alumni.NIM, &alumni.Nama, &alumni.Jurusan, &alumni.Angkatan, &alumni.TahunLulus, &alumni.Email,
+		)
+		if err != nil {
+			return nil, err
+		}
+		alumni.ID = response.AlumniID
+		response.Alumni = &alumni
+		responses = append(responses, response)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	answers, err := r.getAnswers(`WHERE sr.survey_id = $1`, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range responses {
+		responses[i].Answers = answers[responses[i].ID]
+		if responses[i].Answers == nil {
+			responses[i].Answers = map[string]json.RawMessage{}
+		}
+	}
+
+	return responses, nil
+}
+
+func targetAngkatan(values []int) []int64 {
+	result := make([]int64, len(values))
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+	return result
+}
+
+func targetJurusan(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
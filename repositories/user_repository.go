@@ -13,6 +13,8 @@ type UserRepository interface {
     GetByID(id int) (*models.User, error)
     Create(user *models.RegisterRequest, passwordHash string) (*models.User, error)
     UpdateLastLogin(userID int) error
+    GetByOIDCIdentity(issuer, subject string) (*models.User, error)
+    LinkOIDCIdentity(userID int, issuer, subject, email string) error
 }
 
 type userRepository struct {
@@ -137,4 +139,43 @@ func (r *userRepository) UpdateLastLogin(userID int) error {
     query := `UPDATE users SET updated_at = $1 WHERE id = $2`
     _, err := r.db.Exec(query, time.Now(), userID)
     return err
-}
\ No newline at end of file
+}
+
+// GetByOIDCIdentity - ambil user yang terhubung dengan subject identity provider
+func (r *userRepository) GetByOIDCIdentity(issuer, subject string) (*models.User, error) {
+    query := `
+        SELECT u.id, u.username, u.email, u.role, u.created_at, u.updated_at
+        FROM users u
+        JOIN user_oidc_identities i ON i.user_id = u.id
+        WHERE i.issuer = $1 AND i.subject = $2
+    `
+
+    var user models.User
+    row := r.db.QueryRow(query, issuer, subject)
+
+    err := row.Scan(
+        &user.ID, &user.Username, &user.Email,
+        &user.Role, &user.CreatedAt, &user.UpdatedAt,
+    )
+
+    if err != nil {
+        if err == sql.ErrNoRows {
+            return nil, nil
+        }
+        return nil, err
+    }
+
+    return &user, nil
+}
+
+// LinkOIDCIdentity - hubungkan user lokal dengan subject identity provider (upsert email terakhir)
+func (r *userRepository) LinkOIDCIdentity(userID int, issuer, subject, email string) error {
+    query := `
+        INSERT INTO user_oidc_identities (user_id, issuer, subject, email, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $5)
+        ON CONFLICT (issuer, subject)
+        DO UPDATE SET email = EXCLUDED.email, updated_at = EXCLUDED.updated_at
+    `
+    _, err := r.db.Exec(query, userID, issuer, subject, email, time.Now())
+    return err
+}
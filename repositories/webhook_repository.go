@@ -0,0 +1,244 @@
+package repositories
+
+import (
+	"alumni-management-system/config"
+	"alumni-management-system/models"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookRepository interface {
+	GetAll() ([]models.WebhookSubscription, error)
+	GetByID(id int) (*models.WebhookSubscription, error)
+	Create(req *models.CreateWebhookRequest, secret string, createdBy *int) (*models.WebhookSubscription, error)
+	Update(id int, req *models.UpdateWebhookRequest) (*models.WebhookSubscription, error) // nil jika tidak ada
+	Delete(id int) error
+
+	// CreateDeliveries - satu delivery pending untuk setiap subscription aktif yang berlangganan event
+	CreateDeliveries(event, eventID string, payload []byte) (int64, error)
+	// ClaimDueDeliveries - ambil delivery pending yang sudah jatuh tempo dan tunda next_attempt_at sebesar
+	// lease, sehingga instance lain tidak mengirim delivery yang sama. Terisi URL dan Secret subscription.
+	ClaimDueDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordAttempt(id int64, attempt models.WebhookAttempt) error
+	GetDeliveries(subscriptionID int, status string, limit, offset int) ([]models.WebhookDelivery, int, error)
+	GetDelivery(subscriptionID int, id int64) (*models.WebhookDelivery, error)
+	// Redeliver - salin delivery (payload dan event_id sama) sebagai delivery pending baru
+	Redeliver(id int64) (*models.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository() WebhookRepository {
+	return &webhookRepository{db: config.DB}
+}
+
+const webhookSubscriptionColumns = `id, url, events, description, is_active, created_by, created_at, updated_at`
+
+const webhookDeliveryColumns = `id, subscription_id, event, event_id, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, last_response, redelivery_of, created_at, updated_at, delivered_at`
+
+func scanWebhookSubscription(row rowScanner) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := row.Scan(
+		&subscription.ID, &subscription.URL, pq.Array(&subscription.Events), &subscription.Description,
+		&subscription.IsActive, &subscription.CreatedBy, &subscription.CreatedAt, &subscription.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func scanWebhookDelivery(row rowScanner, extra ...interface{}) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var payload []byte
+	err := row.Scan(append([]interface{}{
+		&delivery.ID, &delivery.SubscriptionID, &delivery.Event, &delivery.EventID, &payload,
+		&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt,
+		&delivery.LastStatusCode, &delivery.LastError, &delivery.LastResponse, &delivery.RedeliveryOf,
+		&delivery.CreatedAt, &delivery.UpdatedAt, &delivery.DeliveredAt,
+	}, extra...)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	return &delivery, nil
+}
+
+func (r *webhookRepository) GetAll() ([]models.WebhookSubscription, error) {
+	rows, err := r.db.Query(`SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []models.WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func (r *webhookRepository) GetByID(id int) (*models.WebhookSubscription, error) {
+	return scanWebhookSubscription(r.db.QueryRow(`SELECT `+webhookSubscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, id))
+}
+
+func (r *webhookRepository) Create(req *models.CreateWebhookRequest, secret string, createdBy *int) (*models.WebhookSubscription, error) {
+	isActive := req.IsActive == nil || *req.IsActive
+	subscription, err := scanWebhookSubscription(r.db.QueryRow(`
+		INSERT INTO webhook_subscriptions (url, events, secret, description, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+webhookSubscriptionColumns,
+		req.URL, pq.Array(req.Events), secret, req.Description, isActive, createdBy))
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = secret
+	return subscription, nil
+}
+
+func (r *webhookRepository) Update(id int, req *models.UpdateWebhookRequest) (*models.WebhookSubscription, error) {
+	return scanWebhookSubscription(r.db.QueryRow(`
+		UPDATE webhook_subscriptions
+		SET url = $1, events = $2, description = $3, is_active = $4, updated_at = $5
+		WHERE id = $6
+		RETURNING `+webhookSubscriptionColumns,
+		req.URL, pq.Array(req.Events), req.Description, req.IsActive, time.Now(), id))
+}
+
+func (r *webhookRepository) Delete(id int) error {
+	_, err := r.db.Exec(`DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	return err
+}
+
+func (r *webhookRepository) CreateDeliveries(event, eventID string, payload []byte) (int64, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO webhook_deliveries (subscription_id, event, event_id, payload, next_attempt_at)
+		SELECT id, $1::TEXT, $2::TEXT, $3::JSONB, NOW() FROM webhook_subscriptions
+		WHERE is_active AND events @> ARRAY[$1::TEXT]
+	`, event, eventID, string(payload))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ClaimDueDeliveries - SKIP LOCKED agar beberapa instance aplikasi bisa berjalan bersamaan. Delivery milik
+// subscription yang sudah dinonaktifkan ikut diambil dan akan gagal sebagai dibatalkan oleh service.
+func (r *webhookRepository) ClaimDueDeliveries(limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	now := time.Now()
+	rows, err := r.db.Query(`
+		WITH due AS (
+			SELECT id AS due_id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at = $3
+			FROM due WHERE id = due_id
+			RETURNING `+webhookDeliveryColumns+`
+		)
+		SELECT claimed.*, s.url, s.secret, s.is_active
+		FROM claimed
+		JOIN webhook_subscriptions s ON s.id = claimed.subscription_id
+		ORDER BY claimed.id
+	`, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var active bool
+		var url, secret string
+		delivery, err := scanWebhookDelivery(rows, &url, &secret, &active)
+		if err != nil {
+			return nil, err
+		}
+		if active {
+			delivery.URL, delivery.Secret = url, secret
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *webhookRepository) RecordAttempt(id int64, attempt models.WebhookAttempt) error {
+	now := time.Now()
+	var deliveredAt *time.Time
+	if attempt.Status == models.WebhookDeliverySuccess {
+		deliveredAt = &now
+	}
+	_, err := r.db.Exec(`
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = attempts + 1, next_attempt_at = $2, last_status_code = $3,
+		    last_error = $4, last_response = $5, delivered_at = $6, updated_at = $7
+		WHERE id = $8
+	`, attempt.Status, attempt.NextAttemptAt, attempt.StatusCode, attempt.Error, attempt.Response, deliveredAt, now, id)
+	return err
+}
+
+func (r *webhookRepository) GetDeliveries(subscriptionID int, status string, limit, offset int) ([]models.WebhookDelivery, int, error) {
+	rows, err := r.db.Query(`
+		SELECT `+webhookDeliveryColumns+`, COUNT(*) OVER ()
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		LIMIT $3 OFFSET $4
+	`, subscriptionID, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	total := 0
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	// Halaman di luar jangkauan tidak membawa COUNT(*) OVER ()
+	if len(deliveries) == 0 && offset > 0 {
+		if err := r.db.QueryRow(`
+			SELECT COUNT(*) FROM webhook_deliveries WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		`, subscriptionID, status).Scan(&total); err != nil {
+			return nil, 0, err
+		}
+	}
+	return deliveries, total, nil
+}
+
+func (r *webhookRepository) GetDelivery(subscriptionID int, id int64) (*models.WebhookDelivery, error) {
+	return scanWebhookDelivery(r.db.QueryRow(`
+		SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE subscription_id = $1 AND id = $2
+	`, subscriptionID, id))
+}
+
+func (r *webhookRepository) Redeliver(id int64) (*models.WebhookDelivery, error) {
+	return scanWebhookDelivery(r.db.QueryRow(`
+		INSERT INTO webhook_deliveries (subscription_id, event, event_id, payload, next_attempt_at, redelivery_of)
+		SELECT subscription_id, event, event_id, payload, NOW(), id FROM webhook_deliveries WHERE id = $1
+		RETURNING `+webhookDeliveryColumns, id))
+}
@@ -1,25 +1,48 @@
 package routes
 
 import (
+	"alumni-management-system/docs"
+	"alumni-management-system/i18n"
 	"alumni-management-system/middleware"
 	"alumni-management-system/services"
+	"alumni-management-system/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// UploadRoutes - route multipart yang boleh menerima body sebesar batas upload (UPLOAD_MAX_*_MB);
+// route lain dibatasi BodyLimit default Fiber oleh middleware.BodyLimit
+var UploadRoutes = []string{
+	"PUT " + docs.BasePath + "/alumni/:id/photo",
+	"POST " + docs.BasePath + "/pekerjaan/:id/attachments",
+}
+
 func SetupRoutes(app *fiber.App,
 	alumniService services.AlumniService,
 	pekerjaanService services.PekerjaanService,
-	authService services.AuthService) {
+	authService services.AuthService,
+	oidcService services.OIDCService,
+	auditService services.AuditService,
+	surveyService services.SurveyService,
+	statsService services.StatsService,
+	companyService services.CompanyService,
+	suggestionService services.SuggestionService,
+	idempotencyService services.IdempotencyService,
+	attachmentService services.AttachmentService,
+	webhookService services.WebhookService) {
+
+	// Dokumentasi API (public): spec OpenAPI 3 dan Swagger UI
+	app.Get("/openapi.json", docs.Handler)
+	app.Get("/docs", docs.UI)
 
 	// API group
-	api := app.Group("/alumni-management-system")
+	api := app.Group(docs.BasePath)
 
 	// Health check (public)
 	api.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"success":  true,
-			"message":  "Alumni Management System API is running!",
+			"message":  i18n.T(c, "Alumni Management System API is running!"),
 			"version":  "2.0.0",
 			"features": []string{"CRUD", "JWT Auth", "RBAC", "Pagination", "Search", "Sorting"},
 		})
@@ -30,6 +53,10 @@ func SetupRoutes(app *fiber.App,
 	auth.Post("/login", authService.Login)
 	auth.Post("/register", authService.Register)
 
+	// OpenID Connect SSO (public)
+	auth.Get("/oidc/login", oidcService.Login)
+	auth.Get("/oidc/callback", oidcService.Callback)
+
 	// Protected auth routes (require authentication)
 	authProtected := auth.Group("", middleware.AuthRequired())
 	authProtected.Get("/profile", authService.GetProfile) // Langsung panggil service method
@@ -39,9 +66,9 @@ func SetupRoutes(app *fiber.App,
 		token := c.Get("Authorization")
 		claims, err := authService.ValidateToken(token)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"success": false, "message": "Token tidak valid", "error": err.Error()})
+			return utils.Unauthorized("Token tidak valid").WithCode("TOKEN_INVALID").WithError(err)
 		}
-		return c.JSON(fiber.Map{"success": true, "message": "Token valid", "data": claims})
+		return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Token valid"), "data": claims})
 	})
 
 	// Protected routes - require authentication
@@ -52,12 +79,23 @@ func SetupRoutes(app *fiber.App,
 	// Read operations - Admin dan User bisa akses
 	alumni.Get("/", middleware.UserOrAdmin(), alumniService.GetAllAlumni)
 	alumni.Get("/without-jobs", middleware.UserOrAdmin(), alumniService.GetAlumniWithoutPekerjaan)
+	alumni.Get("/duplicates", middleware.AdminOnly(), alumniService.GetDuplicateAlumni)
+	alumni.Get("/:id/history", middleware.UserOrAdmin(), alumniService.GetAlumniHistory)
+	alumni.Get("/:id/career", middleware.UserOrAdmin(), alumniService.GetAlumniCareer)
+	alumni.Get("/:id/photo", middleware.UserOrAdmin(), attachmentService.GetAlumniPhoto)
 	alumni.Get("/:id", middleware.UserOrAdmin(), alumniService.GetAlumniByID)
 
 	// Write operations - Hanya Admin
-	alumni.Post("/", middleware.AdminOnly(), alumniService.CreateAlumni)      // Langsung panggil service method
+	alumni.Post("/", middleware.AdminOnly(), idempotencyService.Handle, alumniService.CreateAlumni) // Idempotency-Key opsional
 	alumni.Put("/:id", middleware.AdminOnly(), alumniService.UpdateAlumni)    // Langsung panggil service method
+	alumni.Patch("/:id", middleware.AdminOnly(), alumniService.PatchAlumni)
 	alumni.Delete("/:id", middleware.AdminOnly(), alumniService.DeleteAlumni) // Langsung panggil service method
+	alumni.Post("/:id/history/:version/revert", middleware.AdminOnly(), alumniService.RevertAlumni)
+	alumni.Post("/:id/merge", middleware.AdminOnly(), alumniService.MergeAlumni)
+
+	// Foto profil - admin untuk semua alumni, user hanya untuk dirinya sendiri (dicek di service)
+	alumni.Put("/:id/photo", middleware.UserOrAdmin(), attachmentService.UploadAlumniPhoto)
+	alumni.Delete("/:id/photo", middleware.UserOrAdmin(), attachmentService.DeleteAlumniPhoto)
 
 	// Pekerjaan routes dengan RBAC
 	pekerjaan := protected.Group("/pekerjaan")
@@ -69,15 +107,83 @@ func SetupRoutes(app *fiber.App,
 	// Special read operation - Hanya Admin
 	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), pekerjaanService.GetPekerjaanByAlumniID) 
 
+	pekerjaan.Get("/trash/:id", middleware.AdminOnly(), pekerjaanService.GetTrashedPekerjaanByID)
 	pekerjaan.Delete("/trash/:id", middleware.AdminOnly(), pekerjaanService.HardDeleteTrashedPekerjaan)  // Hard delete
     pekerjaan.Put("/trash/restore/:id", middleware.AdminOnly(), pekerjaanService.RestoreTrashedPekerjaan)
 	
+	pekerjaan.Get("/:id/history", middleware.UserOrAdmin(), pekerjaanService.GetPekerjaanHistory)
 	pekerjaan.Get("/:id", middleware.UserOrAdmin(), pekerjaanService.GetPekerjaanByID) // Langsung panggil service method
 	
 	// Write operations - Hanya Admin
-	pekerjaan.Post("/", middleware.AdminOnly(), pekerjaanService.CreatePekerjaan)      // Langsung panggil service method
+	pekerjaan.Post("/", middleware.AdminOnly(), idempotencyService.Handle, pekerjaanService.CreatePekerjaan) // Idempotency-Key opsional
 	pekerjaan.Put("/:id", middleware.AdminOnly(), pekerjaanService.UpdatePekerjaan)    // Langsung panggil service method
+	pekerjaan.Patch("/:id", middleware.AdminOnly(), pekerjaanService.PatchPekerjaan)
 	pekerjaan.Delete("/:id", middleware.AdminOnly(), pekerjaanService.DeletePekerjaan)
+	pekerjaan.Post("/:id/history/:version/revert", middleware.AdminOnly(), pekerjaanService.RevertPekerjaan)
+	pekerjaan.Post("/:id/end", middleware.AdminOnly(), pekerjaanService.EndPekerjaan)
 	pekerjaan.Delete("/soft-delete/:id", pekerjaanService.SoftDeletePekerjaan) // Langsung panggil service method
 
+	// Dokumen pekerjaan - admin untuk semua pekerjaan, user hanya untuk pekerjaannya sendiri (dicek di service)
+	pekerjaan.Get("/:id/attachments", middleware.UserOrAdmin(), attachmentService.GetPekerjaanAttachments)
+	pekerjaan.Get("/:id/attachments/:attachment_id", middleware.UserOrAdmin(), attachmentService.DownloadPekerjaanAttachment)
+	pekerjaan.Post("/:id/attachments", middleware.UserOrAdmin(), attachmentService.UploadPekerjaanAttachment)
+	pekerjaan.Delete("/:id/attachments/:attachment_id", middleware.UserOrAdmin(), attachmentService.DeletePekerjaanAttachment)
+
+	// Audit trail - Hanya Admin
+	protected.Get("/audit", middleware.AdminOnly(), auditService.GetAuditLogs)
+
+	// Tracer study survey routes
+	surveys := protected.Group("/surveys")
+	// Alumni - isi survey yang dibuka untuk angkatan/jurusan-nya
+	surveys.Get("/available", middleware.UserOrAdmin(), surveyService.GetAvailableSurveys)
+	surveys.Get("/:id/response", middleware.UserOrAdmin(), surveyService.GetMySurveyResponse)
+	surveys.Put("/:id/response", middleware.UserOrAdmin(), surveyService.SaveMySurveyResponse)
+	surveys.Get("/:id", middleware.UserOrAdmin(), surveyService.GetSurveyByID)
+
+	// Kelola survey - Hanya Admin
+	surveys.Get("/", middleware.AdminOnly(), surveyService.GetAllSurveys)
+	surveys.Post("/", middleware.AdminOnly(), surveyService.CreateSurvey)
+	surveys.Put("/:id", middleware.AdminOnly(), surveyService.UpdateSurvey)
+	surveys.Delete("/:id", middleware.AdminOnly(), surveyService.DeleteSurvey)
+	surveys.Post("/:id/open", middleware.AdminOnly(), surveyService.OpenSurvey)
+	surveys.Post("/:id/close", middleware.AdminOnly(), surveyService.CloseSurvey)
+	surveys.Get("/:id/completion", middleware.AdminOnly(), surveyService.GetSurveyCompletion)
+	surveys.Get("/:id/export", middleware.AdminOnly(), surveyService.ExportSurveyResponses)
+
+	// Statistik keterserapan kerja (JSON atau ?format=csv) - Admin dan User bisa akses
+	stats := protected.Group("/stats", middleware.UserOrAdmin())
+	stats.Get("/employment-rate", statsService.GetEmploymentRate)
+	stats.Get("/time-to-first-job", statsService.GetTimeToFirstJob)
+	stats.Get("/distribution", statsService.GetDistribution)
+	stats.Get("/active", statsService.GetActiveShare)
+	stats.Get("/salary", statsService.GetSalary)
+
+	// Master data perusahaan - Admin dan User bisa lihat
+	companies := protected.Group("/companies")
+	companies.Get("/", middleware.UserOrAdmin(), companyService.GetAllCompanies)
+	companies.Get("/match", middleware.UserOrAdmin(), companyService.MatchCompany)
+	companies.Get("/:id/alumni", middleware.UserOrAdmin(), companyService.GetCompanyAlumni)
+	companies.Get("/:id", middleware.UserOrAdmin(), companyService.GetCompanyByID)
+
+	// Hanya Admin yang bisa mengubah, menggabung duplikat, dan mengatur alias
+	companies.Post("/", middleware.AdminOnly(), companyService.CreateCompany)
+	companies.Put("/:id", middleware.AdminOnly(), companyService.UpdateCompany)
+	companies.Delete("/:id", middleware.AdminOnly(), companyService.DeleteCompany)
+	companies.Post("/:id/aliases", middleware.AdminOnly(), companyService.AddCompanyAlias)
+	companies.Delete("/:id/aliases/:alias_id", middleware.AdminOnly(), companyService.DeleteCompanyAlias)
+	companies.Post("/:id/merge", middleware.AdminOnly(), companyService.MergeCompanies)
+
+	// Autocomplete untuk form (jurusan, perusahaan, posisi, industri, lokasi) - Admin dan User bisa akses
+	protected.Get("/suggestions/:field", middleware.UserOrAdmin(), suggestionService.GetSuggestions)
+
+	// Webhook subscription dan log delivery - Hanya Admin
+	webhooks := protected.Group("/webhooks", middleware.AdminOnly())
+	webhooks.Get("/", webhookService.GetWebhooks)
+	webhooks.Post("/", webhookService.CreateWebhook)
+	webhooks.Get("/:id", webhookService.GetWebhookByID)
+	webhooks.Put("/:id", webhookService.UpdateWebhook)
+	webhooks.Delete("/:id", webhookService.DeleteWebhook)
+	webhooks.Get("/:id/deliveries", webhookService.GetWebhookDeliveries)
+	webhooks.Post("/:id/deliveries/:delivery_id/redeliver", webhookService.RedeliverWebhook)
+
 }
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"alumni-management-system/docs"
+	"alumni-management-system/services"
+	"alumni-management-system/utils"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newTestApp - app dengan semua route terdaftar. Repository nil: handler tidak pernah dipanggil,
+// yang diperiksa hanya tabel route.
+func newTestApp() *fiber.App {
+	app := fiber.New()
+	audit := services.NewAuditService(nil)
+	companies := services.NewCompanyService(nil, audit)
+	webhooks := services.NewWebhookService(nil, audit)
+	SetupRoutes(app,
+		services.NewAlumniService(nil, nil, nil, audit, webhooks),
+		services.NewPekerjaanService(nil, nil, nil, audit, companies, webhooks),
+		services.NewAuthService(nil, audit),
+		services.NewOIDCService(nil, audit, utils.OIDCConfig{}),
+		audit,
+		services.NewSurveyService(nil, nil, audit),
+		services.NewStatsService(nil),
+		companies,
+		services.NewSuggestionService(nil),
+		services.NewIdempotencyService(nil),
+		services.NewAttachmentService(nil, nil, nil, nil, utils.UploadConfig{}, audit),
+		webhooks)
+	return app
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	if err := docs.Verify(newTestApp()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyRejectsUndocumentedRoute(t *testing.T) {
+	app := newTestApp()
+	app.Get(docs.BasePath+"/undocumented/:id", func(c *fiber.Ctx) error { return nil })
+
+	err := docs.Verify(app)
+	if err == nil {
+		t.Fatal("expected error for undocumented route")
+	}
+	if !strings.Contains(err.Error(), "GET /undocumented/:id") {
+		t.Errorf("error does not name the route: %v", err)
+	}
+}
+
+func TestUploadRoutesAreRegistered(t *testing.T) {
+	registered := map[string]bool{}
+	for _, route := range newTestApp().GetRoutes(true) {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for _, route := range UploadRoutes {
+		if !registered[route] {
+			t.Errorf("upload route %s is not registered", route)
+		}
+	}
+}
@@ -1,11 +1,18 @@
 package services
 
 import (
+	"alumni-management-system/i18n"
 	"alumni-management-system/models"
 	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
 
+	"database/sql"
+	"errors"
+	"math"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -15,187 +22,750 @@ type AlumniService interface {
 	GetAlumniByID(c *fiber.Ctx) error             // Signature: func(*fiber.Ctx) error
 	CreateAlumni(c *fiber.Ctx) error              // Signature: func(*fiber.Ctx) error
 	UpdateAlumni(c *fiber.Ctx) error              // Signature: func(*fiber.Ctx) error
+	PatchAlumni(c *fiber.Ctx) error               // PATCH /alumni/:id (JSON Merge Patch)
 	DeleteAlumni(c *fiber.Ctx) error              // Signature: func(*fiber.Ctx) error
 	GetAlumniWithoutPekerjaan(c *fiber.Ctx) error // New: Get alumni without jobs
+	GetAlumniHistory(c *fiber.Ctx) error          // GET /alumni/:id/history
+	RevertAlumni(c *fiber.Ctx) error              // POST /alumni/:id/history/:version/revert
+	GetAlumniCareer(c *fiber.Ctx) error           // GET /alumni/:id/career
+	GetDuplicateAlumni(c *fiber.Ctx) error        // GET /alumni/duplicates
+	MergeAlumni(c *fiber.Ctx) error               // POST /alumni/:id/merge
 }
 
 type alumniService struct {
-	alumniRepo repositories.AlumniRepository
+	alumniRepo     repositories.AlumniRepository
+	pekerjaanRepo  repositories.PekerjaanRepository
+	historyRepo    repositories.HistoryRepository
+	auditService   AuditService
+	webhookService WebhookService
+
+	// Lihat pekerjaanService.allowMultipleActive, dipakai saat merge
+	allowMultipleActive bool
 }
 
-func NewAlumniService(alumniRepo repositories.AlumniRepository) AlumniService {
+func NewAlumniService(alumniRepo repositories.AlumniRepository, pekerjaanRepo repositories.PekerjaanRepository, historyRepo repositories.HistoryRepository, auditService AuditService, webhookService WebhookService) AlumniService {
 	return &alumniService{
-		alumniRepo: alumniRepo,
+		alumniRepo:     alumniRepo,
+		pekerjaanRepo:  pekerjaanRepo,
+		historyRepo:    historyRepo,
+		auditService:   auditService,
+		webhookService: webhookService,
+
+		allowMultipleActive: allowMultipleActivePekerjaan(),
 	}
 }
 
-// GetAllAlumni - handle GET /alumni (dengan pagination, search, sorting)
+// Kolom sortBy yang diizinkan (relevance hanya jika ada search, lihat querySort)
+var (
+	alumniSortWhitelist      = map[string]bool{"id": true, "nim": true, "nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "created_at": true}
+	withoutJobsSortWhitelist = map[string]bool{"id": true, "nim": true, "nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "created_at": true, models.SortYearsSinceGraduation: true}
+)
+
+// GetAllAlumni - handle GET /alumni (dengan pagination, search, filter, sorting)
 func (s *alumniService) GetAllAlumni(c *fiber.Ctx) error {
+	filter, err := parseAlumniFilter(c)
+	if err != nil {
+		return err
+	}
+	return s.listAlumni(c, filter, alumniSortWhitelist, nil)
+}
+
+// listAlumni - pagination (page/limit atau cursor), search, dan sorting bersama untuk GET /alumni dan
+// GET /alumni/without-jobs. decorate (opsional) mengisi field turunan tiap alumni sebelum dikirim.
+func (s *alumniService) listAlumni(c *fiber.Ctx, filter models.AlumniFilter, sortByWhitelist map[string]bool, decorate func(*models.Alumni)) error {
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
-	sortBy := c.Query("sortBy", "id")
-	order := c.Query("order", "asc")
-	search := c.Query("search", "")
+	search := strings.TrimSpace(c.Query("search", ""))
 
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
 	offset := (page - 1) * limit
 
-	// Validasi input sortBy
-	sortByWhitelist := map[string]bool{"id": true, "nim": true, "nama": true, "jurusan": true, "angkatan": true, "tahun_lulus": true, "email": true, "created_at": true}
-	if !sortByWhitelist[sortBy] {
-		sortBy = "id"
+	sortBy, order := querySort(c, sortByWhitelist, search)
+
+	// Pagination cursor (keyset) jika ?cursor= dikirim: tanpa OFFSET dan tanpa COUNT(*)
+	cursor, err := parseCursor(c, sortBy, order, limit)
+	if err != nil {
+		return err
 	}
-	if strings.ToLower(order) != "desc" {
-		order = "asc"
+	if cursor != nil {
+		alumniList, keys, err := s.alumniRepo.GetAllKeyset(search, sortBy, order, filter, *cursor)
+		if err != nil {
+			return utils.Internal("Gagal mengambil data alumni", err)
+		}
+		alumniList, next, prev := keysetResult(alumniList, keys, *cursor, sortBy, order)
+		decorateAlumni(alumniList, decorate)
+		return c.JSON(models.AlumniCursorResponse{
+			Data: alumniList,
+			Meta: models.CursorMetaInfo{
+				Limit:   cursor.Limit,
+				SortBy:  sortBy,
+				Order:   order,
+				Search:  search,
+				Filters: filterMeta(filter),
+				Next:    next,
+				Prev:    prev,
+			},
+		})
 	}
 
 	// Ambil data dari repository
-	alumniList, err := s.alumniRepo.GetAllPaginated(search, sortBy, order, limit, offset)
+	alumniList, err := s.alumniRepo.GetAllPaginated(search, sortBy, order, filter, limit, offset)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch alumni", "error": err.Error()})
+		return utils.Internal("Gagal mengambil data alumni", err)
 	}
 
-	total, err := s.alumniRepo.CountAlumni(search)
+	total, err := s.alumniRepo.CountAlumni(search, filter)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to count alumni", "error": err.Error()})
+		return utils.Internal("Gagal menghitung alumni", err)
 	}
+	decorateAlumni(alumniList, decorate)
 
 	// Buat response pakai model
 	response := models.AlumniResponse{
 		Data: alumniList,
 		Meta: models.MetaInfo{
-			Page:   page,
-			Limit:  limit,
-			Total:  total,
-			Pages:  (total + limit - 1) / limit,
-			SortBy: sortBy,
-			Order:  order,
-			Search: search,
+			Page:    page,
+			Limit:   limit,
+			Total:   total,
+			Pages:   (total + limit - 1) / limit,
+			SortBy:  sortBy,
+			Order:   order,
+			Search:  search,
+			Filters: filterMeta(filter),
 		},
 	}
 	return c.JSON(response)
 }
 
-// GetAlumniByID - handle GET /alumni/:id
+func decorateAlumni(alumniList []models.Alumni, decorate func(*models.Alumni)) {
+	if decorate == nil {
+		return
+	}
+	for i := range alumniList {
+		decorate(&alumniList[i])
+	}
+}
+
+// parseAlumniFilter - filter GET /alumni: jurusan, angkatan[_from|_to], tahun_lulus[_from|_to], has_pekerjaan,
+// currently_employed, bidang_industri (pekerjaan aktif), created_from, created_to
+func parseAlumniFilter(c *fiber.Ctx) (models.AlumniFilter, error) {
+	filter := models.AlumniFilter{
+		Jurusan:        queryList(c, "jurusan"),
+		BidangIndustri: queryList(c, "bidang_industri"),
+	}
+
+	var err error
+	if filter.AngkatanFrom, filter.AngkatanTo, err = queryIntRange(c, "angkatan"); err != nil {
+		return filter, err
+	}
+	if filter.TahunLulusFrom, filter.TahunLulusTo, err = queryIntRange(c, "tahun_lulus"); err != nil {
+		return filter, err
+	}
+	if filter.HasPekerjaan, err = queryOptionalBool(c, "has_pekerjaan"); err != nil {
+		return filter, err
+	}
+	if filter.CurrentlyEmployed, err = queryOptionalBool(c, "currently_employed"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedFrom, filter.CreatedTo, err = queryTimeRange(c, "created"); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// GetAlumniByID - handle GET /alumni/:id (opsional ?as_of=<timestamp> untuk state di masa lalu)
 func (s *alumniService) GetAlumniByID(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID tidak valid", "error": err.Error()})
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	if asOfParam := c.Query("as_of"); asOfParam != "" {
+		asOf, err := parseTimeQuery(asOfParam, true)
+		if err != nil {
+			return utils.BadRequest("Format as_of tidak valid (RFC3339 atau YYYY-MM-DD)").WithError(err)
+		}
+
+		alumni, err := s.historyRepo.GetAlumniAsOf(id, asOf)
+		if err != nil {
+			return utils.Internal("Gagal mengambil riwayat alumni", err)
+		}
+		if alumni == nil {
+			return utils.NotFound("Alumni tidak ditemukan pada waktu tersebut")
+		}
+		return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data alumni berhasil diambil"), "data": alumni, "as_of": asOf})
 	}
 
 	alumni, err := s.alumniRepo.GetByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch alumni", "error": err.Error()})
+		return utils.Internal("Gagal mengambil data alumni", err)
 	}
 	if alumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+	if notModified(c, recordETag(alumni.ID, alumni.UpdatedAt)) {
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Data alumni berhasil diambil", "data": alumni})
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data alumni berhasil diambil"), "data": alumni})
 }
 
 // CreateAlumni - handle POST /alumni
 func (s *alumniService) CreateAlumni(c *fiber.Ctx) error {
 	var req models.CreateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
-	}
-
-	// Validasi input
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "NIM, nama, jurusan, dan email harus diisi"})
-	}
-
-	if req.Angkatan <= 0 || req.TahunLulus <= 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Angkatan dan tahun lulus harus valid"})
-	}
-
-	if req.TahunLulus < req.Angkatan {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Tahun lulus tidak boleh lebih kecil dari angkatan"})
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
 	alumni, err := s.alumniRepo.Create(&req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to create alumni", "error": err.Error()})
+		return utils.Internal("Gagal membuat data alumni", err)
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": "Alumni berhasil ditambahkan", "data": alumni})
+	s.auditService.Record(c, AuditActionCreate, AuditEntityAlumni, alumni.ID, nil, alumni)
+	s.webhookService.Publish(models.WebhookEventAlumniCreated, alumni)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alumni berhasil ditambahkan"), "data": alumni})
 }
 
 // UpdateAlumni - handle PUT /alumni/:id
 func (s *alumniService) UpdateAlumni(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID tidak valid", "error": err.Error()})
+		return utils.BadRequest("ID tidak valid").WithError(err)
 	}
 
 	var req models.UpdateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+	if err := bindRequest(c, &req); err != nil {
+		return err
 	}
 
-	// Validasi input
-	if req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Nama, jurusan, dan email harus diisi"})
+	// Cek apakah alumni exists
+	existingAlumni, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data alumni", err)
 	}
 
-	if req.Angkatan <= 0 || req.TahunLulus <= 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Angkatan dan tahun lulus harus valid"})
+	if existingAlumni == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+	if err := checkIfMatch(c, recordETag(existingAlumni.ID, existingAlumni.UpdatedAt)); err != nil {
+		return err
 	}
 
-	if req.TahunLulus < req.Angkatan {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Tahun lulus tidak boleh lebih kecil dari angkatan"})
+	alumni, err := s.alumniRepo.Update(id, &req, existingAlumni.UpdatedAt)
+	if err != nil {
+		return writeFailed("Gagal memperbarui data alumni", err)
+	}
+	if alumni == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
 	}
 
-	// Cek apakah alumni exists
-	existingAlumni, err := s.alumniRepo.GetByID(id)
+	s.auditService.Record(c, AuditActionUpdate, AuditEntityAlumni, id, existingAlumni, alumni)
+	s.webhookService.Publish(models.WebhookEventAlumniUpdated, alumni)
+	c.Set(fiber.HeaderETag, recordETag(alumni.ID, alumni.UpdatedAt))
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alumni berhasil diupdate"), "data": alumni})
+}
+
+// PatchAlumni - handle PATCH /alumni/:id. Hanya field yang dikirim yang berubah; null mengosongkan
+// no_telepon/alamat. Patch tanpa perubahan nilai tidak menulis ke database maupun audit log.
+func (s *alumniService) PatchAlumni(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check alumni", "error": err.Error()})
+		return utils.BadRequest("ID tidak valid").WithError(err)
 	}
 
+	existingAlumni, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data alumni", err)
+	}
 	if existingAlumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+	etag := recordETag(existingAlumni.ID, existingAlumni.UpdatedAt)
+	if err := checkIfMatch(c, etag); err != nil {
+		return err
+	}
+
+	current := alumniUpdateRequest(existingAlumni)
+	req := current
+	if _, err := mergePatch(c, &req); err != nil {
+		return err
 	}
 
-	alumni, err := s.alumniRepo.Update(id, &req)
+	changes := changedFields(&current, &req)
+	if len(changes) == 0 {
+		c.Set(fiber.HeaderETag, etag)
+		return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alumni berhasil diupdate"), "data": existingAlumni})
+	}
+
+	alumni, err := s.alumniRepo.Patch(id, changes, existingAlumni.UpdatedAt)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to update alumni", "error": err.Error()})
+		return writeFailed("Gagal memperbarui data alumni", err)
+	}
+	if alumni == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Alumni berhasil diupdate", "data": alumni})
+	s.auditService.Record(c, AuditActionUpdate, AuditEntityAlumni, id, existingAlumni, alumni)
+	s.webhookService.Publish(models.WebhookEventAlumniUpdated, alumni)
+	c.Set(fiber.HeaderETag, recordETag(alumni.ID, alumni.UpdatedAt))
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alumni berhasil diupdate"), "data": alumni})
+}
+
+// alumniUpdateRequest - isi UpdateAlumniRequest dari data alumni (dasar PATCH dan revert)
+func alumniUpdateRequest(alumni *models.Alumni) models.UpdateAlumniRequest {
+	return models.UpdateAlumniRequest{
+		Nama:       alumni.Nama,
+		Jurusan:    alumni.Jurusan,
+		Angkatan:   alumni.Angkatan,
+		TahunLulus: alumni.TahunLulus,
+		Email:      alumni.Email,
+		NoTelepon:  alumni.NoTelepon,
+		Alamat:     alumni.Alamat,
+	}
 }
 
 // DeleteAlumni - handle DELETE /alumni/:id
 func (s *alumniService) DeleteAlumni(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID tidak valid", "error": err.Error()})
+		return utils.BadRequest("ID tidak valid").WithError(err)
 	}
 
 	// Cek apakah alumni exists
 	existingAlumni, err := s.alumniRepo.GetByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check alumni", "error": err.Error()})
+		return utils.Internal("Gagal memeriksa data alumni", err)
 	}
 
 	if existingAlumni == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+	if err := checkIfMatch(c, recordETag(existingAlumni.ID, existingAlumni.UpdatedAt)); err != nil {
+		return err
 	}
 
-	err = s.alumniRepo.Delete(id)
+	err = s.alumniRepo.Delete(id, existingAlumni.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to delete alumni", "error": err.Error()})
+		return writeFailed("Gagal menghapus data alumni", err)
 	}
 
-	return c.JSON(fiber.Map{"success": true, "message": "Alumni berhasil dihapus"})
+	s.auditService.Record(c, AuditActionDelete, AuditEntityAlumni, id, existingAlumni, nil)
+	s.webhookService.Publish(models.WebhookEventAlumniDeleted, existingAlumni)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alumni berhasil dihapus")})
 }
 
+// GetAlumniWithoutPekerjaan - handle GET /alumni/without-jobs untuk outreach: kontrak pagination, search, dan
+// sorting sama dengan GET /alumni, hanya alumni yang belum di-soft delete, ditambah years_since_graduation.
+// status=never_employed (default, belum pernah punya pekerjaan) atau no_active_job (tidak ada pekerjaan aktif).
 func (s *alumniService) GetAlumniWithoutPekerjaan(c *fiber.Ctx) error {
-	alumniList, err := s.alumniRepo.GetAlumniWithoutPekerjaan()
+	filter, err := parseAlumniFilter(c)
+	if err != nil {
+		return err
+	}
+
+	// Kondisi pekerjaan ditentukan oleh status; pekerjaan di trash tidak dihitung (lihat buildAlumniFilter)
+	noJob := false
+	filter.ExcludeDeleted = true
+	filter.HasPekerjaan, filter.CurrentlyEmployed, filter.BidangIndustri = nil, nil, nil
+	switch c.Query("status", models.WithoutJobsNeverEmployed) {
+	case models.WithoutJobsNeverEmployed:
+		filter.HasPekerjaan = &noJob
+	case models.WithoutJobsNoActiveJob:
+		filter.CurrentlyEmployed = &noJob
+	default:
+		return utils.BadRequest("%s harus salah satu dari: %s", "status", models.WithoutJobsNeverEmployed+", "+models.WithoutJobsNoActiveJob)
+	}
+
+	currentYear := time.Now().Year()
+	return s.listAlumni(c, filter, withoutJobsSortWhitelist, func(alumni *models.Alumni) {
+		years := currentYear - alumni.TahunLulus
+		alumni.YearsSinceGraduation = &years
+	})
+}
+
+
+// GetAlumniHistory - handle GET /alumni/:id/history (semua versi + field yang berubah)
+func (s *alumniService) GetAlumniHistory(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	versions, err := s.historyRepo.GetAlumniHistory(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil riwayat alumni", err)
+	}
+	if len(versions) == 0 {
+		return utils.NotFound("Riwayat alumni tidak ditemukan")
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Riwayat alumni berhasil diambil"), "data": withVersionChanges(id, versions)})
+}
+
+// RevertAlumni - handle POST /alumni/:id/history/:version/revert (kembalikan data ke versi tertentu)
+func (s *alumniService) RevertAlumni(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil {
+		return utils.BadRequest("Versi tidak valid").WithError(err)
+	}
+
+	existingAlumni, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data alumni", err)
+	}
+	if existingAlumni == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+	if err := checkIfMatch(c, recordETag(existingAlumni.ID, existingAlumni.UpdatedAt)); err != nil {
+		return err
+	}
+
+	snapshot, err := s.historyRepo.GetAlumniVersion(id, version)
+	if err != nil {
+		return utils.Internal("Gagal mengambil versi alumni", err)
+	}
+	if snapshot == nil {
+		return utils.NotFound("Versi alumni tidak ditemukan")
+	}
+
+	req := alumniUpdateRequest(snapshot)
+	alumni, err := s.alumniRepo.Update(id, &req, existingAlumni.UpdatedAt)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch alumni without jobs", "error": err.Error()})
+		return writeFailed("Gagal mengembalikan alumni ke versi sebelumnya", err)
 	}
-	if len(alumniList) == 0 {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Tidak ada alumni yang belum memiliki pekerjaan"})
+	if alumni == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
 	}
-	return c.JSON(fiber.Map{"success": true, "message": "Data alumni tanpa pekerjaan berhasil diambil", "data": alumniList})
+
+	s.auditService.Record(c, AuditActionRevert, AuditEntityAlumni, id, existingAlumni, alumni)
+	s.webhookService.Publish(models.WebhookEventAlumniUpdated, alumni)
+	c.Set(fiber.HeaderETag, recordETag(alumni.ID, alumni.UpdatedAt))
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alumni berhasil dikembalikan ke versi %d", version), "data": alumni})
 }
+
+// Default dan batas parameter GET /alumni/duplicates
+const (
+	defaultDuplicateSimilarity = 0.6
+	maxDuplicateLimit          = 100
+)
+
+// GetDuplicateAlumni - handle GET /alumni/duplicates?min_similarity=&alumni_id=&page=&limit= (kandidat duplikat
+// untuk ditinjau admin sebelum merge). min_similarity berlaku untuk kemiripan nama; pasangan dengan email atau
+// nomor telepon sama selalu ikut.
+func (s *alumniService) GetDuplicateAlumni(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > maxDuplicateLimit {
+		limit = maxDuplicateLimit
+	}
+
+	minSimilarity := defaultDuplicateSimilarity
+	if raw := c.Query("min_similarity"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < repositories.MinDuplicateNameSimilarity || value > 1 {
+			return utils.BadRequest("Parameter min_similarity harus antara %.1f dan 1", repositories.MinDuplicateNameSimilarity)
+		}
+		minSimilarity = value
+	}
+	alumniID := 0
+	if raw := c.Query("alumni_id"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			return utils.BadRequest("Parameter %s harus berupa angka", "alumni_id")
+		}
+		alumniID = value
+	}
+
+	candidates, total, err := s.alumniRepo.FindDuplicates(minSimilarity, alumniID, limit, (page-1)*limit)
+	if err != nil {
+		return utils.Internal("Gagal mencari duplikat alumni", err)
+	}
+
+	return c.JSON(models.DuplicateCandidateResponse{
+		Data: candidates,
+		Meta: models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: "score",
+			Order:  "desc",
+		},
+	})
+}
+
+// MergeAlumni - handle POST /alumni/:id/merge, gabungkan alumni source_id ke alumni :id. Pekerjaan, respons
+// survey, dan akun user source dipindah ke tujuan lalu source dihapus permanen. Field tujuan dipertahankan
+// kecuali yang disebut di keep_from_source; hasil gabungan divalidasi seperti PUT.
+func (s *alumniService) MergeAlumni(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	var req models.MergeAlumniRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+	if req.SourceID == id {
+		return utils.BadRequest("Alumni tujuan tidak boleh sama dengan source_id")
+	}
+
+	target, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data alumni", err)
+	}
+	if target == nil {
+		return utils.NotFound("Alumni tujuan tidak ditemukan")
+	}
+	source, err := s.alumniRepo.GetByID(req.SourceID)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data alumni", err)
+	}
+	if source == nil {
+		return utils.NotFound("Alumni dengan ID %d tidak ditemukan", req.SourceID)
+	}
+	// If-Match harus memuat ETag tujuan dan source (dipisah koma), keduanya ikut berubah oleh merge
+	if err := checkIfMatch(c, recordETag(target.ID, target.UpdatedAt)); err != nil {
+		return err
+	}
+	if err := checkIfMatch(c, recordETag(source.ID, source.UpdatedAt)); err != nil {
+		return err
+	}
+
+	changes, err := mergedAlumniFields(target, source, req.KeepFromSource)
+	if err != nil {
+		return err
+	}
+
+	// Pekerjaan aktif diperiksa di dalam transaksi merge, setelah baris kedua alumni dikunci
+	result, err := s.alumniRepo.Merge(id, source.ID, target.UpdatedAt, source.UpdatedAt, s.allowMultipleActive, changes)
+	if errors.Is(err, repositories.ErrAlumniUserConflict) {
+		return utils.Conflict("Kedua alumni terhubung ke akun user yang berbeda").WithCode("ALUMNI_USER_CONFLICT")
+	}
+	var activeConflict *repositories.ActivePekerjaanConflictError
+	if errors.As(err, &activeConflict) {
+		return activePekerjaanConflict("Kedua alumni memiliki pekerjaan aktif, akhiri salah satu sebelum digabung", activeConflict.ActiveIDs)
+	}
+	if err != nil {
+		return writeFailed("Gagal menggabungkan alumni", err)
+	}
+	if result == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+
+	merged, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data alumni", err)
+	}
+
+	s.auditService.Record(c, AuditActionMerge, AuditEntityAlumni, source.ID, source, fiber.Map{"merged_into": id})
+	s.auditService.Record(c, AuditActionMerge, AuditEntityAlumni, id, target, merged)
+	s.webhookService.Publish(models.WebhookEventAlumniMerged, fiber.Map{"source": source, "target": merged})
+	if merged != nil {
+		c.Set(fiber.HeaderETag, recordETag(merged.ID, merged.UpdatedAt))
+	}
+
+	return c.JSON(fiber.Map{
+		"success":                true,
+		"message":                i18n.T(c, "Alumni berhasil digabung"),
+		"data":                   merged,
+		"merged_id":              source.ID,
+		"pekerjaan_moved":        result.PekerjaanMoved,
+		"survey_responses_moved": result.SurveyResponsesMoved,
+		"user_moved":             result.UserMoved,
+	})
+}
+
+// mergedAlumniFields - kolom tujuan yang berubah setelah merge: field di keep diambil dari source, no_telepon
+// dan alamat tujuan yang kosong diisi dari source. Hasilnya divalidasi dengan aturan UpdateAlumniRequest.
+func mergedAlumniFields(target, source *models.Alumni, keep []string) (map[string]interface{}, error) {
+	current := alumniUpdateRequest(target)
+	merged := current
+	if merged.NoTelepon == nil {
+		merged.NoTelepon = source.NoTelepon
+	}
+	if merged.Alamat == nil {
+		merged.Alamat = source.Alamat
+	}
+
+	from := alumniUpdateRequest(source)
+	to, src := reflect.ValueOf(&merged).Elem(), reflect.ValueOf(from)
+	fields := jsonFields(to.Type())
+	keepNIM := false
+	for _, name := range keep {
+		if index, ok := fields[name]; ok {
+			to.Field(index).Set(src.Field(index))
+		} else if name == "nim" {
+			keepNIM = true
+		}
+	}
+	if err := utils.ValidateStruct(&merged); err != nil {
+		return nil, err
+	}
+
+	changes := changedFields(&current, &merged)
+	if keepNIM && source.NIM != target.NIM {
+		changes["nim"] = source.NIM
+	}
+	return changes, nil
+}
+
+// GetAlumniCareer - handle GET /alumni/:id/career (timeline, gap, overlap, total pengalaman, posisi saat ini)
+func (s *alumniService) GetAlumniCareer(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	alumni, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data alumni", err)
+	}
+	if alumni == nil {
+		return utils.NotFound("Alumni tidak ditemukan")
+	}
+
+	pekerjaanList, err := s.pekerjaanRepo.GetNonDeletedByAlumniID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data pekerjaan alumni", err)
+	}
+
+	timeline := buildCareerTimeline(pekerjaanList, time.Now(), i18n.FromContext(c))
+	timeline.Alumni = alumni
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Timeline karier alumni berhasil diambil"), "data": timeline})
+}
+
+// buildCareerTimeline - susun timeline dari pekerjaan yang sudah urut tanggal mulai. Semua perhitungan
+// memakai tanggal (tanpa jam) dengan tanggal mulai dan selesai sama-sama dihitung; pekerjaan berurutan
+// (selesai 31 Jan, mulai 1 Feb) tidak dihitung sebagai gap. Warnings diterjemahkan ke bahasa lang.
+func buildCareerTimeline(pekerjaanList []models.PekerjaanAlumni, now time.Time, lang string) *models.CareerTimeline {
+	timeline := &models.CareerTimeline{
+		Entries:          []models.CareerEntry{},
+		Gaps:             []models.CareerGap{},
+		Overlaps:         []models.CareerOverlap{},
+		CurrentPositions: []models.PekerjaanAlumni{},
+		Warnings:         []string{},
+	}
+	today := careerDay(now)
+
+	for _, pekerjaan := range pekerjaanList {
+		entry := models.CareerEntry{Pekerjaan: pekerjaan, Start: careerDay(pekerjaan.TanggalMulaiKerja)}
+		aktif := pekerjaan.StatusPekerjaan == "aktif"
+
+		switch {
+		case pekerjaan.TanggalSelesaiKerja != nil:
+			entry.End = careerDay(*pekerjaan.TanggalSelesaiKerja)
+			if aktif && entry.End.Before(today) {
+				timeline.Warnings = append(timeline.Warnings, i18n.Translate(lang, "Pekerjaan #%d berstatus aktif tetapi tanggal selesai sudah lewat", pekerjaan.ID))
+			}
+			if entry.End.After(today) {
+				entry.End = today
+			}
+		case aktif:
+			entry.End = today
+		default:
+			entry.End = entry.Start
+			timeline.Warnings = append(timeline.Warnings, i18n.Translate(lang, "Pekerjaan #%d berstatus %s tanpa tanggal selesai", pekerjaan.ID, pekerjaan.StatusPekerjaan))
+		}
+		if entry.Start.After(today) {
+			entry.End = entry.Start
+		}
+		entry.Ongoing = aktif && !entry.Start.After(today) && !entry.End.Before(today)
+		entry.DurationDays = careerDays(entry.Start, entry.End) + 1
+
+		timeline.Entries = append(timeline.Entries, entry)
+		if entry.Ongoing {
+			timeline.CurrentPositions = append(timeline.CurrentPositions, pekerjaan)
+		}
+	}
+
+	if n := len(timeline.CurrentPositions); n > 0 {
+		// Entries urut tanggal mulai, jadi posisi saat ini adalah yang terakhir dimulai
+		current := timeline.CurrentPositions[n-1]
+		timeline.CurrentPosition = &current
+	}
+
+	entries := timeline.Entries
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			if entries[j].Start.After(entries[i].End) {
+				continue
+			}
+			end := entries[i].End
+			if entries[j].End.Before(end) {
+				end = entries[j].End
+			}
+			timeline.Overlaps = append(timeline.Overlaps, models.CareerOverlap{
+				FirstPekerjaanID:  entries[i].Pekerjaan.ID,
+				SecondPekerjaanID: entries[j].Pekerjaan.ID,
+				Start:             entries[j].Start,
+				End:               end,
+				Days:              careerDays(entries[j].Start, end) + 1,
+			})
+		}
+	}
+
+	if len(entries) > 0 {
+		coveredStart, coveredUntil, lastID := entries[0].Start, entries[0].End, entries[0].Pekerjaan.ID
+		totalDays := 0
+		for _, entry := range entries[1:] {
+			if entry.Start.After(coveredUntil.AddDate(0, 0, 1)) {
+				// Gap adalah hari tanpa pekerjaan: sehari setelah selesai sampai sehari sebelum mulai
+				timeline.Gaps = append(timeline.Gaps, models.CareerGap{
+					Start:             coveredUntil.AddDate(0, 0, 1),
+					End:               entry.Start.AddDate(0, 0, -1),
+					Days:              careerDays(coveredUntil, entry.Start) - 1,
+					AfterPekerjaanID:  lastID,
+					BeforePekerjaanID: entry.Pekerjaan.ID,
+				})
+				totalDays += careerDays(coveredStart, coveredUntil) + 1
+				coveredStart = entry.Start
+			}
+			if entry.End.After(coveredUntil) {
+				coveredUntil, lastID = entry.End, entry.Pekerjaan.ID
+			}
+		}
+		totalDays += careerDays(coveredStart, coveredUntil) + 1
+
+		timeline.TotalExperienceDays = totalDays
+		timeline.TotalExperienceYears = math.Round(float64(totalDays)/365.25*100) / 100
+	}
+
+	return timeline
+}
+
+func careerDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func careerDays(start, end time.Time) int {
+	return int(end.Sub(start).Hours() / 24)
+}
+
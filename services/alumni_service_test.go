@@ -0,0 +1,107 @@
+package services
+
+import (
+	"alumni-management-system/models"
+	"testing"
+	"time"
+)
+
+func careerDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func careerPekerjaan(id int, status string, mulai time.Time, selesai *time.Time) models.PekerjaanAlumni {
+	return models.PekerjaanAlumni{ID: id, StatusPekerjaan: status, TanggalMulaiKerja: mulai, TanggalSelesaiKerja: selesai}
+}
+
+func datePtr(t time.Time) *time.Time { return &t }
+
+func TestBuildCareerTimelineGaps(t *testing.T) {
+	now := careerDate(2024, time.June, 1)
+
+	t.Run("consecutive jobs", func(t *testing.T) {
+		timeline := buildCareerTimeline([]models.PekerjaanAlumni{
+			careerPekerjaan(1, models.StatusPekerjaanSelesai, careerDate(2023, time.January, 1), datePtr(careerDate(2023, time.January, 31))),
+			careerPekerjaan(2, models.StatusPekerjaanSelesai, careerDate(2023, time.February, 1), datePtr(careerDate(2023, time.February, 28))),
+		}, now, "id")
+		if len(timeline.Gaps) != 0 || len(timeline.Overlaps) != 0 {
+			t.Errorf("gaps %+v, overlaps %+v", timeline.Gaps, timeline.Overlaps)
+		}
+		if d := timeline.Entries[0].DurationDays; d != 31 {
+			t.Errorf("duration = %d, want 31", d)
+		}
+		if timeline.TotalExperienceDays != 59 {
+			t.Errorf("total = %d, want 59", timeline.TotalExperienceDays)
+		}
+	})
+
+	t.Run("gap", func(t *testing.T) {
+		timeline := buildCareerTimeline([]models.PekerjaanAlumni{
+			careerPekerjaan(1, models.StatusPekerjaanSelesai, careerDate(2023, time.January, 1), datePtr(careerDate(2023, time.January, 31))),
+			careerPekerjaan(2, models.StatusPekerjaanSelesai, careerDate(2023, time.February, 3), datePtr(careerDate(2023, time.February, 28))),
+		}, now, "id")
+		if len(timeline.Gaps) != 1 {
+			t.Fatalf("gaps = %+v", timeline.Gaps)
+		}
+		gap := timeline.Gaps[0]
+		if !gap.Start.Equal(careerDate(2023, time.February, 1)) || !gap.End.Equal(careerDate(2023, time.February, 2)) || gap.Days != 2 {
+			t.Errorf("gap = %s..%s (%d days), want 2023-02-01..2023-02-02 (2 days)", gap.Start, gap.End, gap.Days)
+		}
+		if gap.AfterPekerjaanID != 1 || gap.BeforePekerjaanID != 2 {
+			t.Errorf("gap between %d and %d", gap.AfterPekerjaanID, gap.BeforePekerjaanID)
+		}
+		if timeline.TotalExperienceDays != 31+26 {
+			t.Errorf("total = %d, want %d", timeline.TotalExperienceDays, 31+26)
+		}
+	})
+
+	t.Run("overlap on shared day", func(t *testing.T) {
+		timeline := buildCareerTimeline([]models.PekerjaanAlumni{
+			careerPekerjaan(1, models.StatusPekerjaanSelesai, careerDate(2023, time.January, 1), datePtr(careerDate(2023, time.January, 31))),
+			careerPekerjaan(2, models.StatusPekerjaanSelesai, careerDate(2023, time.January, 31), datePtr(careerDate(2023, time.February, 10))),
+		}, now, "id")
+		if len(timeline.Overlaps) != 1 || timeline.Overlaps[0].Days != 1 {
+			t.Errorf("overlaps = %+v, want one 1-day overlap", timeline.Overlaps)
+		}
+		if timeline.TotalExperienceDays != 41 {
+			t.Errorf("total = %d, want 41", timeline.TotalExperienceDays)
+		}
+	})
+
+	t.Run("ongoing job", func(t *testing.T) {
+		timeline := buildCareerTimeline([]models.PekerjaanAlumni{
+			careerPekerjaan(1, models.StatusPekerjaanAktif, careerDate(2024, time.May, 23), nil),
+		}, now.Add(15*time.Hour), "id")
+		entry := timeline.Entries[0]
+		if !entry.Ongoing || !entry.End.Equal(now) || entry.DurationDays != 10 {
+			t.Errorf("entry = %+v", entry)
+		}
+		if timeline.CurrentPosition == nil || timeline.CurrentPosition.ID != 1 {
+			t.Errorf("current position = %+v", timeline.CurrentPosition)
+		}
+	})
+}
+
+func TestBuildCareerTimelineTranslatesWarnings(t *testing.T) {
+	now := careerDate(2024, time.June, 1)
+	pekerjaanList := []models.PekerjaanAlumni{
+		careerPekerjaan(1, models.StatusPekerjaanAktif, careerDate(2023, time.January, 1), datePtr(careerDate(2023, time.March, 1))),
+		careerPekerjaan(2, models.StatusPekerjaanResigned, careerDate(2023, time.April, 1), nil),
+	}
+
+	tests := map[string][]string{
+		"id": {"Pekerjaan #1 berstatus aktif tetapi tanggal selesai sudah lewat", "Pekerjaan #2 berstatus resigned tanpa tanggal selesai"},
+		"en": {"Job #1 is marked active but its end date has passed", "Job #2 has status resigned but no end date"},
+	}
+	for lang, want := range tests {
+		warnings := buildCareerTimeline(pekerjaanList, now, lang).Warnings
+		if len(warnings) != len(want) {
+			t.Fatalf("%s: warnings = %q", lang, warnings)
+		}
+		for i := range want {
+			if warnings[i] != want[i] {
+				t.Errorf("%s: warning %d = %q, want %q", lang, i, warnings[i], want[i])
+			}
+		}
+	}
+}
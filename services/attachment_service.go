@@ -0,0 +1,466 @@
+package services
+
+import (
+	"alumni-management-system/docs"
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const AuditEntityAttachment = "attachment"
+
+const (
+	thumbnailSize           = 256 // piksel, sisi terpanjang
+	attachmentPurgeInterval = time.Hour
+	attachmentPurgeBatch    = 100
+	maxAttachmentNameLength = 255
+)
+
+// Tipe file yang diterima, dideteksi dari isi file (bukan dari Content-Type atau ekstensi kiriman client)
+var (
+	photoContentTypes    = []string{"image/jpeg", "image/png"}
+	documentContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+	attachmentExtensions = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "application/pdf": ".pdf"}
+)
+
+type AttachmentService interface {
+	UploadAlumniPhoto(c *fiber.Ctx) error           // PUT /alumni/:id/photo
+	GetAlumniPhoto(c *fiber.Ctx) error              // GET /alumni/:id/photo
+	DeleteAlumniPhoto(c *fiber.Ctx) error           // DELETE /alumni/:id/photo
+	UploadPekerjaanAttachment(c *fiber.Ctx) error   // POST /pekerjaan/:id/attachments
+	GetPekerjaanAttachments(c *fiber.Ctx) error     // GET /pekerjaan/:id/attachments
+	DownloadPekerjaanAttachment(c *fiber.Ctx) error // GET /pekerjaan/:id/attachments/:attachment_id
+	DeletePekerjaanAttachment(c *fiber.Ctx) error   // DELETE /pekerjaan/:id/attachments/:attachment_id
+}
+
+type attachmentService struct {
+	attachmentRepo repositories.AttachmentRepository
+	alumniRepo     repositories.AlumniRepository
+	pekerjaanRepo  repositories.PekerjaanRepository
+	storage        utils.Storage
+	config         utils.UploadConfig
+	auditService   AuditService
+
+	mu        sync.Mutex
+	nextPurge time.Time
+}
+
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, alumniRepo repositories.AlumniRepository, pekerjaanRepo repositories.PekerjaanRepository, storage utils.Storage, config utils.UploadConfig, auditService AuditService) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		alumniRepo:     alumniRepo,
+		pekerjaanRepo:  pekerjaanRepo,
+		storage:        storage,
+		config:         config,
+		auditService:   auditService,
+	}
+}
+
+// UploadAlumniPhoto - handle PUT /alumni/:id/photo (multipart, field file). Foto lama diganti.
+// Admin boleh untuk semua alumni, user hanya untuk alumni yang terhubung ke akunnya.
+func (s *attachmentService) UploadAlumniPhoto(c *fiber.Ctx) error {
+	alumni, err := s.getAlumni(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOwner(c, alumni.ID, "Akses ditolak. Anda hanya dapat mengubah foto Anda sendiri."); err != nil {
+		return err
+	}
+	s.purgeOrphans()
+
+	attachment, err := s.store(c, fmt.Sprintf("alumni/%d/photo-", alumni.ID), models.AttachmentKindPhoto, s.config.MaxPhotoSize, photoContentTypes)
+	if err != nil {
+		return err
+	}
+	attachment.AlumniID = &alumni.ID
+
+	old, err := s.attachmentRepo.ReplacePhoto(attachment)
+	if err != nil {
+		s.removeFiles(attachment)
+		return utils.Internal("Gagal menyimpan foto alumni", err)
+	}
+	action := AuditActionCreate
+	if old != nil {
+		s.removeFiles(old)
+		action = AuditActionUpdate
+	}
+
+	s.auditService.Record(c, action, AuditEntityAttachment, attachment.ID, old, attachment)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Foto alumni berhasil diupload"), "data": s.withURLs(attachment)})
+}
+
+// GetAlumniPhoto - handle GET /alumni/:id/photo?thumbnail=true (isi file gambar)
+func (s *attachmentService) GetAlumniPhoto(c *fiber.Ctx) error {
+	alumni, err := s.getAlumni(c)
+	if err != nil {
+		return err
+	}
+	photo, err := s.attachmentRepo.GetPhotoByAlumniID(alumni.ID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil foto alumni", err)
+	}
+	if photo == nil {
+		return utils.NotFound("Alumni belum memiliki foto")
+	}
+	return s.send(c, photo)
+}
+
+// DeleteAlumniPhoto - handle DELETE /alumni/:id/photo
+func (s *attachmentService) DeleteAlumniPhoto(c *fiber.Ctx) error {
+	alumni, err := s.getAlumni(c)
+	if err != nil {
+		return err
+	}
+	if err := s.checkOwner(c, alumni.ID, "Akses ditolak. Anda hanya dapat mengubah foto Anda sendiri."); err != nil {
+		return err
+	}
+	photo, err := s.attachmentRepo.GetPhotoByAlumniID(alumni.ID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil foto alumni", err)
+	}
+	if photo == nil {
+		return utils.NotFound("Alumni belum memiliki foto")
+	}
+	return s.delete(c, photo, "Foto alumni berhasil dihapus")
+}
+
+// UploadPekerjaanAttachment - handle POST /pekerjaan/:id/attachments (multipart, field file), mis. surat
+// keterangan kerja. Admin boleh untuk semua pekerjaan, user hanya untuk pekerjaannya sendiri.
+func (s *attachmentService) UploadPekerjaanAttachment(c *fiber.Ctx) error {
+	pekerjaan, err := s.getPekerjaan(c)
+	if err != nil {
+		return err
+	}
+	s.purgeOrphans()
+
+	attachment, err := s.store(c, fmt.Sprintf("pekerjaan/%d/", pekerjaan.ID), models.AttachmentKindDocument, s.config.MaxDocumentSize, documentContentTypes)
+	if err != nil {
+		return err
+	}
+	attachment.PekerjaanID = &pekerjaan.ID
+
+	if err := s.attachmentRepo.Create(attachment); err != nil {
+		s.removeFiles(attachment)
+		return utils.Internal("Gagal menyimpan dokumen pekerjaan", err)
+	}
+
+	s.auditService.Record(c, AuditActionCreate, AuditEntityAttachment, attachment.ID, nil, attachment)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Dokumen pekerjaan berhasil diupload"), "data": s.withURLs(attachment)})
+}
+
+// GetPekerjaanAttachments - handle GET /pekerjaan/:id/attachments (metadata, urut waktu upload)
+func (s *attachmentService) GetPekerjaanAttachments(c *fiber.Ctx) error {
+	pekerjaan, err := s.getPekerjaan(c)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.attachmentRepo.GetByPekerjaanID(pekerjaan.ID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil dokumen pekerjaan", err)
+	}
+	for i := range attachments {
+		s.withURLs(&attachments[i])
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Dokumen pekerjaan berhasil diambil"), "data": attachments})
+}
+
+// DownloadPekerjaanAttachment - handle GET /pekerjaan/:id/attachments/:attachment_id?thumbnail=true
+func (s *attachmentService) DownloadPekerjaanAttachment(c *fiber.Ctx) error {
+	attachment, err := s.getPekerjaanAttachment(c)
+	if err != nil {
+		return err
+	}
+	return s.send(c, attachment)
+}
+
+// DeletePekerjaanAttachment - handle DELETE /pekerjaan/:id/attachments/:attachment_id
+func (s *attachmentService) DeletePekerjaanAttachment(c *fiber.Ctx) error {
+	attachment, err := s.getPekerjaanAttachment(c)
+	if err != nil {
+		return err
+	}
+	return s.delete(c, attachment, "Dokumen pekerjaan berhasil dihapus")
+}
+
+func (s *attachmentService) getAlumni(c *fiber.Ctx) (*models.Alumni, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, utils.BadRequest("ID tidak valid").WithError(err)
+	}
+	alumni, err := s.alumniRepo.GetByID(id)
+	if err != nil {
+		return nil, utils.Internal("Gagal memeriksa data alumni", err)
+	}
+	if alumni == nil {
+		return nil, utils.NotFound("Alumni tidak ditemukan")
+	}
+	return alumni, nil
+}
+
+// getPekerjaan - pekerjaan :id yang belum dihapus; dokumen pekerjaan hanya untuk admin dan pemiliknya
+func (s *attachmentService) getPekerjaan(c *fiber.Ctx) (*models.PekerjaanAlumni, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, utils.BadRequest("ID tidak valid").WithError(err)
+	}
+	pekerjaan, err := s.pekerjaanRepo.GetByID(id)
+	if err != nil {
+		return nil, utils.Internal("Gagal memeriksa data pekerjaan", err)
+	}
+	if pekerjaan == nil {
+		return nil, utils.NotFound("Pekerjaan tidak ditemukan")
+	}
+	if err := s.checkOwner(c, pekerjaan.AlumniID, "Akses ditolak. Anda hanya dapat mengakses dokumen pekerjaan Anda sendiri."); err != nil {
+		return nil, err
+	}
+	return pekerjaan, nil
+}
+
+func (s *attachmentService) getPekerjaanAttachment(c *fiber.Ctx) (*models.Attachment, error) {
+	pekerjaan, err := s.getPekerjaan(c)
+	if err != nil {
+		return nil, err
+	}
+	attachmentID, err := strconv.Atoi(c.Params("attachment_id"))
+	if err != nil {
+		return nil, utils.BadRequest("ID dokumen tidak valid").WithError(err)
+	}
+	attachment, err := s.attachmentRepo.GetByID(attachmentID)
+	if err != nil {
+		return nil, utils.Internal("Gagal mengambil dokumen pekerjaan", err)
+	}
+	if attachment == nil || attachment.PekerjaanID == nil || *attachment.PekerjaanID != pekerjaan.ID {
+		return nil, utils.NotFound("Dokumen pekerjaan tidak ditemukan")
+	}
+	return attachment, nil
+}
+
+// checkOwner - admin boleh mengakses semua alumni, user hanya alumni yang terhubung ke akunnya
+func (s *attachmentService) checkOwner(c *fiber.Ctx, alumniID int, message string) error {
+	if role, _ := c.Locals("role").(string); role == "admin" {
+		return nil
+	}
+	userID, _ := c.Locals("user_id").(int)
+	alumni, err := s.alumniRepo.GetAlumniByUserID(userID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data alumni", err)
+	}
+	if alumni == nil || alumni.ID != alumniID {
+		return utils.Forbidden(message)
+	}
+	return nil
+}
+
+// store - baca field file dari multipart, validasi ukuran dan tipe, lalu simpan file beserta thumbnail
+// (untuk gambar) ke storage dengan key keyPrefix + acak. Attachment belum disimpan ke database.
+func (s *attachmentService) store(c *fiber.Ctx, keyPrefix, kind string, maxSize int64, allowed []string) (*models.Attachment, error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, utils.BadRequest("Field file wajib dikirim sebagai multipart/form-data").WithError(err)
+	}
+	if header.Size > maxSize {
+		return nil, utils.NewValidationError(utils.FieldError{Field: "file", Rule: "max_size",
+			Message: "Ukuran file maksimal %s", Args: []interface{}{formatFileSize(maxSize)}})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, utils.Internal("Gagal membaca file upload", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	file.Close()
+	if err != nil {
+		return nil, utils.Internal("Gagal membaca file upload", err)
+	}
+	if len(data) == 0 {
+		return nil, utils.NewValidationError(utils.FieldError{Field: "file", Rule: "required", Message: "File tidak boleh kosong"})
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !containsString(allowed, contentType) {
+		return nil, utils.NewValidationError(utils.FieldError{Field: "file", Rule: "mime",
+			Message: "Tipe file harus salah satu dari %s", Args: []interface{}{strings.Join(allowed, ", ")}})
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return nil, utils.Internal("Gagal menyimpan file", err)
+	}
+	key := keyPrefix + hex.EncodeToString(random)
+	attachment := &models.Attachment{
+		Kind:        kind,
+		FileName:    attachmentFileName(header.Filename, contentType),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  key + attachmentExtensions[contentType],
+	}
+	if userID, ok := c.Locals("user_id").(int); ok {
+		attachment.UploadedBy = &userID
+	}
+
+	var thumbnail []byte
+	if strings.HasPrefix(contentType, "image/") {
+		thumbnail, err = utils.MakeThumbnail(data, thumbnailSize)
+		if err != nil {
+			return nil, utils.NewValidationError(utils.FieldError{Field: "file", Rule: "image", Message: "File gambar rusak atau terlalu besar"})
+		}
+		thumbnailKey := key + "-thumb.jpg"
+		attachment.ThumbnailKey = &thumbnailKey
+	}
+
+	if err := s.storage.Put(attachment.StorageKey, data, contentType); err != nil {
+		return nil, utils.Internal("Gagal menyimpan file", err)
+	}
+	if thumbnail != nil {
+		if err := s.storage.Put(*attachment.ThumbnailKey, thumbnail, "image/jpeg"); err != nil {
+			s.removeFiles(attachment)
+			return nil, utils.Internal("Gagal menyimpan file", err)
+		}
+	}
+	return attachment, nil
+}
+
+// send - kirim isi file (atau thumbnail jika ?thumbnail=true). Dokumen dikirim sebagai attachment agar
+// browser tidak menampilkan PDF di origin API.
+func (s *attachmentService) send(c *fiber.Ctx, attachment *models.Attachment) error {
+	key, contentType, fileName := attachment.StorageKey, attachment.ContentType, attachment.FileName
+	thumbnail, err := queryOptionalBool(c, "thumbnail")
+	if err != nil {
+		return err
+	}
+	if thumbnail != nil && *thumbnail {
+		if attachment.ThumbnailKey == nil {
+			return utils.NotFound("Thumbnail hanya tersedia untuk file gambar")
+		}
+		key, contentType = *attachment.ThumbnailKey, "image/jpeg"
+		fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName)) + "-thumb.jpg"
+	}
+
+	reader, size, err := s.storage.Open(key)
+	if errors.Is(err, utils.ErrStorageNotFound) {
+		return utils.NotFound("File tidak ditemukan di storage")
+	}
+	if err != nil {
+		return utils.Internal("Gagal membaca file", err)
+	}
+
+	disposition := "inline"
+	if attachment.Kind == models.AttachmentKindDocument {
+		disposition = "attachment"
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": fileName}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	// Isi file untuk satu key tidak pernah berubah (upload ulang membuat key baru)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+	return c.SendStream(reader, int(size))
+}
+
+func (s *attachmentService) delete(c *fiber.Ctx, attachment *models.Attachment, message string) error {
+	if err := s.attachmentRepo.Delete(attachment.ID); err != nil {
+		return utils.Internal("Gagal menghapus file", err)
+	}
+	s.removeFiles(attachment)
+
+	s.auditService.Record(c, AuditActionDelete, AuditEntityAttachment, attachment.ID, attachment, nil)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, message)})
+}
+
+// removeFiles - hapus file dan thumbnail dari storage; kegagalan hanya dicatat di log
+func (s *attachmentService) removeFiles(attachment *models.Attachment) {
+	keys := []string{attachment.StorageKey}
+	if attachment.ThumbnailKey != nil {
+		keys = append(keys, *attachment.ThumbnailKey)
+	}
+	for _, key := range keys {
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("[ATTACHMENT] gagal menghapus %s dari storage: %v", key, err)
+		}
+	}
+}
+
+// purgeOrphans - bersihkan file milik alumni/pekerjaan yang sudah dihapus permanen, paling sering sekali per
+// attachmentPurgeInterval, di background
+func (s *attachmentService) purgeOrphans() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Now().Before(s.nextPurge) {
+		return
+	}
+	s.nextPurge = time.Now().Add(attachmentPurgeInterval)
+	go func() {
+		orphans, err := s.attachmentRepo.GetOrphans(attachmentPurgeBatch)
+		if err != nil {
+			log.Printf("[ATTACHMENT] gagal mengambil file yatim: %v", err)
+			return
+		}
+		for i := range orphans {
+			s.removeFiles(&orphans[i])
+			if err := s.attachmentRepo.Delete(orphans[i].ID); err != nil {
+				log.Printf("[ATTACHMENT] gagal menghapus attachment #%d: %v", orphans[i].ID, err)
+			}
+		}
+	}()
+}
+
+// withURLs - isi URL download file dan thumbnail
+func (s *attachmentService) withURLs(attachment *models.Attachment) *models.Attachment {
+	if attachment.AlumniID != nil {
+		attachment.URL = fmt.Sprintf("%s/alumni/%d/photo", docs.BasePath, *attachment.AlumniID)
+	} else if attachment.PekerjaanID != nil {
+		attachment.URL = fmt.Sprintf("%s/pekerjaan/%d/attachments/%d", docs.BasePath, *attachment.PekerjaanID, attachment.ID)
+	}
+	if attachment.ThumbnailKey != nil {
+		thumbnailURL := attachment.URL + "?thumbnail=true"
+		attachment.ThumbnailURL = &thumbnailURL
+	}
+	return attachment
+}
+
+// attachmentFileName - nama file asli dari client tanpa path dan karakter kontrol, untuk Content-Disposition.
+// Ekstensi disesuaikan dengan tipe file yang terdeteksi.
+func attachmentFileName(name, contentType string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	ext := attachmentExtensions[contentType]
+	name = strings.TrimSpace(name)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if name == "" || name == "." || name == "/" {
+		name = "file"
+	}
+	if len(name)+len(ext) > maxAttachmentNameLength {
+		name = strings.ToValidUTF8(name[:maxAttachmentNameLength-len(ext)], "")
+	}
+	return name + ext
+}
+
+// formatFileSize - ukuran dalam MB/KB untuk pesan error
+func formatFileSize(size int64) string {
+	if size >= 1<<20 {
+		return strconv.FormatFloat(float64(size)/(1<<20), 'f', -1, 64) + " MB"
+	}
+	return strconv.FormatFloat(float64(size)/(1<<10), 'f', -1, 64) + " KB"
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"encoding/json"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Aksi yang dicatat di audit trail
+const (
+	AuditActionCreate     = "create"
+	AuditActionUpdate     = "update"
+	AuditActionDelete     = "delete"
+	AuditActionSoftDelete = "soft_delete"
+	AuditActionRestore    = "restore"
+	AuditActionRevert     = "revert"
+	AuditActionHardDelete = "hard_delete"
+	AuditActionRegister   = "register"
+	AuditActionLogin      = "login"
+	AuditActionLoginSSO   = "login_sso"
+	AuditActionLogout     = "logout"
+	AuditActionMerge      = "merge"
+)
+
+// Entity yang dicatat di audit trail
+const (
+	AuditEntityAlumni    = "alumni"
+	AuditEntityPekerjaan = "pekerjaan"
+	AuditEntityUser      = "user"
+)
+
+type AuditService interface {
+	GetAuditLogs(c *fiber.Ctx) error // GET /audit (admin)
+
+	// Record - catat aksi dengan actor dari c.Locals (hasil middleware AuthRequired)
+	Record(c *fiber.Ctx, action, entity string, entityID int, before, after interface{})
+	// RecordAs - catat aksi dengan actor eksplisit (mis. saat login, sebelum ada token)
+	RecordAs(c *fiber.Ctx, actorUserID int, actorRole, action, entity string, entityID int, before, after interface{})
+}
+
+type auditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+func NewAuditService(auditRepo repositories.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+func (s *auditService) Record(c *fiber.Ctx, action, entity string, entityID int, before, after interface{}) {
+	actorUserID, _ := c.Locals("user_id").(int)
+	actorRole, _ := c.Locals("role").(string)
+	s.RecordAs(c, actorUserID, actorRole, action, entity, entityID, before, after)
+}
+
+func (s *auditService) RecordAs(c *fiber.Ctx, actorUserID int, actorRole, action, entity string, entityID int, before, after interface{}) {
+	entry := models.AuditLog{
+		Action:    action,
+		Entity:    entity,
+		Before:    toAuditJSON(before),
+		After:     toAuditJSON(after),
+		IPAddress: c.IP(),
+	}
+	if actorUserID > 0 {
+		entry.ActorUserID = &actorUserID
+	}
+	if actorRole != "" {
+		entry.ActorRole = &actorRole
+	}
+	if entityID > 0 {
+		entry.EntityID = &entityID
+	}
+	entry.Changes = auditDiff(entry.Before, entry.After)
+
+	// Kegagalan audit tidak membatalkan request yang sudah berhasil, tapi tetap dicatat di log server
+	if err := s.auditRepo.Create(&entry); err != nil {
+		log.Printf("[AUDIT] gagal mencatat %s %s #%d: %v", action, entity, entityID, err)
+	}
+}
+
+// GetAuditLogs - handle GET /audit (filter: actor_user_id, actor_role, action, entity, entity_id, from, to)
+func (s *auditService) GetAuditLogs(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	order := c.Query("order", "desc")
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	if strings.ToLower(order) != "asc" {
+		order = "desc"
+	}
+
+	filter := models.AuditFilter{
+		ActorRole: c.Query("actor_role"),
+		Action:    c.Query("action"),
+		Entity:    c.Query("entity"),
+	}
+	if v := c.Query("actor_user_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return utils.BadRequest("actor_user_id tidak valid").WithError(err)
+		}
+		filter.ActorUserID = &id
+	}
+	if v := c.Query("entity_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return utils.BadRequest("entity_id tidak valid").WithError(err)
+		}
+		filter.EntityID = &id
+	}
+	if v := c.Query("from"); v != "" {
+		from, err := parseTimeQuery(v, false)
+		if err != nil {
+			return utils.BadRequest("Format tanggal from tidak valid (RFC3339 atau YYYY-MM-DD)").WithError(err)
+		}
+		filter.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := parseTimeQuery(v, true)
+		if err != nil {
+			return utils.BadRequest("Format tanggal to tidak valid (RFC3339 atau YYYY-MM-DD)").WithError(err)
+		}
+		filter.To = &to
+	}
+
+	logs, err := s.auditRepo.GetAllPaginated(filter, order, limit, (page-1)*limit)
+	if err != nil {
+		return utils.Internal("Gagal mengambil log audit", err)
+	}
+
+	total, err := s.auditRepo.CountAudit(filter)
+	if err != nil {
+		return utils.Internal("Gagal menghitung log audit", err)
+	}
+
+	response := models.AuditLogResponse{
+		Data: logs,
+		Meta: models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: "created_at",
+			Order:  order,
+		},
+	}
+	return c.JSON(response)
+}
+
+// parseTimeQuery - parse query param waktu (RFC3339 atau YYYY-MM-DD);
+// endOfDay membuat tanggal tanpa jam dianggap sampai akhir hari
+func parseTimeQuery(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return t, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// toAuditJSON - serialize snapshot entity, nil (termasuk typed nil pointer) jadi kosong
+func toAuditJSON(value interface{}) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	return data
+}
+
+// withVersionChanges - isi Changes tiap versi dengan diff terhadap versi sebelumnya
+func withVersionChanges(entityID int, versions []models.RecordVersion) models.RecordHistoryResponse {
+	var previous json.RawMessage
+	for i := range versions {
+		versions[i].Changes = auditDiff(previous, versions[i].Data)
+		previous = versions[i].Data
+	}
+	return models.RecordHistoryResponse{EntityID: entityID, Versions: versions}
+}
+
+// auditDiff - field yang berubah antara before dan after: {"field": {"from": x, "to": y}}
+func auditDiff(before, after json.RawMessage) json.RawMessage {
+	beforeMap := map[string]interface{}{}
+	afterMap := map[string]interface{}{}
+	if len(before) > 0 {
+		if err := json.Unmarshal(before, &beforeMap); err != nil {
+			return nil
+		}
+	}
+	if len(after) > 0 {
+		if err := json.Unmarshal(after, &afterMap); err != nil {
+			return nil
+		}
+	}
+
+	changes := map[string]map[string]interface{}{}
+	for key, from := range beforeMap {
+		if key == "updated_at" {
+			continue
+		}
+		to, ok := afterMap[key]
+		if !ok || !reflect.DeepEqual(from, to) {
+			changes[key] = map[string]interface{}{"from": from, "to": to}
+		}
+	}
+	for key, to := range afterMap {
+		if key == "updated_at" {
+			continue
+		}
+		if _, ok := beforeMap[key]; !ok {
+			changes[key] = map[string]interface{}{"from": nil, "to": to}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil
+	}
+	return data
+}
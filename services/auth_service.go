@@ -1,11 +1,11 @@
 package services
 
 import (
+	"alumni-management-system/i18n"
 	"alumni-management-system/models"
 	"alumni-management-system/repositories"
 	"alumni-management-system/utils"
 	"errors"
-	"fmt"
 	"strings"
 	
 
@@ -21,12 +21,14 @@ type AuthService interface {
 }
 
 type authService struct {
-    userRepo repositories.UserRepository
+    userRepo     repositories.UserRepository
+    auditService AuditService
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, auditService AuditService) AuthService {
     return &authService{
-        userRepo: userRepo,
+        userRepo:     userRepo,
+        auditService: auditService,
     }
 }
 
@@ -34,16 +36,10 @@ func NewAuthService(userRepo repositories.UserRepository) AuthService {
 func (s *authService) Login(c *fiber.Ctx) error {
     var req models.LoginRequest
 
-    if err := c.BodyParser(&req); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Request body tidak valid",
-            "error":   err.Error(),
-        })
+    if err := bindRequest(c, &req); err != nil {
+        return err
     }
 
-    fmt.Printf("[DEBUG] Login attempt - Username: %s\n", req.Username)
-
     // Cari user berdasarkan username atau email
     var user *models.User
     var passwordHash string
@@ -57,54 +53,36 @@ func (s *authService) Login(c *fiber.Ctx) error {
     }
 
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{ // Return 500 for internal server error
-            "success": false,
-            "message": "Error saat mencari user",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal mencari user", err)
     }
 
     if user == nil {
-        return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-            "success": false,
-            "message": "Username atau password salah",
-        })
+        return utils.Unauthorized("Username atau password salah").WithCode("INVALID_CREDENTIALS")
     }
 
-    fmt.Printf("[DEBUG] User found - ID: %d, Username: %s, Role: %s\n", user.ID, user.Username, user.Role)
-    fmt.Printf("[DEBUG] Password hash from DB: %s\n", passwordHash)
-
     // Verify password
     if !utils.CheckPassword(req.Password, passwordHash) {
-        return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-            "success": false,
-            "message": "Username atau password salah",
-        })
+        return utils.Unauthorized("Username atau password salah").WithCode("INVALID_CREDENTIALS")
     }
 
     // Generate JWT token
     token, err := utils.GenerateToken(*user)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal generate token",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal generate token", err)
     }
 
     // Update last login (optional)
     s.userRepo.UpdateLastLogin(user.ID)
+    s.auditService.RecordAs(c, user.ID, user.Role, AuditActionLogin, AuditEntityUser, user.ID, nil, nil)
 
     response := &models.LoginResponse{
         User:  *user,
         Token: token,
     }
 
-    fmt.Printf("[DEBUG] Login successful - User: %s, Role: %s\n", response.User.Username, response.User.Role)
-
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Login berhasil",
+        "message": i18n.T(c, "Login berhasil"),
         "data":    response,
     })
 }
@@ -113,27 +91,8 @@ func (s *authService) Login(c *fiber.Ctx) error {
 func (s *authService) Register(c *fiber.Ctx) error {
     var req models.RegisterRequest
 
-    if err := c.BodyParser(&req); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Request body tidak valid",
-            "error":   err.Error(),
-        })
-    }
-
-    // Validasi input
-    if req.Username == "" || req.Email == "" || req.Password == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Semua field harus diisi",
-        })
-    }
-
-    if !utils.ValidatePasswordStrength(req.Password) {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Password minimal 6 karakter",
-        })
+    if err := bindRequest(c, &req); err != nil {
+        return err
     }
 
     // Set default role jika tidak ada
@@ -141,69 +100,41 @@ func (s *authService) Register(c *fiber.Ctx) error {
         req.Role = "user"
     }
 
-    // Validasi role
-    if req.Role != "admin" && req.Role != "user" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Role harus admin atau user",
-        })
-    }
-
     // Check if username already exists
     existingUser , _, err := s.userRepo.GetByUsername(req.Username)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Error saat check username",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal memeriksa username", err)
     }
     if existingUser  != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Username sudah digunakan",
-        })
+        return utils.Conflict("Username sudah digunakan").WithCode("USERNAME_TAKEN")
     }
 
     // Check if email already exists
     existingUser , _, err = s.userRepo.GetByEmail(req.Email)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Error saat check email",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal memeriksa email", err)
     }
     if existingUser  != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Email sudah digunakan",
-        })
+        return utils.Conflict("Email sudah digunakan").WithCode("EMAIL_TAKEN")
     }
 
     // Hash password
     passwordHash, err := utils.HashPassword(req.Password)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal hash password",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal hash password", err)
     }
 
     // Create user
     user, err := s.userRepo.Create(&req, passwordHash)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal membuat user",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal membuat user", err)
     }
 
+    s.auditService.Record(c, AuditActionRegister, AuditEntityUser, user.ID, nil, user)
+
     return c.Status(fiber.StatusCreated).JSON(fiber.Map{
         "success": true,
-        "message": "Registrasi berhasil",
+        "message": i18n.T(c, "Registrasi berhasil"),
         "data":    user,
     })
 }
@@ -212,26 +143,16 @@ func (s *authService) Register(c *fiber.Ctx) error {
 func (s *authService) GetProfile(c *fiber.Ctx) error {
     userID, ok := c.Locals("user_id").(int)
     if !ok {
-        return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-            "success": false,
-            "message": "User ID tidak ditemukan di context",
-        })
+        return utils.Unauthorized("User ID tidak ditemukan di context")
     }
 
     user, err := s.userRepo.GetByID(userID)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Error saat mengambil profile",
-            "error":   err.Error(),
-        })
+        return utils.Internal("Gagal mengambil profil user", err)
     }
 
     if user == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "User tidak ditemukan",
-        })
+        return utils.NotFound("User tidak ditemukan")
     }
 
     profile := &models.ProfileResponse{
@@ -243,7 +164,7 @@ func (s *authService) GetProfile(c *fiber.Ctx) error {
 
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Profile berhasil diambil",
+        "message": i18n.T(c, "Profile berhasil diambil"),
         "data":    profile,
     })
 }
@@ -252,10 +173,12 @@ func (s *authService) GetProfile(c *fiber.Ctx) error {
 func (s *authService) Logout(c *fiber.Ctx) error {
     // Dalam JWT, logout biasanya dilakukan di client-side dengan menghapus token
     // Server-side logout memerlukan token blacklist yang lebih kompleks
-    
+    userID, _ := c.Locals("user_id").(int)
+    s.auditService.Record(c, AuditActionLogout, AuditEntityUser, userID, nil, nil)
+
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Logout berhasil. Hapus token dari client",
+        "message": i18n.T(c, "Logout berhasil. Hapus token dari client"),
     })
 }
 
@@ -0,0 +1,518 @@
+package services
+
+import (
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"database/sql"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const AuditEntityCompany = "company"
+
+// Skor minimum pencocokan fuzzy sebelum dibuat perusahaan baru (override lewat COMPANY_MATCH_THRESHOLD)
+const defaultCompanyMatchThreshold = 0.6
+
+var (
+	errCompanyNotFound    = utils.NotFound("Perusahaan tidak ditemukan")
+	errCompanyNameInvalid = utils.BadRequest("Nama perusahaan tidak valid")
+)
+
+type CompanyService interface {
+	GetAllCompanies(c *fiber.Ctx) error    // GET /companies
+	GetCompanyByID(c *fiber.Ctx) error     // GET /companies/:id
+	MatchCompany(c *fiber.Ctx) error       // GET /companies/match?name=
+	GetCompanyAlumni(c *fiber.Ctx) error   // GET /companies/:id/alumni
+	CreateCompany(c *fiber.Ctx) error      // POST /companies (admin)
+	UpdateCompany(c *fiber.Ctx) error      // PUT /companies/:id (admin)
+	DeleteCompany(c *fiber.Ctx) error      // DELETE /companies/:id (admin)
+	AddCompanyAlias(c *fiber.Ctx) error    // POST /companies/:id/aliases (admin)
+	DeleteCompanyAlias(c *fiber.Ctx) error // DELETE /companies/:id/aliases/:alias_id (admin)
+	MergeCompanies(c *fiber.Ctx) error     // POST /companies/:id/merge (admin)
+
+	// ResolveCompany - tentukan perusahaan untuk pekerjaan: company_id eksplisit, nama/alias sama persis,
+	// kemiripan fuzzy di atas threshold, atau buat perusahaan baru dari data pekerjaan
+	ResolveCompany(companyID *int, nama, bidangIndustri, lokasi string) (*models.CompanyMatch, error)
+}
+
+type companyService struct {
+	companyRepo    repositories.CompanyRepository
+	auditService   AuditService
+	matchThreshold float64
+}
+
+func NewCompanyService(companyRepo repositories.CompanyRepository, auditService AuditService) CompanyService {
+	threshold := defaultCompanyMatchThreshold
+	if value, err := strconv.ParseFloat(os.Getenv("COMPANY_MATCH_THRESHOLD"), 64); err == nil && value > 0 && value <= 1 {
+		threshold = value
+	}
+	return &companyService{companyRepo: companyRepo, auditService: auditService, matchThreshold: threshold}
+}
+
+// GetAllCompanies - handle GET /companies (dengan pagination, search nama/alias, sorting)
+func (s *companyService) GetAllCompanies(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	sortBy := c.Query("sortBy", "nama")
+	order := c.Query("order", "asc")
+	search := c.Query("search", "")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	sortByWhitelist := map[string]bool{"id": true, "nama": true, "jumlah_alumni": true, "created_at": true}
+	if !sortByWhitelist[sortBy] {
+		sortBy = "nama"
+	}
+	if strings.ToLower(order) != "desc" {
+		order = "asc"
+	}
+
+	companies, err := s.companyRepo.GetAllPaginated(search, sortBy, order, limit, offset)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data perusahaan", err)
+	}
+
+	total, err := s.companyRepo.CountCompanies(search)
+	if err != nil {
+		return utils.Internal("Gagal menghitung perusahaan", err)
+	}
+
+	return c.JSON(models.CompanyResponse{
+		Data: companies,
+		Meta: models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: sortBy,
+			Order:  order,
+			Search: search,
+		},
+	})
+}
+
+// GetCompanyByID - handle GET /companies/:id
+func (s *companyService) GetCompanyByID(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	company, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data perusahaan", err)
+	}
+	if company == nil {
+		return utils.NotFound("Perusahaan tidak ditemukan")
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data perusahaan berhasil diambil"), "data": company})
+}
+
+// MatchCompany - handle GET /companies/match?name= (pratinjau pencocokan sebelum input pekerjaan)
+func (s *companyService) MatchCompany(c *fiber.Ctx) error {
+	name := strings.TrimSpace(c.Query("name"))
+	if utils.NormalizeCompanyName(name) == "" {
+		return utils.BadRequest("Parameter name harus diisi")
+	}
+
+	company, method, err := s.companyRepo.FindByName(name)
+	if err != nil {
+		return utils.Internal("Gagal mencocokkan perusahaan", err)
+	}
+	if company != nil {
+		matches := []models.CompanyMatch{{Company: company, Method: method, Score: 1}}
+		return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Perusahaan ditemukan"), "data": matches})
+	}
+
+	matches, err := s.companyRepo.FindSimilar(name, 5)
+	if err != nil {
+		return utils.Internal("Gagal mencocokkan perusahaan", err)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Kandidat perusahaan berhasil diambil"), "data": matches, "threshold": s.matchThreshold})
+}
+
+// GetCompanyAlumni - handle GET /companies/:id/alumni (opsional ?status=aktif|selesai|resigned)
+func (s *companyService) GetCompanyAlumni(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	status := c.Query("status")
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if status != "" && status != "aktif" && status != "selesai" && status != "resigned" {
+		return utils.BadRequest("Status pekerjaan harus salah satu dari: aktif, selesai, resigned")
+	}
+
+	company, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data perusahaan", err)
+	}
+	if company == nil {
+		return utils.NotFound("Perusahaan tidak ditemukan")
+	}
+
+	pekerjaanList, err := s.companyRepo.GetAlumni(id, status, limit, (page-1)*limit)
+	if err != nil {
+		return utils.Internal("Gagal mengambil alumni perusahaan", err)
+	}
+
+	total, err := s.companyRepo.CountAlumni(id, status)
+	if err != nil {
+		return utils.Internal("Gagal menghitung alumni perusahaan", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "Data alumni di perusahaan berhasil diambil"),
+		"company": company,
+		"data":    pekerjaanList,
+		"meta": models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: "tanggal_mulai_kerja",
+			Order:  "desc",
+		},
+	})
+}
+
+// CreateCompany - handle POST /companies
+func (s *companyService) CreateCompany(c *fiber.Ctx) error {
+	var req models.CreateCompanyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	req.Nama = strings.TrimSpace(req.Nama)
+	if utils.NormalizeCompanyName(req.Nama) == "" {
+		return utils.BadRequest("Nama perusahaan harus diisi")
+	}
+
+	// Nama dan alias tidak boleh bentrok dengan perusahaan lain maupun satu sama lain
+	seen := map[string]bool{utils.NormalizeCompanyName(req.Nama): true}
+	aliases := []string{}
+	for _, alias := range req.Aliases {
+		alias = strings.TrimSpace(alias)
+		normal := utils.NormalizeCompanyName(alias)
+		if normal == "" || seen[normal] {
+			continue
+		}
+		seen[normal] = true
+		aliases = append(aliases, alias)
+	}
+	req.Aliases = aliases
+
+	for _, name := range append([]string{req.Nama}, req.Aliases...) {
+		if err := s.checkNameAvailable(name, 0); err != nil {
+			return err
+		}
+	}
+
+	company, err := s.companyRepo.Create(&req)
+	if err != nil {
+		return utils.Internal("Gagal membuat data perusahaan", err)
+	}
+
+	s.auditService.Record(c, AuditActionCreate, AuditEntityCompany, company.ID, nil, company)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Perusahaan berhasil ditambahkan"), "data": company})
+}
+
+// UpdateCompany - handle PUT /companies/:id
+func (s *companyService) UpdateCompany(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	var req models.UpdateCompanyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	req.Nama = strings.TrimSpace(req.Nama)
+	if utils.NormalizeCompanyName(req.Nama) == "" {
+		return utils.BadRequest("Nama perusahaan harus diisi")
+	}
+
+	existing, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data perusahaan", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Perusahaan tidak ditemukan")
+	}
+
+	if err := s.checkNameAvailable(req.Nama, id); err != nil {
+		return err
+	}
+
+	company, err := s.companyRepo.Update(id, &req)
+	if err != nil {
+		return utils.Internal("Gagal memperbarui data perusahaan", err)
+	}
+
+	s.auditService.Record(c, AuditActionUpdate, AuditEntityCompany, id, existing, company)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Perusahaan berhasil diupdate"), "data": company})
+}
+
+// DeleteCompany - handle DELETE /companies/:id (pekerjaan terkait tetap ada, company_id menjadi NULL)
+func (s *companyService) DeleteCompany(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	existing, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data perusahaan", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Perusahaan tidak ditemukan")
+	}
+
+	if err := s.companyRepo.Delete(id); err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NotFound("Perusahaan tidak ditemukan")
+		}
+		return utils.Internal("Gagal menghapus data perusahaan", err)
+	}
+
+	s.auditService.Record(c, AuditActionDelete, AuditEntityCompany, id, existing, nil)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Perusahaan berhasil dihapus")})
+}
+
+// AddCompanyAlias - handle POST /companies/:id/aliases
+func (s *companyService) AddCompanyAlias(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	var req models.AddCompanyAliasRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+	req.Alias = strings.TrimSpace(req.Alias)
+	if utils.NormalizeCompanyName(req.Alias) == "" {
+		return utils.BadRequest("Alias harus diisi")
+	}
+
+	existing, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data perusahaan", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Perusahaan tidak ditemukan")
+	}
+
+	if err := s.checkNameAvailable(req.Alias, 0); err != nil {
+		return err
+	}
+
+	if _, err := s.companyRepo.AddAlias(id, req.Alias); err != nil {
+		return utils.Internal("Gagal menambahkan alias perusahaan", err)
+	}
+
+	company, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data perusahaan", err)
+	}
+
+	s.auditService.Record(c, AuditActionUpdate, AuditEntityCompany, id, existing, company)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alias perusahaan berhasil ditambahkan"), "data": company})
+}
+
+// DeleteCompanyAlias - handle DELETE /companies/:id/aliases/:alias_id
+func (s *companyService) DeleteCompanyAlias(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+	aliasID, err := strconv.Atoi(c.Params("alias_id"))
+	if err != nil {
+		return utils.BadRequest("ID alias tidak valid").WithError(err)
+	}
+
+	existing, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data perusahaan", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Perusahaan tidak ditemukan")
+	}
+
+	if err := s.companyRepo.DeleteAlias(id, aliasID); err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NotFound("Alias perusahaan tidak ditemukan")
+		}
+		return utils.Internal("Gagal menghapus alias perusahaan", err)
+	}
+
+	company, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data perusahaan", err)
+	}
+
+	s.auditService.Record(c, AuditActionUpdate, AuditEntityCompany, id, existing, company)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Alias perusahaan berhasil dihapus"), "data": company})
+}
+
+// MergeCompanies - handle POST /companies/:id/merge, gabungkan duplikat ke perusahaan :id
+func (s *companyService) MergeCompanies(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	var req models.MergeCompaniesRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	target, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data perusahaan", err)
+	}
+	if target == nil {
+		return utils.NotFound("Perusahaan tujuan tidak ditemukan")
+	}
+
+	var sourceIDs []int
+	var sources []*models.Company
+	seen := map[int]bool{}
+	for _, sourceID := range req.SourceIDs {
+		if sourceID == id {
+			return utils.BadRequest("Perusahaan tujuan tidak boleh ada di source_ids")
+		}
+		if seen[sourceID] {
+			continue
+		}
+		seen[sourceID] = true
+
+		source, err := s.companyRepo.GetByID(sourceID)
+		if err != nil {
+			return utils.Internal("Gagal memeriksa data perusahaan", err)
+		}
+		if source == nil {
+			return utils.NotFound("Perusahaan dengan ID %d tidak ditemukan", sourceID)
+		}
+		sourceIDs = append(sourceIDs, sourceID)
+		sources = append(sources, source)
+	}
+	if len(sourceIDs) == 0 {
+		return utils.BadRequest("source_ids harus berisi minimal satu ID perusahaan")
+	}
+
+	moved, err := s.companyRepo.Merge(id, sourceIDs)
+	if err != nil {
+		return utils.Internal("Gagal menggabungkan perusahaan", err)
+	}
+
+	merged, err := s.companyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data perusahaan", err)
+	}
+
+	for _, source := range sources {
+		s.auditService.Record(c, AuditActionMerge, AuditEntityCompany, source.ID, source, fiber.Map{"merged_into": id})
+	}
+	s.auditService.Record(c, AuditActionMerge, AuditEntityCompany, id, target, merged)
+
+	return c.JSON(fiber.Map{
+		"success":         true,
+		"message":         i18n.T(c, "Perusahaan berhasil digabung"),
+		"data":            merged,
+		"merged_ids":      sourceIDs,
+		"pekerjaan_moved": moved,
+	})
+}
+
+// ResolveCompany - lihat interface
+func (s *companyService) ResolveCompany(companyID *int, nama, bidangIndustri, lokasi string) (*models.CompanyMatch, error) {
+	if companyID != nil {
+		company, err := s.companyRepo.GetByID(*companyID)
+		if err != nil {
+			return nil, utils.Internal("Gagal mengambil data perusahaan", err)
+		}
+		if company == nil {
+			return nil, errCompanyNotFound
+		}
+		return &models.CompanyMatch{Company: company, Method: models.CompanyMatchByID, Score: 1}, nil
+	}
+	if utils.NormalizeCompanyName(nama) == "" {
+		return nil, errCompanyNameInvalid
+	}
+
+	company, method, err := s.companyRepo.FindByName(nama)
+	if err != nil {
+		return nil, utils.Internal("Gagal mencocokkan perusahaan", err)
+	}
+	if company != nil {
+		return &models.CompanyMatch{Company: company, Method: method, Score: 1}, nil
+	}
+
+	candidates, err := s.companyRepo.FindSimilar(nama, 1)
+	if err != nil {
+		return nil, utils.Internal("Gagal mencocokkan perusahaan", err)
+	}
+	if len(candidates) > 0 && candidates[0].Score >= s.matchThreshold {
+		return &candidates[0], nil
+	}
+
+	req := models.CreateCompanyRequest{Nama: strings.TrimSpace(nama)}
+	if bidangIndustri = strings.TrimSpace(bidangIndustri); bidangIndustri != "" {
+		req.BidangIndustri = &bidangIndustri
+	}
+	if lokasi = strings.TrimSpace(lokasi); lokasi != "" {
+		req.Lokasi = &lokasi
+	}
+	company, err = s.companyRepo.Create(&req)
+	if err != nil {
+		// Request paralel dengan nama yang sama - pakai perusahaan yang sudah dibuat
+		if existing, method, findErr := s.companyRepo.FindByName(nama); findErr == nil && existing != nil {
+			return &models.CompanyMatch{Company: existing, Method: method, Score: 1}, nil
+		}
+		return nil, utils.Internal("Gagal membuat perusahaan", err)
+	}
+	return &models.CompanyMatch{Company: company, Method: models.CompanyMatchCreated, Score: 1}, nil
+}
+
+// checkNameAvailable - nama/alias tidak boleh sama (setelah normalisasi) dengan perusahaan lain.
+// Mengembalikan nil jika tersedia, Conflict (COMPANY_NAME_TAKEN) jika sudah dipakai.
+func (s *companyService) checkNameAvailable(name string, ownerID int) error {
+	company, _, err := s.companyRepo.FindByName(name)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa nama perusahaan", err)
+	}
+	if company != nil && company.ID != ownerID {
+		return utils.Conflict("Nama \"%s\" sudah dipakai oleh perusahaan %s", name, company.Nama).WithCode("COMPANY_NAME_TAKEN")
+	}
+	return nil
+}
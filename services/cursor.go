@@ -0,0 +1,93 @@
+package services
+
+import (
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// cursorToken - isi cursor next/prev (JSON lalu base64url, bagi client cukup dianggap string opaque).
+// sortBy dan order ikut disimpan karena posisi keyset hanya bermakna pada urutan yang sama.
+type cursorToken struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Before bool   `json:"b,omitempty"`
+	repositories.Keyset
+}
+
+// parseCursor - pagination cursor aktif jika parameter cursor dikirim (?cursor= untuk halaman pertama).
+// Return nil jika request memakai pagination page/limit biasa.
+func parseCursor(c *fiber.Ctx, sortBy, order string, limit int) (*repositories.KeysetPage, error) {
+	if !c.Context().QueryArgs().Has("cursor") {
+		return nil, nil
+	}
+	// Skor relevansi dihitung per query, bukan kolom, sehingga tidak bisa dijadikan posisi keyset
+	if sortBy == repositories.SortRelevance {
+		return nil, utils.BadRequest("Pagination cursor tidak mendukung sortBy=relevance")
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	page := &repositories.KeysetPage{Limit: limit}
+	raw := c.Query("cursor")
+	if raw == "" {
+		return page, nil
+	}
+
+	var token cursorToken
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil || json.Unmarshal(data, &token) != nil || token.ID < 1 {
+		return nil, utils.BadRequest("Cursor tidak valid")
+	}
+	if token.SortBy != sortBy || token.Order != order {
+		return nil, utils.BadRequest("Cursor dibuat untuk sortBy=%s dan order=%s", token.SortBy, token.Order)
+	}
+	page.After, page.Before = &token.Keyset, token.Before
+	return page, nil
+}
+
+// keysetResult - potong hasil query keyset (sampai Limit+1 baris) menjadi satu halaman dalam urutan
+// tampilan, beserta cursor next/prev (nil jika tidak ada halaman ke arah tersebut)
+func keysetResult[T any](items []T, keys []repositories.Keyset, page repositories.KeysetPage, sortBy, order string) ([]T, *string, *string) {
+	more := len(items) > page.Limit
+	if more {
+		items, keys = items[:page.Limit], keys[:page.Limit]
+	}
+	if page.Before {
+		slices.Reverse(items)
+		slices.Reverse(keys)
+	}
+
+	hasNext, hasPrev := more, page.After != nil
+	if page.Before {
+		hasNext, hasPrev = page.After != nil, more
+	}
+
+	var next, prev *string
+	switch {
+	case len(keys) > 0:
+		if hasNext {
+			next = encodeCursor(sortBy, order, keys[len(keys)-1], false)
+		}
+		if hasPrev {
+			prev = encodeCursor(sortBy, order, keys[0], true)
+		}
+	// Halaman kosong (mis. data di ujung sudah dihapus): tetap bisa kembali lewat posisi acuan
+	case page.After != nil && page.Before:
+		next = encodeCursor(sortBy, order, *page.After, false)
+	case page.After != nil:
+		prev = encodeCursor(sortBy, order, *page.After, true)
+	}
+	return items, next, prev
+}
+
+func encodeCursor(sortBy, order string, key repositories.Keyset, before bool) *string {
+	data, _ := json.Marshal(cursorToken{SortBy: sortBy, Order: order, Before: before, Keyset: key})
+	cursor := base64.RawURLEncoding.EncodeToString(data)
+	return &cursor
+}
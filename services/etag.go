@@ -0,0 +1,64 @@
+package services
+
+import (
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// recordETag - ETag alumni/pekerjaan dari id dan updated_at. Semua UPDATE ke alumni dan pekerjaan_alumni
+// mengisi updated_at, sehingga setiap perubahan menghasilkan ETag baru (presisi mikrodetik PostgreSQL).
+func recordETag(id int, updatedAt time.Time) string {
+	return fmt.Sprintf(`"%d-%x"`, id, updatedAt.UnixMicro())
+}
+
+// notModified - pasang header ETag dan cek If-None-Match; true berarti handler cukup membalas 304
+func notModified(c *fiber.Ctx, etag string) bool {
+	c.Set(fiber.HeaderETag, etag)
+	return etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag, true)
+}
+
+// checkIfMatch - precondition PUT/PATCH/DELETE: If-Match wajib dikirim (428) dan harus sama dengan ETag
+// data saat ini (412). Client yang datanya sudah basi harus GET ulang sebelum menulis.
+func checkIfMatch(c *fiber.Ctx, etag string) error {
+	header := c.Get(fiber.HeaderIfMatch)
+	if strings.TrimSpace(header) == "" {
+		return utils.PreconditionRequired("Header If-Match wajib dikirim, ambil ETag dari GET terlebih dahulu")
+	}
+	if !etagMatches(header, etag, false) {
+		return utils.PreconditionFailed("Data sudah diubah oleh pengguna lain, muat ulang data terlebih dahulu").WithDetail("etag", etag)
+	}
+	return nil
+}
+
+// writeFailed - error dari Update/Patch/Delete repository. ErrStaleRecord berarti data diubah pengguna lain di
+// antara checkIfMatch dan query tulis, dijawab 412 seperti If-Match yang basi; selainnya 500.
+func writeFailed(message string, err error) error {
+	if errors.Is(err, repositories.ErrStaleRecord) {
+		return utils.PreconditionFailed("Data sudah diubah oleh pengguna lain, muat ulang data terlebih dahulu")
+	}
+	return utils.Internal(message, err)
+}
+
+// etagMatches - header berisi "*" atau salah satu ETag dalam daftar. If-None-Match memakai perbandingan
+// lemah (prefix W/ diabaikan), If-Match memakai perbandingan kuat (RFC 9110 bagian 13.1).
+func etagMatches(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	headerIdempotencyKey     = "Idempotency-Key"
+	headerIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	defaultIdempotencyTTL    = 24 * time.Hour // ubah lewat IDEMPOTENCY_TTL_HOURS
+	idempotencyLockTimeout   = time.Minute    // request pertama yang tidak selesai selama ini dianggap gagal
+	idempotencyPurgeInterval = time.Hour
+)
+
+type IdempotencyService interface {
+	Handle(c *fiber.Ctx) error // dipasang sebelum handler POST yang membuat data
+}
+
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	ttl             time.Duration
+
+	mu        sync.Mutex
+	nextPurge time.Time
+}
+
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository) IdempotencyService {
+	ttl := defaultIdempotencyTTL
+	if hours, err := strconv.ParseFloat(os.Getenv("IDEMPOTENCY_TTL_HOURS"), 64); err == nil && hours > 0 {
+		ttl = time.Duration(hours * float64(time.Hour))
+	}
+	return &idempotencyService{idempotencyRepo: idempotencyRepo, ttl: ttl}
+}
+
+// Handle - header Idempotency-Key opsional. Response sukses request pertama disimpan per user selama ttl;
+// request berikutnya dengan key dan body yang sama mendapat response tersebut apa adanya dengan header
+// Idempotent-Replayed: true. Key yang sama dengan body atau endpoint berbeda ditolak 409, begitu juga selama
+// request pertama masih diproses. Error dari handler (validasi, konflik, 5xx) tidak disimpan sehingga client
+// bisa memperbaiki request dan mencoba lagi dengan key yang sama.
+func (s *idempotencyService) Handle(c *fiber.Ctx) error {
+	key := strings.TrimSpace(c.Get(headerIdempotencyKey))
+	if key == "" {
+		return c.Next()
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return utils.BadRequest("Idempotency-Key maksimal %d karakter", maxIdempotencyKeyLength)
+	}
+	s.purgeExpired()
+
+	userID, _ := c.Locals("user_id").(int)
+	fingerprint := requestFingerprint(c)
+	claimed, existing, err := s.idempotencyRepo.Claim(userID, key, fingerprint, s.ttl, idempotencyLockTimeout)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa Idempotency-Key", err)
+	}
+	if !claimed {
+		// Fingerprint kosong: klaim baru saja dilepas request pertama, perlakukan sebagai masih diproses
+		if existing.Fingerprint != "" && existing.Fingerprint != fingerprint {
+			return utils.Conflict("Idempotency-Key sudah dipakai untuk request yang berbeda").WithCode("IDEMPOTENCY_KEY_REUSED")
+		}
+		if existing.StatusCode == nil {
+			return utils.Conflict("Request dengan Idempotency-Key ini masih diproses").WithCode("IDEMPOTENCY_IN_PROGRESS")
+		}
+		c.Set(headerIdempotentReplayed, "true")
+		c.Set(fiber.HeaderContentType, existing.ContentType)
+		return c.Status(*existing.StatusCode).Send(existing.ResponseBody)
+	}
+
+	// Klaim dilepas jika handler gagal (error, 5xx, atau panic)
+	stored := false
+	defer func() {
+		if !stored {
+			if err := s.idempotencyRepo.Release(userID, key); err != nil {
+				log.Printf("[IDEMPOTENCY] gagal melepas key %q user #%d: %v", key, userID, err)
+			}
+		}
+	}()
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+	status := c.Response().StatusCode()
+	if status >= fiber.StatusInternalServerError {
+		return nil
+	}
+
+	stored = true
+	body := append([]byte(nil), c.Response().Body()...)
+	if err := s.idempotencyRepo.Complete(userID, key, status, string(c.Response().Header.ContentType()), body); err != nil {
+		// Data sudah dibuat; klaim dibiarkan agar replay dalam lockTimeout tetap ditolak, bukan membuat duplikat
+		log.Printf("[IDEMPOTENCY] gagal menyimpan response key %q user #%d: %v", key, userID, err)
+	}
+	return nil
+}
+
+// purgeExpired - hapus key kedaluwarsa paling sering sekali per idempotencyPurgeInterval, di background
+func (s *idempotencyService) purgeExpired() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if time.Now().Before(s.nextPurge) {
+		return
+	}
+	s.nextPurge = time.Now().Add(idempotencyPurgeInterval)
+	go func() {
+		if _, err := s.idempotencyRepo.DeleteExpired(); err != nil {
+			log.Printf("[IDEMPOTENCY] gagal menghapus key kedaluwarsa: %v", err)
+		}
+	}()
+}
+
+// requestFingerprint - sha256 dari method, path, dan body. Body JSON dinormalisasi (urutan key, spasi)
+// sehingga request yang sama dari client berbeda tetap dianggap sama.
+func requestFingerprint(c *fiber.Ctx) string {
+	body := c.Body()
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if decoder.Decode(&value) == nil {
+		if normalized, err := json.Marshal(value); err == nil {
+			body = normalized
+		}
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.Method() + " " + c.Path() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
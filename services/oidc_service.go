@@ -0,0 +1,264 @@
+package services
+
+import (
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Batas waktu antara redirect ke identity provider dan callback
+const oidcLoginTTL = 10 * time.Minute
+
+type OIDCService interface {
+	Login(c *fiber.Ctx) error    // GET /auth/oidc/login - redirect ke identity provider
+	Callback(c *fiber.Ctx) error // GET /auth/oidc/callback - tukar code, issue JWT aplikasi
+}
+
+type oidcPendingLogin struct {
+	nonce     string
+	verifier  string
+	expiresAt time.Time
+}
+
+type oidcService struct {
+	userRepo     repositories.UserRepository
+	auditService AuditService
+	config       utils.OIDCConfig
+
+	providerMu sync.Mutex
+	provider   *utils.OIDCProvider
+
+	pendingMu sync.Mutex
+	pending   map[string]oidcPendingLogin
+}
+
+func NewOIDCService(userRepo repositories.UserRepository, auditService AuditService, config utils.OIDCConfig) OIDCService {
+	return &oidcService{
+		userRepo:     userRepo,
+		auditService: auditService,
+		config:       config,
+		pending:      make(map[string]oidcPendingLogin),
+	}
+}
+
+// getProvider - discovery dilakukan saat pertama dipakai agar server tetap jalan walau IdP sedang down
+func (s *oidcService) getProvider() (*utils.OIDCProvider, error) {
+	s.providerMu.Lock()
+	defer s.providerMu.Unlock()
+
+	if s.provider != nil {
+		return s.provider, nil
+	}
+	provider, err := utils.DiscoverOIDCProvider(s.config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	s.provider = provider
+	return provider, nil
+}
+
+func (s *oidcService) savePending(state string, login oidcPendingLogin) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	now := time.Now()
+	for key, value := range s.pending {
+		if now.After(value.expiresAt) {
+			delete(s.pending, key)
+		}
+	}
+	s.pending[state] = login
+}
+
+// takePending - ambil dan hapus state (sekali pakai)
+func (s *oidcService) takePending(state string) (oidcPendingLogin, bool) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	login, ok := s.pending[state]
+	delete(s.pending, state)
+	if !ok || time.Now().After(login.expiresAt) {
+		return oidcPendingLogin{}, false
+	}
+	return login, true
+}
+
+// Login - mulai authorization code flow dengan PKCE
+func (s *oidcService) Login(c *fiber.Ctx) error {
+	if !s.config.Enabled {
+		return utils.NotFound("Login SSO tidak diaktifkan")
+	}
+
+	provider, err := s.getProvider()
+	if err != nil {
+		return utils.BadGateway("Gagal menghubungi identity provider").WithError(err)
+	}
+
+	state, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return utils.Internal("Gagal generate state", err)
+	}
+	nonce, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return utils.Internal("Gagal generate nonce", err)
+	}
+	verifier, err := utils.GenerateRandomString(48)
+	if err != nil {
+		return utils.Internal("Gagal generate code verifier", err)
+	}
+
+	s.savePending(state, oidcPendingLogin{
+		nonce:     nonce,
+		verifier:  verifier,
+		expiresAt: time.Now().Add(oidcLoginTTL),
+	})
+
+	authURL := provider.AuthCodeURL(s.config, state, nonce, verifier)
+
+	// Client mobile/SPA bisa minta URL dalam bentuk JSON
+	if c.Query("mode") == "json" {
+		return c.JSON(fiber.Map{
+			"success": true,
+			"message": i18n.T(c, "URL login SSO berhasil dibuat"),
+			"data":    models.OIDCLoginResponse{AuthorizationURL: authURL, State: state},
+		})
+	}
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback - validasi state, tukar code, verifikasi ID token lalu issue JWT aplikasi
+func (s *oidcService) Callback(c *fiber.Ctx) error {
+	if !s.config.Enabled {
+		return utils.NotFound("Login SSO tidak diaktifkan")
+	}
+
+	if idpError := c.Query("error"); idpError != "" {
+		return utils.Unauthorized("Login SSO dibatalkan atau ditolak").WithCode("SSO_DENIED").WithError(fmt.Errorf("%s %s", idpError, c.Query("error_description")))
+	}
+
+	pending, ok := s.takePending(c.Query("state"))
+	if !ok {
+		return utils.BadRequest("State login SSO tidak valid atau expired")
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		return utils.BadRequest("Authorization code tidak ditemukan")
+	}
+
+	provider, err := s.getProvider()
+	if err != nil {
+		return utils.BadGateway("Gagal menghubungi identity provider").WithError(err)
+	}
+
+	rawIDToken, err := provider.ExchangeCode(s.config, code, pending.verifier)
+	if err != nil {
+		return utils.Unauthorized("Gagal menukar authorization code").WithError(err)
+	}
+
+	claims, err := provider.VerifyIDToken(s.config, rawIDToken, pending.nonce)
+	if err != nil {
+		return utils.Unauthorized("ID token tidak valid").WithError(err)
+	}
+
+	user, err := s.resolveUser(c, provider.Issuer, claims)
+	if err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateToken(*user)
+	if err != nil {
+		return utils.Internal("Gagal generate token", err)
+	}
+
+	s.userRepo.UpdateLastLogin(user.ID)
+	s.auditService.RecordAs(c, user.ID, user.Role, AuditActionLoginSSO, AuditEntityUser, user.ID, nil, nil)
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.T(c, "Login SSO berhasil"),
+		"data": &models.LoginResponse{
+			User:  *user,
+			Token: token,
+		},
+	})
+}
+
+// resolveUser - petakan subject/email IdP ke baris users, auto-provision jika diizinkan
+func (s *oidcService) resolveUser(c *fiber.Ctx, issuer string, claims *models.OIDCIDTokenClaims) (*models.User, error) {
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	// 1. Subject sudah pernah terhubung
+	user, err := s.userRepo.GetByOIDCIdentity(issuer, claims.Subject)
+	if err != nil {
+		return nil, utils.Internal("Gagal mencari user", err)
+	}
+	if user != nil {
+		if err := s.userRepo.LinkOIDCIdentity(user.ID, issuer, claims.Subject, email); err != nil {
+			return nil, utils.Internal("Gagal memperbarui identitas SSO", err)
+		}
+		return user, nil
+	}
+
+	// 2. Hubungkan ke user lokal dengan email yang sama (hanya jika email terverifikasi di IdP)
+	if email == "" || !claims.EmailVerified {
+		return nil, utils.Forbidden("Email akun SSO tidak tersedia atau belum terverifikasi")
+	}
+	user, _, err = s.userRepo.GetByEmail(email)
+	if err != nil {
+		return nil, utils.Internal("Gagal mencari user", err)
+	}
+
+	// 3. Auto-provision user baru
+	if user == nil {
+		if !s.config.AutoProvision {
+			return nil, utils.Forbidden("Akun SSO belum terdaftar di sistem")
+		}
+		username, err := s.availableUsername(claims, email)
+		if err != nil {
+			return nil, utils.Internal("Gagal memeriksa username", err)
+		}
+		// Password hash kosong: user SSO tidak bisa login dengan password lokal
+		user, err = s.userRepo.Create(&models.RegisterRequest{
+			Username: username,
+			Email:    email,
+			Role:     s.config.DefaultRole,
+		}, "")
+		if err != nil {
+			return nil, utils.Internal("Gagal membuat user", err)
+		}
+		s.auditService.RecordAs(c, user.ID, user.Role, AuditActionRegister, AuditEntityUser, user.ID, nil, user)
+	}
+
+	if err := s.userRepo.LinkOIDCIdentity(user.ID, issuer, claims.Subject, email); err != nil {
+		return nil, utils.Internal("Gagal menghubungkan identitas SSO", err)
+	}
+	return user, nil
+}
+
+// availableUsername - turunkan username dari preferred_username/email, tambah suffix jika sudah dipakai
+func (s *oidcService) availableUsername(claims *models.OIDCIDTokenClaims, email string) (string, error) {
+	base := strings.TrimSpace(claims.PreferredUsername)
+	if base == "" || strings.Contains(base, "@") {
+		base = strings.SplitN(email, "@", 2)[0]
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		existing, _, err := s.userRepo.GetByUsername(candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s%d", base, i)
+	}
+}
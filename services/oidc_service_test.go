@@ -0,0 +1,445 @@
+package services
+
+import (
+	"alumni-management-system/models"
+	"alumni-management-system/utils"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// oidcTestIdP - identity provider tiruan. Token endpoint hanya menerima code_verifier yang cocok dengan
+// code_challenge dari URL login, lalu menerbitkan ID token hasil issue untuk nonce dari URL login.
+type oidcTestIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu         sync.Mutex
+	challenges map[string]string // code -> code_challenge
+	idTokens   map[string]string // code -> id_token
+}
+
+func newOIDCTestIdP(t *testing.T) *oidcTestIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &oidcTestIdP{t: t, key: key, challenges: map[string]string{}, idTokens: map[string]string{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kid": "test-key", "kty": "RSA", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		code := r.PostForm.Get("code")
+		idp.mu.Lock()
+		challenge, idToken := idp.challenges[code], idp.idTokens[code]
+		idp.mu.Unlock()
+		if challenge == "" || utils.PKCEChallenge(r.PostForm.Get("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize - simulasikan login user di IdP untuk URL authorization: simpan challenge dan ID token dari
+// claims (diubah lewat edit), return code untuk callback
+func (idp *oidcTestIdP) authorize(authURL string, edit func(*models.OIDCIDTokenClaims)) string {
+	idp.t.Helper()
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	query := parsed.Query()
+	now := time.Now()
+	claims := &models.OIDCIDTokenClaims{
+		Email:             "Budi@Example.com",
+		EmailVerified:     true,
+		PreferredUsername: "budi",
+		Nonce:             query.Get("nonce"),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idp.server.URL,
+			Subject:   "subject-1",
+			Audience:  jwt.ClaimStrings{query.Get("client_id")},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		},
+	}
+	if edit != nil {
+		edit(claims)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test-key"
+	raw, err := token.SignedString(idp.key)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	code := "code-" + query.Get("state")
+	idp.challenges[code] = query.Get("code_challenge")
+	idp.idTokens[code] = raw
+	return code
+}
+
+// fakeUserRepo - UserRepository di memori
+type fakeUserRepo struct {
+	users      []models.User
+	identities map[string]int // issuer + " " + subject -> user id
+	created    int
+}
+
+func (r *fakeUserRepo) find(match func(models.User) bool) *models.User {
+	for i := range r.users {
+		if match(r.users[i]) {
+			user := r.users[i]
+			return &user
+		}
+	}
+	return nil
+}
+
+func (r *fakeUserRepo) GetByUsername(username string) (*models.User, string, error) {
+	return r.find(func(u models.User) bool { return u.Username == username }), "", nil
+}
+
+func (r *fakeUserRepo) GetByEmail(email string) (*models.User, string, error) {
+	return r.find(func(u models.User) bool { return u.Email == email }), "", nil
+}
+
+func (r *fakeUserRepo) GetByID(id int) (*models.User, error) {
+	return r.find(func(u models.User) bool { return u.ID == id }), nil
+}
+
+func (r *fakeUserRepo) Create(req *models.RegisterRequest, passwordHash string) (*models.User, error) {
+	r.created++
+	user := models.User{ID: len(r.users) + 1, Username: req.Username, Email: req.Email, Role: req.Role}
+	r.users = append(r.users, user)
+	return &user, nil
+}
+
+func (r *fakeUserRepo) UpdateLastLogin(userID int) error { return nil }
+
+func (r *fakeUserRepo) GetByOIDCIdentity(issuer, subject string) (*models.User, error) {
+	id, ok := r.identities[issuer+" "+subject]
+	if !ok {
+		return nil, nil
+	}
+	return r.GetByID(id)
+}
+
+func (r *fakeUserRepo) LinkOIDCIdentity(userID int, issuer, subject, email string) error {
+	r.identities[issuer+" "+subject] = userID
+	return nil
+}
+
+// nopAuditService - AuditService yang tidak mencatat apa pun
+type nopAuditService struct{}
+
+func (nopAuditService) GetAuditLogs(c *fiber.Ctx) error { return nil }
+func (nopAuditService) Record(*fiber.Ctx, string, string, int, interface{}, interface{}) {
+}
+func (nopAuditService) RecordAs(*fiber.Ctx, int, string, string, string, int, interface{}, interface{}) {
+}
+
+type oidcTestResponse struct {
+	status int
+	code   string
+	data   models.LoginResponse
+}
+
+// newOIDCTestApp - app dengan route login/callback dan error handler minimal (status + code AppError)
+func newOIDCTestApp(idp *oidcTestIdP, repo *fakeUserRepo, autoProvision bool) *fiber.App {
+	service := NewOIDCService(repo, nopAuditService{}, utils.OIDCConfig{
+		Enabled:       true,
+		Issuer:        idp.server.URL,
+		ClientID:      "alumni-app",
+		RedirectURL:   "http://localhost:3000/callback",
+		Scopes:        []string{"openid", "email", "profile"},
+		AutoProvision: autoProvision,
+		DefaultRole:   "user",
+	})
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) {
+			return c.Status(appErr.Status).JSON(fiber.Map{"code": appErr.Code})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"code": err.Error()})
+	}})
+	app.Get("/login", service.Login)
+	app.Get("/callback", service.Callback)
+	return app
+}
+
+func oidcRequest(t *testing.T, app *fiber.App, target string) oidcTestResponse {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, target, nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Code string               `json:"code"`
+		Data models.LoginResponse `json:"data"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	return oidcTestResponse{status: resp.StatusCode, code: body.Code, data: body.Data}
+}
+
+// startLogin - GET /login?mode=json, return URL authorization dan state
+func startLogin(t *testing.T, app *fiber.App) (string, string) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/login?mode=json", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		Data models.OIDCLoginResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("login: status %d, %v", resp.StatusCode, err)
+	}
+	return body.Data.AuthorizationURL, body.Data.State
+}
+
+func callbackURL(state, code string) string {
+	return "/callback?" + url.Values{"state": {state}, "code": {code}}.Encode()
+}
+
+func TestOIDCCallbackRejectsInvalidLogins(t *testing.T) {
+	idp := newOIDCTestIdP(t)
+
+	tests := []struct {
+		name       string
+		edit       func(*models.OIDCIDTokenClaims)
+		callback   func(state, code string) string
+		wantStatus int
+	}{
+		{"state mismatch", nil, func(state, code string) string {
+			return callbackURL("forged-state", code)
+		}, fiber.StatusBadRequest},
+		{"nonce mismatch", func(claims *models.OIDCIDTokenClaims) {
+			claims.Nonce = "replayed-nonce"
+		}, callbackURL, fiber.StatusUnauthorized},
+		{"expired id_token", func(claims *models.OIDCIDTokenClaims) {
+			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		}, callbackURL, fiber.StatusUnauthorized},
+		{"wrong audience", func(claims *models.OIDCIDTokenClaims) {
+			claims.Audience = jwt.ClaimStrings{"another-client"}
+		}, callbackURL, fiber.StatusUnauthorized},
+		{"unverified email", func(claims *models.OIDCIDTokenClaims) {
+			claims.EmailVerified = false
+		}, callbackURL, fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeUserRepo{identities: map[string]int{}}
+			app := newOIDCTestApp(idp, repo, true)
+			authURL, state := startLogin(t, app)
+			code := idp.authorize(authURL, tt.edit)
+
+			if resp := oidcRequest(t, app, tt.callback(state, code)); resp.status != tt.wantStatus {
+				t.Errorf("status = %d (%s), want %d", resp.status, resp.code, tt.wantStatus)
+			}
+			if repo.created != 0 || len(repo.identities) != 0 {
+				t.Error("rejected login must not create or link users")
+			}
+		})
+	}
+}
+
+func TestOIDCCallbackStateIsSingleUse(t *testing.T) {
+	idp := newOIDCTestIdP(t)
+	app := newOIDCTestApp(idp, &fakeUserRepo{identities: map[string]int{}}, true)
+	authURL, state := startLogin(t, app)
+	code := idp.authorize(authURL, nil)
+
+	if resp := oidcRequest(t, app, callbackURL(state, code)); resp.status != fiber.StatusOK {
+		t.Fatalf("first callback: status %d (%s)", resp.status, resp.code)
+	}
+	if resp := oidcRequest(t, app, callbackURL(state, code)); resp.status != fiber.StatusBadRequest {
+		t.Errorf("replayed callback: status %d, want 400", resp.status)
+	}
+}
+
+func TestOIDCCallbackRequiresPKCEVerifier(t *testing.T) {
+	idp := newOIDCTestIdP(t)
+	app := newOIDCTestApp(idp, &fakeUserRepo{identities: map[string]int{}}, true)
+
+	// Code diterbitkan untuk login lain (challenge berbeda): verifier milik state ini tidak cocok
+	otherURL, _ := startLogin(t, app)
+	code := idp.authorize(otherURL, nil)
+	_, state := startLogin(t, app)
+
+	resp := oidcRequest(t, app, callbackURL(state, code))
+	if resp.status != fiber.StatusUnauthorized {
+		t.Errorf("status = %d (%s), want 401", resp.status, resp.code)
+	}
+
+	authURL, err := url.Parse(otherURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if method := authURL.Query().Get("code_challenge_method"); method != "S256" {
+		t.Errorf("code_challenge_method = %q, want S256", method)
+	}
+}
+
+func TestOIDCCallbackAutoProvision(t *testing.T) {
+	idp := newOIDCTestIdP(t)
+
+	t.Run("creates user", func(t *testing.T) {
+		repo := &fakeUserRepo{identities: map[string]int{}}
+		app := newOIDCTestApp(idp, repo, true)
+		authURL, state := startLogin(t, app)
+
+		resp := oidcRequest(t, app, callbackURL(state, idp.authorize(authURL, nil)))
+		if resp.status != fiber.StatusOK {
+			t.Fatalf("status = %d (%s)", resp.status, resp.code)
+		}
+		if repo.created != 1 || resp.data.User.Username != "budi" || resp.data.User.Email != "budi@example.com" || resp.data.User.Role != "user" {
+			t.Errorf("unexpected user: %+v (created %d)", resp.data.User, repo.created)
+		}
+		if repo.identities[idp.server.URL+" subject-1"] != resp.data.User.ID {
+			t.Error("new user is not linked to the IdP subject")
+		}
+		if resp.data.Token == "" {
+			t.Error("missing application token")
+		}
+	})
+
+	t.Run("username taken", func(t *testing.T) {
+		repo := &fakeUserRepo{identities: map[string]int{}, users: []models.User{{ID: 1, Username: "budi", Email: "other@example.com", Role: "user"}}}
+		app := newOIDCTestApp(idp, repo, true)
+		authURL, state := startLogin(t, app)
+
+		resp := oidcRequest(t, app, callbackURL(state, idp.authorize(authURL, nil)))
+		if resp.status != fiber.StatusOK || resp.data.User.Username != "budi2" {
+			t.Errorf("status = %d, username = %q, want budi2", resp.status, resp.data.User.Username)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		repo := &fakeUserRepo{identities: map[string]int{}}
+		app := newOIDCTestApp(idp, repo, false)
+		authURL, state := startLogin(t, app)
+
+		resp := oidcRequest(t, app, callbackURL(state, idp.authorize(authURL, nil)))
+		if resp.status != fiber.StatusForbidden || repo.created != 0 {
+			t.Errorf("status = %d, created = %d, want 403 without new user", resp.status, repo.created)
+		}
+	})
+}
+
+func TestOIDCCallbackLinksExistingUser(t *testing.T) {
+	idp := newOIDCTestIdP(t)
+
+	t.Run("by verified email", func(t *testing.T) {
+		repo := &fakeUserRepo{identities: map[string]int{}, users: []models.User{{ID: 7, Username: "budi.s", Email: "budi@example.com", Role: "admin"}}}
+		// Auto-provision mati: user lokal dengan email sama tetap bisa login dan dihubungkan
+		app := newOIDCTestApp(idp, repo, false)
+		authURL, state := startLogin(t, app)
+
+		resp := oidcRequest(t, app, callbackURL(state, idp.authorize(authURL, nil)))
+		if resp.status != fiber.StatusOK || resp.data.User.ID != 7 || resp.data.User.Role != "admin" {
+			t.Fatalf("status = %d, user = %+v", resp.status, resp.data.User)
+		}
+		if repo.created != 0 || repo.identities[idp.server.URL+" subject-1"] != 7 {
+			t.Errorf("expected link to user 7 without creating users, identities %v", repo.identities)
+		}
+	})
+
+	t.Run("by subject", func(t *testing.T) {
+		repo := &fakeUserRepo{
+			identities: map[string]int{idp.server.URL + " subject-1": 3},
+			users:      []models.User{{ID: 3, Username: "alumni3", Email: "lama@example.com", Role: "user"}},
+		}
+		app := newOIDCTestApp(idp, repo, false)
+		authURL, state := startLogin(t, app)
+
+		// Email di IdP sudah berubah dan belum terverifikasi, subject tetap menentukan user
+		code := idp.authorize(authURL, func(claims *models.OIDCIDTokenClaims) {
+			claims.Email, claims.EmailVerified = "baru@example.com", false
+		})
+		resp := oidcRequest(t, app, callbackURL(state, code))
+		if resp.status != fiber.StatusOK || resp.data.User.ID != 3 || repo.created != 0 {
+			t.Errorf("status = %d (%s), user = %+v", resp.status, resp.code, resp.data.User)
+		}
+	})
+}
+
+func TestOIDCDisabled(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) {
+			return c.SendStatus(appErr.Status)
+		}
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}})
+	service := NewOIDCService(&fakeUserRepo{}, nopAuditService{}, utils.OIDCConfig{})
+	app.Get("/login", service.Login)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/login", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
+
+// Pastikan URL login membawa parameter yang dibutuhkan IdP
+func TestOIDCLoginRedirect(t *testing.T) {
+	idp := newOIDCTestIdP(t)
+	app := newOIDCTestApp(idp, &fakeUserRepo{identities: map[string]int{}}, true)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/login", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	location := resp.Header.Get(fiber.HeaderLocation)
+	if resp.StatusCode != fiber.StatusFound || !strings.HasPrefix(location, idp.server.URL+"/authorize?") {
+		t.Fatalf("status = %d, location = %s", resp.StatusCode, location)
+	}
+	authURL, _ := url.Parse(location)
+	for _, name := range []string{"state", "nonce", "code_challenge"} {
+		if authURL.Query().Get(name) == "" {
+			t.Errorf("missing %s in authorization URL", name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"alumni-management-system/utils"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// mergePatch - terapkan body JSON Merge Patch (RFC 7386) ke req yang sudah berisi data saat ini.
+// Field yang tidak dikirim tidak berubah dan null mengosongkan field opsional. Field yang tidak dikenal
+// atau field wajib yang di-null-kan ditolak, lalu hasil merge divalidasi dengan aturan yang sama seperti PUT.
+// Return nama field json yang dikirim client.
+func mergePatch(c *fiber.Ctx, req interface{}) (map[string]bool, error) {
+	var patch map[string]json.RawMessage
+	body := bytes.TrimSpace(c.Body())
+	if len(body) == 0 || body[0] != '{' || json.Unmarshal(body, &patch) != nil {
+		return nil, utils.BadRequest("Body PATCH harus berupa objek JSON")
+	}
+
+	target := reflect.ValueOf(req).Elem()
+	fields := jsonFields(target.Type())
+	names := make([]string, 0, len(patch))
+	for name := range patch {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	patched := map[string]bool{}
+	var errs []utils.FieldError
+	for _, name := range names {
+		index, ok := fields[name]
+		if !ok {
+			errs = append(errs, utils.FieldError{Field: name, Rule: "unknown", Message: "%s tidak bisa diubah", Args: []interface{}{name}})
+			continue
+		}
+		field := target.Field(index)
+		if string(bytes.TrimSpace(patch[name])) == "null" {
+			if field.Kind() != reflect.Ptr {
+				errs = append(errs, utils.FieldError{Field: name, Rule: "required", Message: "%s harus diisi", Args: []interface{}{name}})
+				continue
+			}
+			field.Set(reflect.Zero(field.Type()))
+		} else {
+			// Nilai baru di-decode ke variabel baru: pointer di req masih bisa menunjuk ke data lama
+			value := reflect.New(field.Type())
+			if err := json.Unmarshal(patch[name], value.Interface()); err != nil {
+				errs = append(errs, utils.FieldError{Field: name, Rule: "type", Message: "%s tidak valid", Args: []interface{}{name}})
+				continue
+			}
+			field.Set(value.Elem())
+		}
+		patched[name] = true
+	}
+	if len(errs) > 0 {
+		return nil, utils.NewValidationError(errs...)
+	}
+	return patched, utils.ValidateStruct(req)
+}
+
+// changedFields - field yang nilainya berbeda antara before dan after (struct request yang sama),
+// kunci = nama field json. Pointer dibandingkan isinya, sehingga mengirim nilai yang sama bukan perubahan.
+func changedFields(before, after interface{}) map[string]interface{} {
+	b, a := reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem()
+	changes := map[string]interface{}{}
+	for name, index := range jsonFields(a.Type()) {
+		if !reflect.DeepEqual(b.Field(index).Interface(), a.Field(index).Interface()) {
+			changes[name] = a.Field(index).Interface()
+		}
+	}
+	return changes
+}
+
+// jsonFields - nama field json -> index field struct
+func jsonFields(t reflect.Type) map[string]int {
+	fields := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
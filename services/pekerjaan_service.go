@@ -1,11 +1,15 @@
 package services
 
 import (
+	"alumni-management-system/i18n"
 	"alumni-management-system/models"
 	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
 	
+	"os"
 	"strconv"
 	"strings"
+	"time"
     "database/sql"
 	
 
@@ -19,27 +23,58 @@ type PekerjaanService interface {
 	GetPekerjaanByAlumniID(c *fiber.Ctx) error // Signature: func(*fiber.Ctx) error
 	CreatePekerjaan(c *fiber.Ctx) error // Signature: func(*fiber.Ctx) error
 	UpdatePekerjaan(c *fiber.Ctx) error // Signature: func(*fiber.Ctx) error
+	PatchPekerjaan(c *fiber.Ctx) error // PATCH /pekerjaan/:id (JSON Merge Patch)
 	DeletePekerjaan(c *fiber.Ctx) error 
     SoftDeletePekerjaan(c *fiber.Ctx) error// Signature: func(*fiber.Ctx) error
 	GetAllNonPekerjaan(c *fiber.Ctx) error
     GetTrashedPekerjaan(c *fiber.Ctx) error
+    GetTrashedPekerjaanByID(c *fiber.Ctx) error // GET /pekerjaan/trash/:id (ETag untuk hard delete/restore)
     HardDeleteTrashedPekerjaan(c *fiber.Ctx) error 
     RestoreTrashedPekerjaan(c *fiber.Ctx) error  
+    GetPekerjaanHistory(c *fiber.Ctx) error
+    RevertPekerjaan(c *fiber.Ctx) error
+    EndPekerjaan(c *fiber.Ctx) error
       // Signature: func(*fiber.Ctx) error
 }
 
 type pekerjaanService struct {
-	pekerjaanRepo repositories.PekerjaanRepository
-	alumniRepo    repositories.AlumniRepository
+	pekerjaanRepo  repositories.PekerjaanRepository
+	alumniRepo     repositories.AlumniRepository
+	historyRepo    repositories.HistoryRepository
+	auditService   AuditService
+	companyService CompanyService
+	webhookService WebhookService
+
+	// Boleh lebih dari satu pekerjaan aktif per alumni (env PEKERJAAN_ALLOW_MULTIPLE_ACTIVE, default false)
+	allowMultipleActive bool
 }
 
-func NewPekerjaanService(pekerjaanRepo repositories.PekerjaanRepository, alumniRepo repositories.AlumniRepository) PekerjaanService {
+// Transisi status_pekerjaan yang diizinkan (status yang sama selalu boleh).
+// Pekerjaan yang sudah berakhir tidak bisa kembali aktif; selesai <-> resigned dianggap koreksi data.
+var pekerjaanStatusTransitions = map[string][]string{
+	models.StatusPekerjaanAktif:    {models.StatusPekerjaanSelesai, models.StatusPekerjaanResigned},
+	models.StatusPekerjaanSelesai:  {models.StatusPekerjaanResigned},
+	models.StatusPekerjaanResigned: {models.StatusPekerjaanSelesai},
+}
+
+func NewPekerjaanService(pekerjaanRepo repositories.PekerjaanRepository, alumniRepo repositories.AlumniRepository, historyRepo repositories.HistoryRepository, auditService AuditService, companyService CompanyService, webhookService WebhookService) PekerjaanService {
 	return &pekerjaanService{
-		pekerjaanRepo: pekerjaanRepo,
-		alumniRepo:    alumniRepo,
+		pekerjaanRepo:       pekerjaanRepo,
+		alumniRepo:          alumniRepo,
+		historyRepo:         historyRepo,
+		auditService:        auditService,
+		companyService:      companyService,
+		webhookService:      webhookService,
+		allowMultipleActive: allowMultipleActivePekerjaan(),
 	}
 }
 
+// allowMultipleActivePekerjaan - env PEKERJAAN_ALLOW_MULTIPLE_ACTIVE
+func allowMultipleActivePekerjaan() bool {
+	allow, _ := strconv.ParseBool(os.Getenv("PEKERJAAN_ALLOW_MULTIPLE_ACTIVE"))
+	return allow
+}
+
 
 
 
@@ -47,7 +82,7 @@ func NewPekerjaanService(pekerjaanRepo repositories.PekerjaanRepository, alumniR
 func (s *pekerjaanService) SoftDeletePekerjaan(c *fiber.Ctx) error {
     pekerjaanID, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID pekerjaan tidak valid", "error": err.Error()})
+        return utils.BadRequest("ID pekerjaan tidak valid").WithError(err)
     }
 
     requesterRole := c.Locals("role").(string)
@@ -56,362 +91,601 @@ func (s *pekerjaanService) SoftDeletePekerjaan(c *fiber.Ctx) error {
     // 1. Dapatkan detail pekerjaan yang akan dihapus
     pekerjaan, err := s.pekerjaanRepo.GetByID(pekerjaanID)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal mengambil detail pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal mengambil detail pekerjaan", err)
     }
     if pekerjaan == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan atau sudah dihapus."})
+        return utils.NotFound("Pekerjaan tidak ditemukan atau sudah dihapus.")
     }
 
     // 2. Dapatkan detail alumni yang terkait dengan pekerjaan ini
     alumniPekerjaan, err := s.alumniRepo.GetByID(pekerjaan.AlumniID)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal mengambil detail alumni terkait pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal mengambil detail alumni terkait pekerjaan", err)
     }
     if alumniPekerjaan == nil {
         // Ini seharusnya tidak terjadi jika data konsisten, tapi baik untuk penanganan error
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Alumni terkait pekerjaan tidak ditemukan."})
+        return utils.NotFound("Alumni terkait pekerjaan tidak ditemukan.")
     }
+    etag := recordETag(pekerjaan.ID, pekerjaan.UpdatedAt)
 
-    // Logika Otorisasi
+    // Logika Otorisasi (If-Match diperiksa setelahnya agar non-pemilik tidak melihat ETag)
     if requesterRole == "admin" {
         // Admin boleh menghapus semua riwayat pekerjaan
-        err = s.pekerjaanRepo.SoftDelete(pekerjaanID)
+        if err := checkIfMatch(c, etag); err != nil {
+            return err
+        }
+        err = s.pekerjaanRepo.SoftDelete(pekerjaanID, pekerjaan.UpdatedAt)
         if err != nil {
             if err == sql.ErrNoRows {
-                return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan atau sudah dihapus."})
+                return utils.NotFound("Pekerjaan tidak ditemukan atau sudah dihapus.")
             }
-            return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal melakukan soft delete pekerjaan", "error": err.Error()})
+            return writeFailed("Gagal melakukan soft delete pekerjaan", err)
         }
-        return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan berhasil di-soft delete oleh admin."})
+        s.auditService.Record(c, AuditActionSoftDelete, AuditEntityPekerjaan, pekerjaanID, pekerjaan, nil)
+        s.webhookService.Publish(models.WebhookEventPekerjaanSoftDeleted, pekerjaan)
+        return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil di-soft delete oleh admin.")})
     } else if requesterRole == "user" {
         
         if alumniPekerjaan.UserID == nil || *alumniPekerjaan.UserID != requesterUserID {
-            return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "message": "Akses ditolak. Anda hanya dapat menghapus pekerjaan Anda sendiri."})
+            return utils.Forbidden("Akses ditolak. Anda hanya dapat menghapus pekerjaan Anda sendiri.")
+        }
+        if err := checkIfMatch(c, etag); err != nil {
+            return err
         }
 
-        err = s.pekerjaanRepo.SoftDelete(pekerjaanID)
+        err = s.pekerjaanRepo.SoftDelete(pekerjaanID, pekerjaan.UpdatedAt)
         if err != nil {
             if err == sql.ErrNoRows {
-                return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan atau sudah dihapus."})
+                return utils.NotFound("Pekerjaan tidak ditemukan atau sudah dihapus.")
             }
-            return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Gagal melakukan soft delete pekerjaan", "error": err.Error()})
+            return writeFailed("Gagal melakukan soft delete pekerjaan", err)
         }
-        return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan Anda berhasil di-soft delete."})
+        s.auditService.Record(c, AuditActionSoftDelete, AuditEntityPekerjaan, pekerjaanID, pekerjaan, nil)
+        s.webhookService.Publish(models.WebhookEventPekerjaanSoftDeleted, pekerjaan)
+        return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan Anda berhasil di-soft delete.")})
     } else {
-        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"success": false, "message": "Akses ditolak. Role tidak valid."})
+        return utils.Forbidden("Akses ditolak. Role tidak valid.")
     }
 }
 
-// GetAllPekerjaan - handle GET /pekerjaan (dengan pagination, search, sorting)
+// GetAllPekerjaan - handle GET /pekerjaan (dengan pagination, search, filter, sorting)
 func (s *pekerjaanService) GetAllPekerjaan(c *fiber.Ctx) error {
     page, _ := strconv.Atoi(c.Query("page", "1"))
     limit, _ := strconv.Atoi(c.Query("limit", "10"))
-    sortBy := c.Query("sortBy", "id")
-    order := c.Query("order", "asc")
-    search := c.Query("search", "")
+    search := strings.TrimSpace(c.Query("search", ""))
 
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 {
+        limit = 10
+    }
     offset := (page - 1) * limit
 
-    // Validasi input sortBy
-    sortByWhitelist := map[string]bool{"id": true, "alumni_id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true}
-    if !sortByWhitelist[sortBy] {
-        sortBy = "id"
+    // Validasi input sortBy (relevance hanya jika ada search)
+    sortByWhitelist := map[string]bool{"id": true, "alumni_id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "gaji_min": true, "gaji_max": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true}
+    sortBy, order := querySort(c, sortByWhitelist, search)
+
+    filter, err := parsePekerjaanFilter(c)
+    if err != nil {
+        return err
+    }
+
+    // Pagination cursor (keyset) jika ?cursor= dikirim: tanpa OFFSET dan tanpa COUNT(*)
+    cursor, err := parseCursor(c, sortBy, order, limit)
+    if err != nil {
+        return err
     }
-    if strings.ToLower(order) != "desc" {
-        order = "asc"
+    if cursor != nil {
+        pekerjaanList, keys, err := s.pekerjaanRepo.GetAllKeyset(search, sortBy, order, filter, *cursor)
+        if err != nil {
+            return utils.Internal("Gagal mengambil data pekerjaan alumni", err)
+        }
+        pekerjaanList, next, prev := keysetResult(pekerjaanList, keys, *cursor, sortBy, order)
+        return c.JSON(models.PekerjaanCursorResponse{
+            Data: pekerjaanList,
+            Meta: models.CursorMetaInfo{
+                Limit:   cursor.Limit,
+                SortBy:  sortBy,
+                Order:   order,
+                Search:  search,
+                Filters: filterMeta(filter),
+                Next:    next,
+                Prev:    prev,
+            },
+        })
     }
 
     // Ambil data dari repository
-    pekerjaanList, err := s.pekerjaanRepo.GetAllPaginated(search, sortBy, order, limit, offset)
+    pekerjaanList, err := s.pekerjaanRepo.GetAllPaginated(search, sortBy, order, filter, limit, offset)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch pekerjaan alumni", "error": err.Error()})
+        return utils.Internal("Gagal mengambil data pekerjaan alumni", err)
     }
 
-    total, err := s.pekerjaanRepo.CountPekerjaan(search)
+    total, err := s.pekerjaanRepo.CountPekerjaan(search, filter)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to count pekerjaan alumni", "error": err.Error()})
+        return utils.Internal("Gagal menghitung pekerjaan alumni", err)
     }
 
     // Buat response pakai model
     response := models.PekerjaanResponse{
         Data: pekerjaanList,
         Meta: models.MetaInfo{
-            Page:   page,
-            Limit:  limit,
-            Total:  total,
-            Pages:  (total + limit - 1) / limit,
-            SortBy: sortBy,
-            Order:  order,
-            Search: search,
+            Page:    page,
+            Limit:   limit,
+            Total:   total,
+            Pages:   (total + limit - 1) / limit,
+            SortBy:  sortBy,
+            Order:   order,
+            Search:  search,
+            Filters: filterMeta(filter),
         },
     }
     return c.JSON(response)
 }
 
-// GetPekerjaanByID - handle GET /pekerjaan/:id
+// GetPekerjaanByID - handle GET /pekerjaan/:id (opsional ?as_of=<timestamp> untuk state di masa lalu)
 func (s *pekerjaanService) GetPekerjaanByID(c *fiber.Ctx) error {
     id, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID tidak valid", "error": err.Error()})
+        return utils.BadRequest("ID tidak valid").WithError(err)
+    }
+
+    if asOfParam := c.Query("as_of"); asOfParam != "" {
+        asOf, err := parseTimeQuery(asOfParam, true)
+        if err != nil {
+            return utils.BadRequest("Format as_of tidak valid (RFC3339 atau YYYY-MM-DD)").WithError(err)
+        }
+
+        pekerjaan, err := s.historyRepo.GetPekerjaanAsOf(id, asOf)
+        if err != nil {
+            return utils.Internal("Gagal mengambil riwayat pekerjaan", err)
+        }
+        if pekerjaan == nil {
+            return utils.NotFound("Pekerjaan tidak ditemukan pada waktu tersebut")
+        }
+        return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data pekerjaan berhasil diambil"), "data": pekerjaan, "as_of": asOf})
     }
 
     pekerjaan, err := s.pekerjaanRepo.GetByID(id)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal mengambil data pekerjaan", err)
     }
     if pekerjaan == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan"})
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+    if notModified(c, recordETag(pekerjaan.ID, pekerjaan.UpdatedAt)) {
+        return c.SendStatus(fiber.StatusNotModified)
     }
 
-    return c.JSON(fiber.Map{"success": true, "message": "Data pekerjaan berhasil diambil", "data": pekerjaan})
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data pekerjaan berhasil diambil"), "data": pekerjaan})
 }
 
 // GetPekerjaanByAlumniID - handle GET /pekerjaan/alumni/:alumni_id
 func (s *pekerjaanService) GetPekerjaanByAlumniID(c *fiber.Ctx) error {
     alumniID, err := strconv.Atoi(c.Params("alumni_id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Alumni ID tidak valid", "error": err.Error()})
+        return utils.BadRequest("Alumni ID tidak valid").WithError(err)
     }
 
     // Cek apakah alumni exists
     alumni, err := s.alumniRepo.GetByID(alumniID)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check alumni", "error": err.Error()})
+        return utils.Internal("Gagal memeriksa data alumni", err)
     }
 
     if alumni == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+        return utils.NotFound("Alumni tidak ditemukan")
     }
 
     pekerjaanList, err := s.pekerjaanRepo.GetByAlumniID(alumniID)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch pekerjaan alumni", "error": err.Error()})
+        return utils.Internal("Gagal mengambil data pekerjaan alumni", err)
     }
 
-    return c.JSON(fiber.Map{"success": true, "message": "Data pekerjaan alumni berhasil diambil", "data": pekerjaanList})
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data pekerjaan alumni berhasil diambil"), "data": pekerjaanList})
 }
 
 // CreatePekerjaan - handle POST /pekerjaan
 func (s *pekerjaanService) CreatePekerjaan(c *fiber.Ctx) error {
     var req models.CreatePekerjaanRequest
-    if err := c.BodyParser(&req); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+    if err := bindRequest(c, &req); err != nil {
+        return err
     }
 
-    // Validasi input
-    if req.AlumniID <= 0 {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Alumni ID harus valid"})
+    // Validasi tanggal terhadap status
+    if err := validatePekerjaanDates(req.StatusPekerjaan, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, time.Now()); err != nil {
+        return err
     }
 
-    if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Nama perusahaan, posisi jabatan, bidang industri, dan lokasi kerja harus diisi"})
+    if err := normalizeGaji(&req.GajiRange, &req.GajiMin, &req.GajiMax, &req.GajiCurrency); err != nil {
+        return err
     }
 
-    if req.StatusPekerjaan == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Status pekerjaan harus diisi"})
-    }
-
-    if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Status pekerjaan harus salah satu dari: aktif, selesai, resigned"})
+    // Cek apakah alumni exists
+    alumni, err := s.alumniRepo.GetByID(req.AlumniID)
+    if err != nil {
+        return utils.Internal("Gagal memeriksa data alumni", err)
     }
 
-    // Validasi tanggal
-    if req.TanggalMulaiKerja.IsZero() {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Tanggal mulai kerja harus diisi"})
+    if alumni == nil {
+        return utils.NotFound("Alumni tidak ditemukan")
     }
 
-    if req.TanggalSelesaiKerja != nil && req.TanggalSelesaiKerja.Before(req.TanggalMulaiKerja) {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Tanggal selesai kerja tidak boleh lebih awal dari tanggal mulai kerja"})
+    if req.StatusPekerjaan == models.StatusPekerjaanAktif {
+        activeIDs, err := s.conflictingActiveJobs(req.AlumniID, 0)
+        if err != nil {
+            return utils.Internal("Gagal memeriksa pekerjaan aktif", err)
+        }
+        if len(activeIDs) > 0 {
+            return activePekerjaanConflict("Alumni masih memiliki pekerjaan aktif, akhiri pekerjaan tersebut terlebih dahulu", activeIDs)
+        }
     }
 
-    // Cek apakah alumni exists
-    alumni, err := s.alumniRepo.GetByID(req.AlumniID)
+    companyMatch, err := s.companyService.ResolveCompany(req.CompanyID, req.NamaPerusahaan, req.BidangIndustri, req.LokasiKerja)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check alumni", "error": err.Error()})
-    }
-
-    if alumni == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Alumni tidak ditemukan"})
+        return err
     }
+    req.CompanyID = &companyMatch.Company.ID
 
     pekerjaan, err := s.pekerjaanRepo.Create(&req)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to create pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal membuat data pekerjaan", err)
     }
 
-    return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": "Pekerjaan berhasil ditambahkan", "data": pekerjaan})
+    s.auditService.Record(c, AuditActionCreate, AuditEntityPekerjaan, pekerjaan.ID, nil, pekerjaan)
+    s.webhookService.Publish(models.WebhookEventPekerjaanCreated, pekerjaan)
+
+    return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil ditambahkan"), "data": pekerjaan, "company_match": companyMatch})
 }
 
 // UpdatePekerjaan - handle PUT /pekerjaan/:id
 func (s *pekerjaanService) UpdatePekerjaan(c *fiber.Ctx) error {
     id, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID tidak valid", "error": err.Error()})
+        return utils.BadRequest("ID tidak valid").WithError(err)
     }
 
     var req models.UpdatePekerjaanRequest
-    if err := c.BodyParser(&req); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Request body tidak valid", "error": err.Error()})
+    if err := bindRequest(c, &req); err != nil {
+        return err
     }
 
-    // Validasi input
-    if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Nama perusahaan, posisi jabatan, bidang industri, dan lokasi kerja harus diisi"})
+    // Validasi tanggal terhadap status
+    if err := validatePekerjaanDates(req.StatusPekerjaan, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, time.Now()); err != nil {
+        return err
     }
 
-    if req.StatusPekerjaan == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Status pekerjaan harus diisi"})
+    if err := normalizeGaji(&req.GajiRange, &req.GajiMin, &req.GajiMax, &req.GajiCurrency); err != nil {
+        return err
     }
 
-    if req.StatusPekerjaan != "aktif" && req.StatusPekerjaan != "selesai" && req.StatusPekerjaan != "resigned" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Status pekerjaan harus salah satu dari: aktif, selesai, resigned"})
+    // Cek apakah pekerjaan exists
+    existingPekerjaan, err := s.pekerjaanRepo.GetByID(id)
+    if err != nil {
+        return utils.Internal("Gagal memeriksa data pekerjaan", err)
     }
 
-    // Validasi tanggal
-    if req.TanggalMulaiKerja.IsZero() {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Tanggal mulai kerja harus diisi"})
+    if existingPekerjaan == nil {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
     }
 
-    if req.TanggalSelesaiKerja != nil && req.TanggalSelesaiKerja.Before(req.TanggalMulaiKerja) {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "Tanggal selesai kerja tidak boleh lebih awal dari tanggal mulai kerja"})
+    if err := checkIfMatch(c, recordETag(existingPekerjaan.ID, existingPekerjaan.UpdatedAt)); err != nil {
+        return err
     }
 
-    // Cek apakah pekerjaan exists
-    existingPekerjaan, err := s.pekerjaanRepo.GetByID(id)
+    if !canTransitionPekerjaan(existingPekerjaan.StatusPekerjaan, req.StatusPekerjaan) {
+        return utils.Conflict("Transisi status dari %s ke %s tidak diizinkan", existingPekerjaan.StatusPekerjaan, req.StatusPekerjaan).WithCode("INVALID_STATUS_TRANSITION")
+    }
+
+    companyMatch, err := s.companyService.ResolveCompany(req.CompanyID, req.NamaPerusahaan, req.BidangIndustri, req.LokasiKerja)
+    if err != nil {
+        return err
+    }
+    req.CompanyID = &companyMatch.Company.ID
+
+    pekerjaan, err := s.pekerjaanRepo.Update(id, &req, existingPekerjaan.UpdatedAt)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check pekerjaan", "error": err.Error()})
+        return writeFailed("Gagal memperbarui data pekerjaan", err)
     }
+    if pekerjaan == nil {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+
+    s.auditService.Record(c, AuditActionUpdate, AuditEntityPekerjaan, id, existingPekerjaan, pekerjaan)
+    s.webhookService.Publish(models.WebhookEventPekerjaanUpdated, pekerjaan)
+    c.Set(fiber.HeaderETag, recordETag(pekerjaan.ID, pekerjaan.UpdatedAt))
 
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil diupdate"), "data": pekerjaan, "company_match": companyMatch})
+}
+
+// PatchPekerjaan - handle PATCH /pekerjaan/:id. Hanya field yang dikirim yang berubah; aturan tanggal,
+// transisi status, dan gaji diperiksa terhadap hasil gabungan dengan data lama. company_match hanya
+// diisi jika nama_perusahaan atau company_id ikut dikirim.
+func (s *pekerjaanService) PatchPekerjaan(c *fiber.Ctx) error {
+    id, err := strconv.Atoi(c.Params("id"))
+    if err != nil {
+        return utils.BadRequest("ID tidak valid").WithError(err)
+    }
+
+    existingPekerjaan, err := s.pekerjaanRepo.GetByID(id)
+    if err != nil {
+        return utils.Internal("Gagal memeriksa data pekerjaan", err)
+    }
     if existingPekerjaan == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan"})
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+
+    etag := recordETag(existingPekerjaan.ID, existingPekerjaan.UpdatedAt)
+    if err := checkIfMatch(c, etag); err != nil {
+        return err
     }
 
-    pekerjaan, err := s.pekerjaanRepo.Update(id, &req)
+    current := pekerjaanUpdateRequest(existingPekerjaan)
+    req := current
+    patched, err := mergePatch(c, &req)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to update pekerjaan", "error": err.Error()})
+        return err
     }
 
-    return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan berhasil diupdate", "data": pekerjaan})
+    if err := validatePekerjaanDates(req.StatusPekerjaan, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, time.Now()); err != nil {
+        return err
+    }
+
+    // gaji_range selalu diturunkan dari gaji_min/gaji_max; range baru tanpa angka baru diparse ulang,
+    // sedangkan label lama dibuang jika angka dikirim agar {"gaji_min": null, "gaji_max": null} benar-benar menghapus gaji
+    if patched["gaji_range"] || patched["gaji_min"] || patched["gaji_max"] || patched["gaji_currency"] {
+        if patched["gaji_range"] && !patched["gaji_min"] && !patched["gaji_max"] {
+            req.GajiMin, req.GajiMax = nil, nil
+        }
+        if !patched["gaji_range"] && (patched["gaji_min"] || patched["gaji_max"]) {
+            req.GajiRange = nil
+        }
+        if err := normalizeGaji(&req.GajiRange, &req.GajiMin, &req.GajiMax, &req.GajiCurrency); err != nil {
+            return err
+        }
+    }
+
+    if !canTransitionPekerjaan(existingPekerjaan.StatusPekerjaan, req.StatusPekerjaan) {
+        return utils.Conflict("Transisi status dari %s ke %s tidak diizinkan", existingPekerjaan.StatusPekerjaan, req.StatusPekerjaan).WithCode("INVALID_STATUS_TRANSITION")
+    }
+
+    // Nama perusahaan baru tanpa company_id dicocokkan ulang, bukan tetap menempel ke perusahaan lama
+    var companyMatch *models.CompanyMatch
+    if patched["nama_perusahaan"] || patched["company_id"] {
+        if !patched["company_id"] {
+            req.CompanyID = nil
+        }
+        companyMatch, err = s.companyService.ResolveCompany(req.CompanyID, req.NamaPerusahaan, req.BidangIndustri, req.LokasiKerja)
+        if err != nil {
+            return err
+        }
+        req.CompanyID = &companyMatch.Company.ID
+    }
+
+    changes := changedFields(&current, &req)
+    if len(changes) == 0 {
+        c.Set(fiber.HeaderETag, etag)
+        return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil diupdate"), "data": existingPekerjaan, "company_match": companyMatch})
+    }
+
+    pekerjaan, err := s.pekerjaanRepo.Patch(id, changes, existingPekerjaan.UpdatedAt)
+    if err != nil {
+        return writeFailed("Gagal memperbarui data pekerjaan", err)
+    }
+    if pekerjaan == nil {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+
+    s.auditService.Record(c, AuditActionUpdate, AuditEntityPekerjaan, id, existingPekerjaan, pekerjaan)
+    s.webhookService.Publish(models.WebhookEventPekerjaanUpdated, pekerjaan)
+    c.Set(fiber.HeaderETag, recordETag(pekerjaan.ID, pekerjaan.UpdatedAt))
+
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil diupdate"), "data": pekerjaan, "company_match": companyMatch})
+}
+
+// pekerjaanUpdateRequest - isi UpdatePekerjaanRequest dari data pekerjaan (dasar PATCH dan revert)
+func pekerjaanUpdateRequest(pekerjaan *models.PekerjaanAlumni) models.UpdatePekerjaanRequest {
+    return models.UpdatePekerjaanRequest{
+        NamaPerusahaan:      pekerjaan.NamaPerusahaan,
+        PosisiJabatan:       pekerjaan.PosisiJabatan,
+        BidangIndustri:      pekerjaan.BidangIndustri,
+        LokasiKerja:         pekerjaan.LokasiKerja,
+        GajiRange:           pekerjaan.GajiRange,
+        GajiMin:             pekerjaan.GajiMin,
+        GajiMax:             pekerjaan.GajiMax,
+        GajiCurrency:        pekerjaan.GajiCurrency,
+        CompanyID:           pekerjaan.CompanyID,
+        TanggalMulaiKerja:   pekerjaan.TanggalMulaiKerja,
+        TanggalSelesaiKerja: pekerjaan.TanggalSelesaiKerja,
+        StatusPekerjaan:     pekerjaan.StatusPekerjaan,
+        DeskripsiPekerjaan:  pekerjaan.DeskripsiPekerjaan,
+    }
 }
 
 // DeletePekerjaan - handle DELETE /pekerjaan/:id
 func (s *pekerjaanService) DeletePekerjaan(c *fiber.Ctx) error {
     id, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "message": "ID tidak valid", "error": err.Error()})
+        return utils.BadRequest("ID tidak valid").WithError(err)
     }
 
     // Cek apakah pekerjaan exists
     existingPekerjaan, err := s.pekerjaanRepo.GetByID(id)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to check pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal memeriksa data pekerjaan", err)
     }
 
     if existingPekerjaan == nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Pekerjaan tidak ditemukan"})
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+    if err := checkIfMatch(c, recordETag(existingPekerjaan.ID, existingPekerjaan.UpdatedAt)); err != nil {
+        return err
     }
 
-    err = s.pekerjaanRepo.Delete(id)
+    err = s.pekerjaanRepo.Delete(id, existingPekerjaan.UpdatedAt)
+    if err == sql.ErrNoRows {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to delete pekerjaan", "error": err.Error()})
+        return writeFailed("Gagal menghapus data pekerjaan", err)
     }
 
-    return c.JSON(fiber.Map{"success": true, "message": "Pekerjaan berhasil dihapus"})
+    s.auditService.Record(c, AuditActionDelete, AuditEntityPekerjaan, id, existingPekerjaan, nil)
+    s.webhookService.Publish(models.WebhookEventPekerjaanDeleted, existingPekerjaan)
+
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil dihapus")})
 }
 
 // GetAllNonPekerjaan - handle GET /pekerjaan/non (jika diperlukan, tanpa pagination)
 func (s *pekerjaanService) GetAllNonPekerjaan(c *fiber.Ctx) error {
     pekerjaanList, err := s.pekerjaanRepo.GetAll()
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch pekerjaan alumni", "error": err.Error()})
+        return utils.Internal("Gagal mengambil data pekerjaan alumni", err)
     }
 
-    return c.JSON(fiber.Map{"success": true, "message": "Data pekerjaan berhasil diambil", "data": pekerjaanList})
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data pekerjaan berhasil diambil"), "data": pekerjaanList})
 }
 
 
-// GetTrashedPekerjaan - handle GET /pekerjaan/trash (data soft-deleted dengan pagination, search, sorting)
+// GetTrashedPekerjaan - handle GET /pekerjaan/trash (data soft-deleted dengan pagination, search, filter, sorting)
 func (s *pekerjaanService) GetTrashedPekerjaan(c *fiber.Ctx) error {
     page, _ := strconv.Atoi(c.Query("page", "1"))
     limit, _ := strconv.Atoi(c.Query("limit", "10"))
-    sortBy := c.Query("sortBy", "id")
-    order := c.Query("order", "asc")
-    search := c.Query("search", "")
+    search := strings.TrimSpace(c.Query("search", ""))
 
+    if page < 1 {
+        page = 1
+    }
+    if limit < 1 {
+        limit = 10
+    }
     offset := (page - 1) * limit
 
-   
-    sortByWhitelist := map[string]bool{"id": true, "alumni_id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true, "updated_at": true}
-    if !sortByWhitelist[sortBy] {
-        sortBy = "id"
+    // Validasi input sortBy (relevance hanya jika ada search)
+    sortByWhitelist := map[string]bool{"id": true, "alumni_id": true, "nama_perusahaan": true, "posisi_jabatan": true, "bidang_industri": true, "lokasi_kerja": true, "gaji_min": true, "gaji_max": true, "tanggal_mulai_kerja": true, "status_pekerjaan": true, "created_at": true, "updated_at": true}
+    sortBy, order := querySort(c, sortByWhitelist, search)
+
+    filter, err := parsePekerjaanFilter(c)
+    if err != nil {
+        return err
     }
-    if strings.ToLower(order) != "desc" {
-        order = "asc"
+
+    cursor, err := parseCursor(c, sortBy, order, limit)
+    if err != nil {
+        return err
+    }
+    if cursor != nil {
+        pekerjaanList, keys, err := s.pekerjaanRepo.GetTrashedKeyset(search, sortBy, order, filter, *cursor)
+        if err != nil {
+            return utils.Internal("Gagal mengambil pekerjaan di trash", err)
+        }
+        // Trash kosong tetap 404 seperti mode page; halaman kosong di tengah scroll masih membawa cursor
+        if len(pekerjaanList) == 0 && cursor.After == nil {
+            return utils.NotFound("Tidak ada data pekerjaan di trash")
+        }
+        pekerjaanList, next, prev := keysetResult(pekerjaanList, keys, *cursor, sortBy, order)
+        return c.JSON(models.PekerjaanCursorResponse{
+            Data: pekerjaanList,
+            Meta: models.CursorMetaInfo{
+                Limit:   cursor.Limit,
+                SortBy:  sortBy,
+                Order:   order,
+                Search:  search,
+                Filters: filterMeta(filter),
+                Next:    next,
+                Prev:    prev,
+            },
+        })
     }
 
-    
-    pekerjaanList, err := s.pekerjaanRepo.GetTrashedPaginated(search, sortBy, order, limit, offset)
+    pekerjaanList, err := s.pekerjaanRepo.GetTrashedPaginated(search, sortBy, order, filter, limit, offset)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to fetch trashed pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal mengambil pekerjaan di trash", err)
     }
 
-    total, err := s.pekerjaanRepo.CountTrashed(search)
+    total, err := s.pekerjaanRepo.CountTrashed(search, filter)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"success": false, "message": "Failed to count trashed pekerjaan", "error": err.Error()})
+        return utils.Internal("Gagal menghitung pekerjaan di trash", err)
     }
 
     if len(pekerjaanList) == 0 {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"success": false, "message": "Tidak ada data pekerjaan di trash"})
+        return utils.NotFound("Tidak ada data pekerjaan di trash")
     }
 
     
     response := models.PekerjaanResponse{
         Data: pekerjaanList,
         Meta: models.MetaInfo{
-            Page:   page,
-            Limit:  limit,
-            Total:  total,
-            Pages:  (total + limit - 1) / limit,
-            SortBy: sortBy,
-            Order:  order,
-            Search: search,
+            Page:    page,
+            Limit:   limit,
+            Total:   total,
+            Pages:   (total + limit - 1) / limit,
+            SortBy:  sortBy,
+            Order:   order,
+            Search:  search,
+            Filters: filterMeta(filter),
         },
     }
     return c.JSON(response)
 }
 
 
+// GetTrashedPekerjaanByID - handle GET /pekerjaan/trash/:id (detail pekerjaan di trash, hanya admin).
+// ETag-nya dipakai sebagai If-Match untuk hard delete dan restore.
+func (s *pekerjaanService) GetTrashedPekerjaanByID(c *fiber.Ctx) error {
+    id, err := strconv.Atoi(c.Params("id"))
+    if err != nil {
+        return utils.BadRequest("ID tidak valid").WithError(err)
+    }
+
+    trashed, err := s.pekerjaanRepo.GetTrashedByID(id)
+    if err != nil {
+        return utils.Internal("Gagal mengambil data pekerjaan di trash", err)
+    }
+    if trashed == nil {
+        return utils.NotFound("Data pekerjaan tidak ditemukan di trash")
+    }
+    if notModified(c, recordETag(trashed.ID, trashed.UpdatedAt)) {
+        return c.SendStatus(fiber.StatusNotModified)
+    }
+
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data pekerjaan berhasil diambil"), "data": trashed})
+}
 
 // HardDeleteTrashedPekerjaan - handle DELETE /pekerjaan/trash/:id (hard delete dari trash, hanya admin)
 func (s *pekerjaanService) HardDeleteTrashedPekerjaan(c *fiber.Ctx) error {
     id, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "ID tidak valid",
-            "error":   err.Error(),
-        })
+        return utils.BadRequest("ID tidak valid").WithError(err)
     }
 
-   
-    err = s.pekerjaanRepo.HardDeleteTrashed(id)
+    // Snapshot sebelum dihapus permanen untuk audit trail
+    trashed, err := s.pekerjaanRepo.GetTrashedByID(id)
+    if err != nil {
+        return utils.Internal("Gagal mengambil data pekerjaan di trash", err)
+    }
+    if trashed == nil {
+        return utils.NotFound("Data pekerjaan tidak ditemukan di trash")
+    }
+    if err := checkIfMatch(c, recordETag(trashed.ID, trashed.UpdatedAt)); err != nil {
+        return err
+    }
+
+    err = s.pekerjaanRepo.HardDeleteTrashed(id, trashed.UpdatedAt)
     if err != nil {
         if err == sql.ErrNoRows {
-            return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-                "success": false,
-                "message": "Data pekerjaan tidak ditemukan di trash",
-            })
-        }
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal melakukan hard delete dari trash",
-            "error":   err.Error(),
-        })
+            return utils.NotFound("Data pekerjaan tidak ditemukan di trash")
+        }
+        return writeFailed("Gagal melakukan hard delete dari trash", err)
     }
 
+    s.auditService.Record(c, AuditActionHardDelete, AuditEntityPekerjaan, id, trashed, nil)
+    s.webhookService.Publish(models.WebhookEventPekerjaanDeleted, trashed)
+
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Data pekerjaan berhasil di-hard delete dari trash",
+        "message": i18n.T(c, "Data pekerjaan berhasil di-hard delete dari trash"),
     })
 }
 
@@ -420,32 +694,341 @@ func (s *pekerjaanService) HardDeleteTrashedPekerjaan(c *fiber.Ctx) error {
 func (s *pekerjaanService) RestoreTrashedPekerjaan(c *fiber.Ctx) error {
     id, err := strconv.Atoi(c.Params("id"))
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "ID tidak valid",
-            "error":   err.Error(),
-        })
+        return utils.BadRequest("ID tidak valid").WithError(err)
     }
 
-   
-    restored, err := s.pekerjaanRepo.RestoreTrashed(id)
+    trashed, err := s.pekerjaanRepo.GetTrashedByID(id)
+    if err != nil {
+        return utils.Internal("Gagal mengambil data pekerjaan di trash", err)
+    }
+    if trashed == nil {
+        return utils.NotFound("Data pekerjaan tidak ditemukan di trash")
+    }
+    if err := checkIfMatch(c, recordETag(trashed.ID, trashed.UpdatedAt)); err != nil {
+        return err
+    }
+
+    // Restore pekerjaan aktif tunduk pada aturan jumlah pekerjaan aktif yang sama dengan create
+    if trashed.StatusPekerjaan == models.StatusPekerjaanAktif {
+        activeIDs, err := s.conflictingActiveJobs(trashed.AlumniID, id)
+        if err != nil {
+            return utils.Internal("Gagal memeriksa pekerjaan aktif", err)
+        }
+        if len(activeIDs) > 0 {
+            return activePekerjaanConflict("Alumni masih memiliki pekerjaan aktif, akhiri pekerjaan tersebut sebelum restore", activeIDs)
+        }
+    }
+
+    restored, err := s.pekerjaanRepo.RestoreTrashed(id, trashed.UpdatedAt)
     if err != nil {
         if err == sql.ErrNoRows {
-            return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-                "success": false,
-                "message": "Data pekerjaan tidak ditemukan di trash",
-            })
-        }
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Gagal melakukan restore dari trash",
-            "error":   err.Error(),
-        })
+            return utils.NotFound("Data pekerjaan tidak ditemukan di trash")
+        }
+        return writeFailed("Gagal melakukan restore dari trash", err)
+    }
+    if restored != nil {
+        c.Set(fiber.HeaderETag, recordETag(restored.ID, restored.UpdatedAt))
     }
 
+    s.auditService.Record(c, AuditActionRestore, AuditEntityPekerjaan, id, trashed, restored)
+    s.webhookService.Publish(models.WebhookEventPekerjaanRestored, restored)
+
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Data pekerjaan berhasil direstore dari trash",
+        "message": i18n.T(c, "Data pekerjaan berhasil direstore dari trash"),
         "data":    restored, 
     })
 }
+
+
+// GetPekerjaanHistory - handle GET /pekerjaan/:id/history (semua versi + field yang berubah)
+func (s *pekerjaanService) GetPekerjaanHistory(c *fiber.Ctx) error {
+    id, err := strconv.Atoi(c.Params("id"))
+    if err != nil {
+        return utils.BadRequest("ID tidak valid").WithError(err)
+    }
+
+    versions, err := s.historyRepo.GetPekerjaanHistory(id)
+    if err != nil {
+        return utils.Internal("Gagal mengambil riwayat pekerjaan", err)
+    }
+    if len(versions) == 0 {
+        return utils.NotFound("Riwayat pekerjaan tidak ditemukan")
+    }
+
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Riwayat pekerjaan berhasil diambil"), "data": withVersionChanges(id, versions)})
+}
+
+// RevertPekerjaan - handle POST /pekerjaan/:id/history/:version/revert (kembalikan data ke versi tertentu)
+func (s *pekerjaanService) RevertPekerjaan(c *fiber.Ctx) error {
+    id, err := strconv.Atoi(c.Params("id"))
+    if err != nil {
+        return utils.BadRequest("ID tidak valid").WithError(err)
+    }
+    version, err := strconv.Atoi(c.Params("version"))
+    if err != nil {
+        return utils.BadRequest("Versi tidak valid").WithError(err)
+    }
+
+    existingPekerjaan, err := s.pekerjaanRepo.GetByID(id)
+    if err != nil {
+        return utils.Internal("Gagal memeriksa data pekerjaan", err)
+    }
+    if existingPekerjaan == nil {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+    if err := checkIfMatch(c, recordETag(existingPekerjaan.ID, existingPekerjaan.UpdatedAt)); err != nil {
+        return err
+    }
+
+    snapshot, err := s.historyRepo.GetPekerjaanVersion(id, version)
+    if err != nil {
+        return utils.Internal("Gagal mengambil versi pekerjaan", err)
+    }
+    if snapshot == nil {
+        return utils.NotFound("Versi pekerjaan tidak ditemukan")
+    }
+
+    req := pekerjaanUpdateRequest(snapshot)
+
+    // Versi lama tetap tunduk pada aturan siklus hidup: pekerjaan yang sudah berakhir tidak bisa aktif lagi
+    if !canTransitionPekerjaan(existingPekerjaan.StatusPekerjaan, req.StatusPekerjaan) {
+        return utils.Conflict("Transisi status dari %s ke %s tidak diizinkan", existingPekerjaan.StatusPekerjaan, req.StatusPekerjaan).WithCode("INVALID_STATUS_TRANSITION")
+    }
+    if err := validatePekerjaanDates(req.StatusPekerjaan, req.TanggalMulaiKerja, req.TanggalSelesaiKerja, time.Now()); err != nil {
+        return err
+    }
+
+    // Revert ke versi aktif tidak boleh menghasilkan pekerjaan aktif ganda
+    if req.StatusPekerjaan == models.StatusPekerjaanAktif && existingPekerjaan.StatusPekerjaan != models.StatusPekerjaanAktif {
+        activeIDs, err := s.conflictingActiveJobs(existingPekerjaan.AlumniID, id)
+        if err != nil {
+            return utils.Internal("Gagal memeriksa pekerjaan aktif", err)
+        }
+        if len(activeIDs) > 0 {
+            return activePekerjaanConflict("Alumni masih memiliki pekerjaan aktif, akhiri pekerjaan tersebut terlebih dahulu", activeIDs)
+        }
+    }
+
+    // Perusahaan di snapshot bisa sudah dihapus/digabung - cocokkan ulang dari nama
+    companyMatch, err := s.companyService.ResolveCompany(req.CompanyID, req.NamaPerusahaan, req.BidangIndustri, req.LokasiKerja)
+    if err == errCompanyNotFound {
+        companyMatch, err = s.companyService.ResolveCompany(nil, req.NamaPerusahaan, req.BidangIndustri, req.LokasiKerja)
+    }
+    if err != nil {
+        return err
+    }
+    req.CompanyID = &companyMatch.Company.ID
+
+    pekerjaan, err := s.pekerjaanRepo.Update(id, &req, existingPekerjaan.UpdatedAt)
+    if err != nil {
+        return writeFailed("Gagal mengembalikan pekerjaan ke versi sebelumnya", err)
+    }
+    if pekerjaan == nil {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+
+    s.auditService.Record(c, AuditActionRevert, AuditEntityPekerjaan, id, existingPekerjaan, pekerjaan)
+    s.webhookService.Publish(models.WebhookEventPekerjaanUpdated, pekerjaan)
+    c.Set(fiber.HeaderETag, recordETag(pekerjaan.ID, pekerjaan.UpdatedAt))
+
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil dikembalikan ke versi %d", version), "data": pekerjaan})
+}
+
+// parsePekerjaanFilter - filter band gaji: gaji_min, gaji_max, gaji_currency (default IDR jika band diisi),
+// status_pekerjaan, bidang_industri, lokasi_kerja, tanggal_mulai_from/_to, tanggal_selesai_from/_to,
+// jurusan dan angkatan[_from|_to] alumni, serta employed_at (sedang bekerja pada tanggal tersebut)
+func parsePekerjaanFilter(c *fiber.Ctx) (models.PekerjaanFilter, error) {
+    filter := models.PekerjaanFilter{
+        GajiCurrency:    strings.ToUpper(strings.TrimSpace(c.Query("gaji_currency"))),
+        StatusPekerjaan: queryList(c, "status_pekerjaan"),
+        BidangIndustri:  queryList(c, "bidang_industri"),
+        LokasiKerja:     queryList(c, "lokasi_kerja"),
+        Jurusan:         queryList(c, "jurusan"),
+    }
+
+    for _, status := range filter.StatusPekerjaan {
+        if _, ok := pekerjaanStatusTransitions[status]; !ok {
+            return filter, utils.BadRequest("Status pekerjaan harus salah satu dari: aktif, selesai, resigned")
+        }
+    }
+
+    var err error
+    if filter.TanggalMulaiFrom, filter.TanggalMulaiTo, err = queryTimeRange(c, "tanggal_mulai"); err != nil {
+        return filter, err
+    }
+    if filter.TanggalSelesaiFrom, filter.TanggalSelesaiTo, err = queryTimeRange(c, "tanggal_selesai"); err != nil {
+        return filter, err
+    }
+    if filter.AngkatanFrom, filter.AngkatanTo, err = queryIntRange(c, "angkatan"); err != nil {
+        return filter, err
+    }
+    if value := c.Query("employed_at"); value != "" {
+        employedAt, err := parseTimeQuery(value, false)
+        if err != nil {
+            return filter, utils.BadRequest("Format tanggal %s tidak valid (RFC3339 atau YYYY-MM-DD)", "employed_at").WithError(err)
+        }
+        filter.EmployedAt = &employedAt
+    }
+
+    for name, target := range map[string]**int64{"gaji_min": &filter.GajiMin, "gaji_max": &filter.GajiMax} {
+        if value := c.Query(name); value != "" {
+            amount, err := strconv.ParseInt(value, 10, 64)
+            if err != nil || amount < 0 {
+                return filter, utils.BadRequest("Parameter %s harus berupa angka positif", name)
+            }
+            *target = &amount
+        }
+    }
+    if filter.GajiMin != nil && filter.GajiMax != nil && *filter.GajiMax < *filter.GajiMin {
+        return filter, utils.BadRequest("Parameter gaji_max tidak boleh lebih kecil dari gaji_min")
+    }
+
+    if filter.GajiCurrency == "" && (filter.GajiMin != nil || filter.GajiMax != nil) {
+        filter.GajiCurrency = utils.DefaultGajiCurrency
+    }
+    if filter.GajiCurrency != "" && !utils.IsValidGajiCurrency(filter.GajiCurrency) {
+        return filter, utils.BadRequest("Parameter gaji_currency harus kode mata uang 3 huruf, contoh: IDR")
+    }
+    return filter, nil
+}
+
+// normalizeGaji - lengkapi gaji_min/gaji_max/gaji_currency (parse dari gaji_range lama jika keduanya kosong),
+// validasi rentang, lalu isi gaji_range dengan label standar
+func normalizeGaji(gajiRange **string, gajiMin, gajiMax **int64, gajiCurrency **string) error {
+    if *gajiMin == nil && *gajiMax == nil {
+        if *gajiRange == nil || strings.TrimSpace(**gajiRange) == "" {
+            *gajiRange, *gajiCurrency = nil, nil
+            return nil
+        }
+        min, max, currency, err := utils.ParseGajiRange(**gajiRange)
+        if err != nil {
+            return utils.BadRequest("Gaji range tidak dapat dibaca, gunakan gaji_min dan gaji_max")
+        }
+        *gajiMin, *gajiMax = min, max
+        if *gajiCurrency == nil || strings.TrimSpace(**gajiCurrency) == "" {
+            *gajiCurrency = &currency
+        }
+    }
+
+    if (*gajiMin != nil && **gajiMin < 0) || (*gajiMax != nil && **gajiMax < 0) {
+        return utils.BadRequest("Gaji tidak boleh bernilai negatif")
+    }
+    if *gajiMin != nil && *gajiMax != nil && **gajiMax < **gajiMin {
+        return utils.BadRequest("Gaji maksimum tidak boleh lebih kecil dari gaji minimum")
+    }
+
+    currency := utils.DefaultGajiCurrency
+    if *gajiCurrency != nil && strings.TrimSpace(**gajiCurrency) != "" {
+        currency = strings.ToUpper(strings.TrimSpace(**gajiCurrency))
+    }
+    if !utils.IsValidGajiCurrency(currency) {
+        return utils.BadRequest("Mata uang gaji harus kode 3 huruf, contoh: IDR")
+    }
+    *gajiCurrency = &currency
+    *gajiRange = utils.FormatGajiRange(*gajiMin, *gajiMax, currency)
+    return nil
+}
+
+// pekerjaanDateError - kesalahan validasi pada field tanggal_selesai_kerja
+func pekerjaanDateError(message string, args ...interface{}) error {
+    return utils.NewValidationError(utils.FieldError{Field: "tanggal_selesai_kerja", Rule: "pekerjaan_dates", Message: message, Args: args})
+}
+
+// validatePekerjaanDates - tanggal selesai harus setelah tanggal mulai dan sesuai status:
+// pekerjaan aktif tidak boleh punya tanggal selesai (diakhiri lewat POST /pekerjaan/:id/end),
+// selesai/resigned wajib punya tanggal selesai yang tidak di masa depan
+func validatePekerjaanDates(status string, mulai time.Time, selesai *time.Time, now time.Time) error {
+    if selesai != nil && !selesai.After(mulai) {
+        return pekerjaanDateError("Tanggal selesai kerja harus setelah tanggal mulai kerja")
+    }
+
+    today := careerDay(now)
+    switch status {
+    case models.StatusPekerjaanAktif:
+        if selesai != nil {
+            return pekerjaanDateError("Pekerjaan berstatus aktif tidak boleh memiliki tanggal selesai kerja")
+        }
+    case models.StatusPekerjaanSelesai, models.StatusPekerjaanResigned:
+        if selesai == nil {
+            return pekerjaanDateError("Tanggal selesai kerja wajib diisi untuk status %s", status)
+        }
+        if careerDay(*selesai).After(today) {
+            return pekerjaanDateError("Tanggal selesai kerja untuk status %s tidak boleh di masa depan", status)
+        }
+    }
+    return nil
+}
+
+// EndPekerjaan - handle POST /pekerjaan/:id/end (akhiri pekerjaan aktif, default status selesai per hari ini)
+func (s *pekerjaanService) EndPekerjaan(c *fiber.Ctx) error {
+    id, err := strconv.Atoi(c.Params("id"))
+    if err != nil {
+        return utils.BadRequest("ID tidak valid").WithError(err)
+    }
+
+    var req models.EndPekerjaanRequest
+    if len(c.Body()) > 0 {
+        if err := bindRequest(c, &req); err != nil {
+            return err
+        }
+    }
+    if req.StatusPekerjaan == "" {
+        req.StatusPekerjaan = models.StatusPekerjaanSelesai
+    }
+    tanggalSelesai := careerDay(time.Now())
+    if req.TanggalSelesaiKerja != nil {
+        tanggalSelesai = *req.TanggalSelesaiKerja
+    }
+
+    existingPekerjaan, err := s.pekerjaanRepo.GetByID(id)
+    if err != nil {
+        return utils.Internal("Gagal memeriksa data pekerjaan", err)
+    }
+    if existingPekerjaan == nil {
+        return utils.NotFound("Pekerjaan tidak ditemukan")
+    }
+    if existingPekerjaan.StatusPekerjaan != models.StatusPekerjaanAktif {
+        return utils.Conflict("Hanya pekerjaan berstatus aktif yang bisa diakhiri").WithCode("PEKERJAAN_NOT_ACTIVE")
+    }
+
+    if err := validatePekerjaanDates(req.StatusPekerjaan, existingPekerjaan.TanggalMulaiKerja, &tanggalSelesai, time.Now()); err != nil {
+        return err
+    }
+
+    pekerjaan, err := s.pekerjaanRepo.EndJob(id, req.StatusPekerjaan, tanggalSelesai)
+    if err != nil {
+        return utils.Internal("Gagal mengakhiri pekerjaan", err)
+    }
+    if pekerjaan == nil {
+        // Diakhiri/dihapus request lain di antara GetByID dan EndJob
+        return utils.Conflict("Pekerjaan sudah tidak aktif").WithCode("PEKERJAAN_NOT_ACTIVE")
+    }
+
+    s.auditService.Record(c, AuditActionUpdate, AuditEntityPekerjaan, id, existingPekerjaan, pekerjaan)
+    s.webhookService.Publish(models.WebhookEventPekerjaanUpdated, pekerjaan)
+
+    return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Pekerjaan berhasil diakhiri"), "data": pekerjaan})
+}
+
+// canTransitionPekerjaan - cek perubahan status berdasarkan pekerjaanStatusTransitions
+func canTransitionPekerjaan(from, to string) bool {
+    if from == to {
+        return true
+    }
+    return containsString(pekerjaanStatusTransitions[from], to)
+}
+
+// activePekerjaanConflict - 409 ACTIVE_PEKERJAAN_EXISTS beserta ID pekerjaan aktif yang bentrok
+func activePekerjaanConflict(message string, activeIDs []int) error {
+    return utils.Conflict(message).WithCode("ACTIVE_PEKERJAAN_EXISTS").WithDetail("active_pekerjaan_ids", activeIDs)
+}
+
+// conflictingActiveJobs - ID pekerjaan aktif lain milik alumni yang bentrok dengan aturan satu pekerjaan aktif;
+// selalu kosong jika PEKERJAAN_ALLOW_MULTIPLE_ACTIVE=true
+func (s *pekerjaanService) conflictingActiveJobs(alumniID, excludeID int) ([]int, error) {
+    if s.allowMultipleActive {
+        return nil, nil
+    }
+    return s.pekerjaanRepo.GetActiveIDsByAlumniID(alumniID, excludeID)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakePekerjaanRepo - satu pekerjaan di memori; method lain tidak dipakai tes (panic jika terpanggil)
+type fakePekerjaanRepo struct {
+	repositories.PekerjaanRepository
+	pekerjaan *models.PekerjaanAlumni
+	changes   map[string]interface{}
+}
+
+func (r *fakePekerjaanRepo) GetByID(id int) (*models.PekerjaanAlumni, error) {
+	if r.pekerjaan == nil || r.pekerjaan.ID != id {
+		return nil, nil
+	}
+	pekerjaan := *r.pekerjaan
+	return &pekerjaan, nil
+}
+
+func (r *fakePekerjaanRepo) Patch(id int, changes map[string]interface{}, updatedAt time.Time) (*models.PekerjaanAlumni, error) {
+	if !updatedAt.Equal(r.pekerjaan.UpdatedAt) {
+		return nil, repositories.ErrStaleRecord
+	}
+	r.changes = changes
+	patched := *r.pekerjaan
+	for column, value := range changes {
+		switch column {
+		case "gaji_range":
+			patched.GajiRange = value.(*string)
+		case "gaji_min":
+			patched.GajiMin = value.(*int64)
+		case "gaji_max":
+			patched.GajiMax = value.(*int64)
+		case "gaji_currency":
+			patched.GajiCurrency = value.(*string)
+		}
+	}
+	patched.UpdatedAt = updatedAt.Add(time.Second)
+	return &patched, nil
+}
+
+// nopWebhookService - WebhookService yang tidak mengantre event
+type nopWebhookService struct{ WebhookService }
+
+func (nopWebhookService) Publish(string, interface{}) {}
+
+func newPatchTestApp(repo *fakePekerjaanRepo) *fiber.App {
+	service := NewPekerjaanService(repo, nil, nil, nopAuditService{}, nil, nopWebhookService{})
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		var appErr *utils.AppError
+		if errors.As(err, &appErr) {
+			return c.Status(appErr.Status).JSON(fiber.Map{"code": appErr.Code})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"code": err.Error()})
+	}})
+	app.Patch("/pekerjaan/:id", service.PatchPekerjaan)
+	return app
+}
+
+func testPekerjaanWithGaji() *models.PekerjaanAlumni {
+	min, max := int64(5000000), int64(10000000)
+	return &models.PekerjaanAlumni{
+		ID:                7,
+		AlumniID:          1,
+		NamaPerusahaan:    "PT Maju",
+		PosisiJabatan:     "Backend Engineer",
+		BidangIndustri:    "Teknologi",
+		LokasiKerja:       "Jakarta",
+		GajiRange:         utils.FormatGajiRange(&min, &max, "IDR"),
+		GajiMin:           &min,
+		GajiMax:           &max,
+		GajiCurrency:      strPtr("IDR"),
+		TanggalMulaiKerja: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		StatusPekerjaan:   models.StatusPekerjaanAktif,
+		UpdatedAt:         time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func patchPekerjaan(t *testing.T, app *fiber.App, repo *fakePekerjaanRepo, body string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPatch, "/pekerjaan/7", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderIfMatch, recordETag(repo.pekerjaan.ID, repo.pekerjaan.UpdatedAt))
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestPatchPekerjaanClearsGaji(t *testing.T) {
+	repo := &fakePekerjaanRepo{pekerjaan: testPekerjaanWithGaji()}
+	app := newPatchTestApp(repo)
+
+	if status := patchPekerjaan(t, app, repo, `{"gaji_min": null, "gaji_max": null}`); status != fiber.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	for _, column := range []string{"gaji_range", "gaji_min", "gaji_max", "gaji_currency"} {
+		value, ok := repo.changes[column]
+		if !ok {
+			t.Errorf("%s not written", column)
+			continue
+		}
+		switch v := value.(type) {
+		case *string:
+			if v != nil {
+				t.Errorf("%s = %q, want null", column, *v)
+			}
+		case *int64:
+			if v != nil {
+				t.Errorf("%s = %d, want null", column, *v)
+			}
+		}
+	}
+}
+
+func TestPatchPekerjaanChangesOneGajiBound(t *testing.T) {
+	repo := &fakePekerjaanRepo{pekerjaan: testPekerjaanWithGaji()}
+	app := newPatchTestApp(repo)
+
+	if status := patchPekerjaan(t, app, repo, `{"gaji_max": 12000000}`); status != fiber.StatusOK {
+		t.Fatalf("status = %d", status)
+	}
+	if max, ok := repo.changes["gaji_max"].(*int64); !ok || max == nil || *max != 12000000 {
+		t.Errorf("gaji_max = %v", repo.changes["gaji_max"])
+	}
+	if label, ok := repo.changes["gaji_range"].(*string); !ok || label == nil || *label != "IDR 5.000.000 - 12.000.000" {
+		t.Errorf("gaji_range = %v", repo.changes["gaji_range"])
+	}
+	if _, ok := repo.changes["gaji_min"]; ok {
+		t.Error("gaji_min must stay unchanged")
+	}
+}
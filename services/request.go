@@ -0,0 +1,133 @@
+package services
+
+import (
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// bindRequest - parse body ke req lalu jalankan tag validate. Body yang tidak bisa diparse menjadi
+// BadRequest, field yang tidak valid menjadi *utils.ValidationError (422 lewat middleware.ErrorHandler).
+func bindRequest(c *fiber.Ctx, req interface{}) error {
+	if err := c.BodyParser(req); err != nil {
+		return utils.BadRequest("Request body tidak valid").WithError(err)
+	}
+	return utils.ValidateStruct(req)
+}
+
+// queryList - nilai query multi-value: ?jurusan=TI&jurusan=SI atau ?jurusan=TI,SI
+func queryList(c *fiber.Ctx, name string) []string {
+	var values []string
+	for _, raw := range c.Context().QueryArgs().PeekMulti(name) {
+		for _, value := range strings.Split(string(raw), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// queryIntRange - parameter <name>_from dan <name>_to; parameter <name> saja berarti from = to
+func queryIntRange(c *fiber.Ctx, name string) (from, to *int, err error) {
+	parse := func(param string) (*int, error) {
+		value := c.Query(param)
+		if value == "" {
+			return nil, nil
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, utils.BadRequest("Parameter %s harus berupa angka", param)
+		}
+		return &number, nil
+	}
+
+	if from, err = parse(name + "_from"); err != nil {
+		return nil, nil, err
+	}
+	if to, err = parse(name + "_to"); err != nil {
+		return nil, nil, err
+	}
+	exact, err := parse(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exact != nil {
+		from, to = exact, exact
+	}
+	if from != nil && to != nil && *to < *from {
+		return nil, nil, utils.BadRequest("Parameter %s tidak boleh lebih kecil dari %s", name+"_to", name+"_from")
+	}
+	return from, to, nil
+}
+
+// queryTimeRange - parameter <prefix>_from dan <prefix>_to (RFC3339 atau YYYY-MM-DD, _to inklusif sampai akhir hari)
+func queryTimeRange(c *fiber.Ctx, prefix string) (from, to *time.Time, err error) {
+	for _, bound := range []struct {
+		param    string
+		target   **time.Time
+		endOfDay bool
+	}{{prefix + "_from", &from, false}, {prefix + "_to", &to, true}} {
+		value := c.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseTimeQuery(value, bound.endOfDay)
+		if err != nil {
+			return nil, nil, utils.BadRequest("Format tanggal %s tidak valid (RFC3339 atau YYYY-MM-DD)", bound.param).WithError(err)
+		}
+		*bound.target = &parsed
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, nil, utils.BadRequest("Parameter %s tidak boleh lebih kecil dari %s", prefix+"_to", prefix+"_from")
+	}
+	return from, to, nil
+}
+
+// queryOptionalBool - nil jika parameter tidak diisi
+func queryOptionalBool(c *fiber.Ctx, name string) (*bool, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, utils.BadRequest("Parameter %s harus berupa true atau false", name)
+	}
+	return &parsed, nil
+}
+
+// querySort - sortBy (alias: sort) dan order untuk endpoint list. Kolom di luar whitelist menjadi id;
+// sort=relevance hanya berlaku bila ada search dan default order-nya desc (skor tertinggi dulu).
+func querySort(c *fiber.Ctx, whitelist map[string]bool, search string) (sortBy, order string) {
+	sortBy = c.Query("sortBy", c.Query("sort", "id"))
+	if sortBy == repositories.SortRelevance {
+		if search == "" {
+			sortBy = "id"
+		}
+	} else if !whitelist[sortBy] {
+		sortBy = "id"
+	}
+
+	order = strings.ToLower(c.Query("order"))
+	if order == "" && sortBy == repositories.SortRelevance {
+		order = "desc"
+	}
+	if order != "desc" {
+		order = "asc"
+	}
+	return sortBy, order
+}
+
+// filterMeta - filter untuk MetaInfo.Filters; nil (tidak ditampilkan) jika tidak ada filter yang aktif
+func filterMeta(filter interface{}) interface{} {
+	if reflect.ValueOf(filter).IsZero() {
+		return nil
+	}
+	return filter
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatsService interface {
+	GetEmploymentRate(c *fiber.Ctx) error // GET /stats/employment-rate
+	GetTimeToFirstJob(c *fiber.Ctx) error // GET /stats/time-to-first-job
+	GetDistribution(c *fiber.Ctx) error   // GET /stats/distribution
+	GetActiveShare(c *fiber.Ctx) error    // GET /stats/active
+	GetSalary(c *fiber.Ctx) error         // GET /stats/salary
+}
+
+type statsService struct {
+	statsRepo repositories.StatsRepository
+}
+
+func NewStatsService(statsRepo repositories.StatsRepository) StatsService {
+	return &statsService{statsRepo: statsRepo}
+}
+
+// GetEmploymentRate - handle GET /stats/employment-rate?group_by=angkatan|jurusan|tahun_lulus
+func (s *statsService) GetEmploymentRate(c *fiber.Ctx) error {
+	filter, groupBy, err := parseStatsQuery(c)
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.statsRepo.GetEmploymentRate(groupBy, filter)
+	if err != nil {
+		return utils.Internal("Gagal mengambil statistik tingkat keterserapan kerja", err)
+	}
+
+	if wantsCSV(c) {
+		records := [][]string{{"group", "total_alumni", "employed", "currently_active", "employment_rate", "active_rate"}}
+		for _, stat := range stats {
+			records = append(records, []string{
+				stat.Group, strconv.Itoa(stat.TotalAlumni), strconv.Itoa(stat.Employed),
+				strconv.Itoa(stat.CurrentlyActive), formatStat(stat.EmploymentRate), formatStat(stat.ActiveRate),
+			})
+		}
+		return sendCSV(c, "employment-rate.csv", records)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Statistik tingkat keterserapan kerja berhasil diambil"), "data": stats})
+}
+
+// GetTimeToFirstJob - handle GET /stats/time-to-first-job?group_by=angkatan|jurusan|tahun_lulus
+func (s *statsService) GetTimeToFirstJob(c *fiber.Ctx) error {
+	filter, groupBy, err := parseStatsQuery(c)
+	if err != nil {
+		return err
+	}
+
+	stats, err := s.statsRepo.GetTimeToFirstJob(groupBy, filter)
+	if err != nil {
+		return utils.Internal("Gagal mengambil statistik waktu tunggu kerja pertama", err)
+	}
+
+	if wantsCSV(c) {
+		records := [][]string{{"group", "alumni", "avg_months", "median_months", "min_months", "max_months", "before_graduation"}}
+		for _, stat := range stats {
+			records = append(records, []string{
+				stat.Group, strconv.Itoa(stat.Alumni), formatStat(stat.AvgMonths), formatStat(stat.MedianMonths),
+				formatStat(stat.MinMonths), formatStat(stat.MaxMonths), strconv.Itoa(stat.BeforeGraduation),
+			})
+		}
+		return sendCSV(c, "time-to-first-job.csv", records)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Statistik waktu tunggu kerja pertama berhasil diambil"), "data": stats})
+}
+
+// GetDistribution - handle GET /stats/distribution?by=bidang_industri|lokasi_kerja&only_active=true
+func (s *statsService) GetDistribution(c *fiber.Ctx) error {
+	filter, _, err := parseStatsQuery(c)
+	if err != nil {
+		return err
+	}
+
+	by := c.Query("by", "bidang_industri")
+	if !repositories.IsValidStatsDistribution(by) {
+		return utils.BadRequest("Parameter by harus salah satu dari: bidang_industri, lokasi_kerja")
+	}
+	onlyActive := c.QueryBool("only_active", false)
+
+	stats, err := s.statsRepo.GetDistribution(by, onlyActive, filter)
+	if err != nil {
+		return utils.Internal("Gagal mengambil statistik distribusi", err)
+	}
+
+	if wantsCSV(c) {
+		records := [][]string{{by, "jobs", "alumni", "share"}}
+		for _, stat := range stats {
+			records = append(records, []string{stat.Value, strconv.Itoa(stat.Jobs), strconv.Itoa(stat.Alumni), formatStat(stat.Share)})
+		}
+		return sendCSV(c, "distribution-"+by+".csv", records)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Statistik sebaran pekerjaan berhasil diambil"), "data": stats})
+}
+
+// GetActiveShare - handle GET /stats/active
+func (s *statsService) GetActiveShare(c *fiber.Ctx) error {
+	filter, _, err := parseStatsQuery(c)
+	if err != nil {
+		return err
+	}
+
+	stat, err := s.statsRepo.GetActiveShare(filter)
+	if err != nil {
+		return utils.Internal("Gagal mengambil statistik pekerjaan aktif", err)
+	}
+
+	if wantsCSV(c) {
+		return sendCSV(c, "active-share.csv", [][]string{
+			{"total_alumni", "currently_active", "share"},
+			{strconv.Itoa(stat.TotalAlumni), strconv.Itoa(stat.CurrentlyActive), formatStat(stat.Share)},
+		})
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Statistik alumni yang sedang bekerja berhasil diambil"), "data": stat})
+}
+
+// GetSalary - handle GET /stats/salary?group_by=jurusan|bidang_industri|angkatan|tahun_lulus&currency=IDR&only_active=true
+func (s *statsService) GetSalary(c *fiber.Ctx) error {
+	filter, err := parseStatsFilter(c)
+	if err != nil {
+		return err
+	}
+
+	groupBy := c.Query("group_by")
+	if !repositories.IsValidSalaryGroup(groupBy) {
+		return utils.BadRequest("Parameter group_by harus salah satu dari: angkatan, jurusan, tahun_lulus, bidang_industri")
+	}
+	currency := strings.ToUpper(c.Query("currency", utils.DefaultGajiCurrency))
+	if !utils.IsValidGajiCurrency(currency) {
+		return utils.BadRequest("Parameter currency harus kode mata uang 3 huruf, contoh: IDR")
+	}
+	onlyActive := c.QueryBool("only_active", false)
+
+	stats, err := s.statsRepo.GetSalary(groupBy, currency, onlyActive, filter)
+	if err != nil {
+		return utils.Internal("Gagal mengambil statistik gaji", err)
+	}
+
+	if wantsCSV(c) {
+		records := [][]string{{"group", "currency", "jobs", "alumni", "min", "p25", "median", "p75", "p90", "max", "avg"}}
+		for _, stat := range stats {
+			records = append(records, []string{
+				stat.Group, stat.Currency, strconv.Itoa(stat.Jobs), strconv.Itoa(stat.Alumni),
+				formatStat(stat.Min), formatStat(stat.P25), formatStat(stat.Median), formatStat(stat.P75),
+				formatStat(stat.P90), formatStat(stat.Max), formatStat(stat.Avg),
+			})
+		}
+		return sendCSV(c, "salary.csv", records)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Statistik gaji berhasil diambil"), "data": stats})
+}
+
+// parseStatsQuery - filter umum ditambah group_by kolom alumni
+func parseStatsQuery(c *fiber.Ctx) (models.StatsFilter, string, error) {
+	filter, err := parseStatsFilter(c)
+	if err != nil {
+		return filter, "", err
+	}
+
+	groupBy := c.Query("group_by")
+	if !repositories.IsValidStatsGroup(groupBy) {
+		return filter, "", utils.BadRequest("Parameter group_by harus salah satu dari: angkatan, jurusan, tahun_lulus")
+	}
+
+	return filter, groupBy, nil
+}
+
+// parseStatsFilter - filter umum: jurusan, angkatan, tahun_lulus, tahun_lulus_from, tahun_lulus_to
+func parseStatsFilter(c *fiber.Ctx) (models.StatsFilter, error) {
+	filter := models.StatsFilter{Jurusan: c.Query("jurusan")}
+
+	intParams := map[string]**int{
+		"angkatan":         &filter.Angkatan,
+		"tahun_lulus":      &filter.TahunLulus,
+		"tahun_lulus_from": &filter.TahunLulusFrom,
+		"tahun_lulus_to":   &filter.TahunLulusTo,
+	}
+	for name, target := range intParams {
+		if value := c.Query(name); value != "" {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return filter, utils.BadRequest("Parameter %s harus berupa angka", name)
+			}
+			*target = &number
+		}
+	}
+
+	return filter, nil
+}
+
+func wantsCSV(c *fiber.Ctx) bool {
+	return c.Query("format") == "csv"
+}
+
+// sendCSV - kirim records (baris pertama header) sebagai file CSV
+func sendCSV(c *fiber.Ctx, filename string, records [][]string) error {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return utils.Internal("Gagal membuat file CSV", err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.Send(buf.Bytes())
+}
+
+func formatStat(value float64) string {
+	return strconv.FormatFloat(value, 'f', 4, 64)
+}
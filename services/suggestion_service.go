@@ -0,0 +1,57 @@
+package services
+
+import (
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Batas jumlah saran per request
+const (
+	defaultSuggestionLimit = 10
+	maxSuggestionLimit     = 50
+)
+
+type SuggestionService interface {
+	GetSuggestions(c *fiber.Ctx) error // GET /suggestions/:field?q=&limit=
+}
+
+type suggestionService struct {
+	suggestionRepo repositories.SuggestionRepository
+}
+
+func NewSuggestionService(suggestionRepo repositories.SuggestionRepository) SuggestionService {
+	return &suggestionService{suggestionRepo: suggestionRepo}
+}
+
+// GetSuggestions - handle GET /suggestions/:field (nilai yang sudah dipakai, diurutkan dari yang paling sering)
+func (s *suggestionService) GetSuggestions(c *fiber.Ctx) error {
+	field := c.Params("field")
+	valid := false
+	for _, name := range models.SuggestionFields {
+		valid = valid || name == field
+	}
+	if !valid {
+		return utils.BadRequest("%s harus salah satu dari: %s", "field", strings.Join(models.SuggestionFields, ", "))
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultSuggestionLimit)))
+	if limit < 1 {
+		limit = defaultSuggestionLimit
+	}
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	suggestions, err := s.suggestionRepo.Suggest(field, c.Query("q"), limit)
+	if err != nil {
+		return utils.Internal("Gagal mengambil saran", err)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Saran berhasil diambil"), "data": suggestions})
+}
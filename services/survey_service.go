@@ -0,0 +1,732 @@
+package services
+
+import (
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Entity audit untuk modul survey
+const (
+	AuditEntitySurvey         = "survey"
+	AuditEntitySurveyResponse = "survey_response"
+)
+
+type SurveyService interface {
+	// Admin
+	GetAllSurveys(c *fiber.Ctx) error         // GET /surveys
+	CreateSurvey(c *fiber.Ctx) error          // POST /surveys
+	UpdateSurvey(c *fiber.Ctx) error          // PUT /surveys/:id (hanya draft)
+	DeleteSurvey(c *fiber.Ctx) error          // DELETE /surveys/:id (hanya draft)
+	OpenSurvey(c *fiber.Ctx) error            // POST /surveys/:id/open
+	CloseSurvey(c *fiber.Ctx) error           // POST /surveys/:id/close
+	GetSurveyCompletion(c *fiber.Ctx) error   // GET /surveys/:id/completion
+	ExportSurveyResponses(c *fiber.Ctx) error // GET /surveys/:id/export?format=csv|json
+
+	// Admin dan alumni
+	GetSurveyByID(c *fiber.Ctx) error // GET /surveys/:id
+
+	// Alumni (user yang terhubung ke baris alumni)
+	GetAvailableSurveys(c *fiber.Ctx) error  // GET /surveys/available
+	GetMySurveyResponse(c *fiber.Ctx) error  // GET /surveys/:id/response
+	SaveMySurveyResponse(c *fiber.Ctx) error // PUT /surveys/:id/response
+}
+
+type surveyService struct {
+	surveyRepo   repositories.SurveyRepository
+	alumniRepo   repositories.AlumniRepository
+	auditService AuditService
+}
+
+func NewSurveyService(surveyRepo repositories.SurveyRepository, alumniRepo repositories.AlumniRepository, auditService AuditService) SurveyService {
+	return &surveyService{
+		surveyRepo:   surveyRepo,
+		alumniRepo:   alumniRepo,
+		auditService: auditService,
+	}
+}
+
+// GetAllSurveys - handle GET /surveys (opsional ?status=draft|open|closed)
+func (s *surveyService) GetAllSurveys(c *fiber.Ctx) error {
+	surveys, err := s.surveyRepo.GetAll(c.Query("status"))
+	if err != nil {
+		return utils.Internal("Gagal mengambil data survey", err)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data survey berhasil diambil"), "data": surveys})
+}
+
+// GetSurveyByID - handle GET /surveys/:id (user hanya bisa melihat survey yang dibuka untuknya)
+func (s *surveyService) GetSurveyByID(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	if role, _ := c.Locals("role").(string); role != "admin" {
+		alumni, err := s.currentAlumni(c)
+		if err != nil {
+			return err
+		}
+		eligible, err := s.isEligible(id, alumni)
+		if err != nil {
+			return utils.Internal("Gagal memeriksa data survey", err)
+		}
+		if !eligible {
+			return utils.NotFound("Survey tidak ditemukan atau tidak dibuka untuk Anda")
+		}
+	}
+
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data survey", err)
+	}
+	if survey == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data survey berhasil diambil"), "data": survey})
+}
+
+// CreateSurvey - handle POST /surveys (status awal draft)
+func (s *surveyService) CreateSurvey(c *fiber.Ctx) error {
+	var req models.CreateSurveyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := validateSurveyDefinition(&req); len(errs) > 0 {
+		return &utils.ValidationError{Message: "Definisi survey tidak valid", Errors: errs}
+	}
+
+	var createdBy *int
+	if userID, ok := c.Locals("user_id").(int); ok {
+		createdBy = &userID
+	}
+
+	survey, err := s.surveyRepo.Create(&req, createdBy)
+	if err != nil {
+		return utils.Internal("Gagal membuat data survey", err)
+	}
+
+	s.auditService.Record(c, AuditActionCreate, AuditEntitySurvey, survey.ID, nil, survey)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Survey berhasil dibuat"), "data": survey})
+}
+
+// UpdateSurvey - handle PUT /surveys/:id (pertanyaan tidak boleh berubah setelah survey dibuka)
+func (s *surveyService) UpdateSurvey(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	var req models.UpdateSurveyRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	if errs := validateSurveyDefinition(&req); len(errs) > 0 {
+		return &utils.ValidationError{Message: "Definisi survey tidak valid", Errors: errs}
+	}
+
+	existing, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data survey", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+	if existing.Status != models.SurveyStatusDraft {
+		return utils.Conflict("Hanya survey berstatus draft yang bisa diubah")
+	}
+
+	survey, err := s.surveyRepo.Update(id, &req)
+	if err != nil {
+		return utils.Internal("Gagal memperbarui data survey", err)
+	}
+
+	s.auditService.Record(c, AuditActionUpdate, AuditEntitySurvey, id, existing, survey)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Survey berhasil diupdate"), "data": survey})
+}
+
+// DeleteSurvey - handle DELETE /surveys/:id
+func (s *surveyService) DeleteSurvey(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	existing, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data survey", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+	// Survey yang pernah dibuka mungkin sudah punya jawaban - cukup ditutup
+	if existing.Status != models.SurveyStatusDraft {
+		return utils.Conflict("Survey yang sudah dibuka tidak bisa dihapus, tutup survey sebagai gantinya")
+	}
+
+	if err := s.surveyRepo.Delete(id); err != nil {
+		return utils.Internal("Gagal menghapus data survey", err)
+	}
+
+	s.auditService.Record(c, AuditActionDelete, AuditEntitySurvey, id, existing, nil)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Survey berhasil dihapus")})
+}
+
+// OpenSurvey - handle POST /surveys/:id/open
+func (s *surveyService) OpenSurvey(c *fiber.Ctx) error {
+	return s.changeStatus(c, models.SurveyStatusOpen)
+}
+
+// CloseSurvey - handle POST /surveys/:id/close
+func (s *surveyService) CloseSurvey(c *fiber.Ctx) error {
+	return s.changeStatus(c, models.SurveyStatusClosed)
+}
+
+func (s *surveyService) changeStatus(c *fiber.Ctx, status string) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	existing, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data survey", err)
+	}
+	if existing == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+	if existing.Status == status {
+		return utils.Conflict("Status survey sudah %s", status)
+	}
+	if status == models.SurveyStatusClosed && existing.Status != models.SurveyStatusOpen {
+		return utils.Conflict("Hanya survey yang sedang dibuka yang bisa ditutup")
+	}
+	if status == models.SurveyStatusOpen && len(existing.Questions) == 0 {
+		return utils.Conflict("Survey tanpa pertanyaan tidak bisa dibuka")
+	}
+
+	if err := s.surveyRepo.UpdateStatus(id, status); err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NotFound("Survey tidak ditemukan")
+		}
+		return utils.Internal("Gagal memperbarui status survey", err)
+	}
+
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data survey", err)
+	}
+
+	s.auditService.Record(c, AuditActionUpdate, AuditEntitySurvey, id, existing, survey)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Status survey berhasil diubah menjadi %s", status), "data": survey})
+}
+
+// GetSurveyCompletion - handle GET /surveys/:id/completion
+func (s *surveyService) GetSurveyCompletion(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data survey", err)
+	}
+	if survey == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+
+	completion, err := s.surveyRepo.GetCompletion(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil progres pengisian survey", err)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Progres survey berhasil diambil"), "data": completion})
+}
+
+// ExportSurveyResponses - handle GET /surveys/:id/export?format=csv|json&status=submitted|in_progress
+func (s *surveyService) ExportSurveyResponses(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	status := c.Query("status")
+	if status != "" && status != models.SurveyResponseSubmitted && status != models.SurveyResponseInProgress {
+		return utils.BadRequest("Status harus salah satu dari: submitted, in_progress")
+	}
+
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data survey", err)
+	}
+	if survey == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+
+	responses, err := s.surveyRepo.GetResponsesForExport(id, status)
+	if err != nil {
+		return utils.Internal("Gagal mengambil jawaban survey", err)
+	}
+
+	if c.Query("format", "csv") == "json" {
+		return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Jawaban survey berhasil diambil"), "data": fiber.Map{"survey": survey, "responses": responses}})
+	}
+
+	header := []string{"response_id", "alumni_id", "nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "status", "submitted_at"}
+	for _, q := range survey.Questions {
+		header = append(header, q.Kode)
+	}
+	records := [][]string{header}
+
+	for _, response := range responses {
+		submittedAt := ""
+		if response.SubmittedAt != nil {
+			submittedAt = response.SubmittedAt.Format(time.RFC3339)
+		}
+		record := []string{
+			strconv.Itoa(response.ID), strconv.Itoa(response.AlumniID),
+			response.Alumni.NIM, response.Alumni.Nama, response.Alumni.Jurusan,
+			strconv.Itoa(response.Alumni.Angkatan), strconv.Itoa(response.Alumni.TahunLulus),
+			response.Alumni.Email, response.Status, submittedAt,
+		}
+		for _, q := range survey.Questions {
+			record = append(record, answerToCSV(response.Answers[q.Kode]))
+		}
+		records = append(records, record)
+	}
+
+	return sendCSV(c, fmt.Sprintf("survey-%d-responses.csv", id), records)
+}
+
+// GetAvailableSurveys - handle GET /surveys/available (survey yang dibuka untuk angkatan/jurusan alumni)
+func (s *surveyService) GetAvailableSurveys(c *fiber.Ctx) error {
+	alumni, err := s.currentAlumni(c)
+	if err != nil {
+		return err
+	}
+
+	surveys, err := s.surveyRepo.GetOpenForAlumni(alumni.Angkatan, alumni.Jurusan)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data survey", err)
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data survey berhasil diambil"), "data": surveys})
+}
+
+// GetMySurveyResponse - handle GET /surveys/:id/response
+func (s *surveyService) GetMySurveyResponse(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	alumni, err := s.currentAlumni(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.surveyRepo.GetResponse(id, alumni.ID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil jawaban survey", err)
+	}
+	if response == nil {
+		return utils.NotFound("Anda belum mengisi survey ini")
+	}
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Jawaban survey berhasil diambil"), "data": response})
+}
+
+// SaveMySurveyResponse - handle PUT /surveys/:id/response
+// Jawaban digabung dengan jawaban tersimpan (nilai null menghapus jawaban), submit=true mengunci jawaban
+func (s *surveyService) SaveMySurveyResponse(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return utils.BadRequest("ID tidak valid").WithError(err)
+	}
+
+	var req models.SubmitSurveyResponseRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+
+	alumni, err := s.currentAlumni(c)
+	if err != nil {
+		return err
+	}
+
+	eligible, err := s.isEligible(id, alumni)
+	if err != nil {
+		return utils.Internal("Gagal memeriksa data survey", err)
+	}
+	if !eligible {
+		return utils.NotFound("Survey tidak ditemukan atau tidak dibuka untuk Anda")
+	}
+
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return utils.Internal("Gagal mengambil data survey", err)
+	}
+	if survey == nil {
+		return utils.NotFound("Survey tidak ditemukan")
+	}
+
+	existing, err := s.surveyRepo.GetResponse(id, alumni.ID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil jawaban survey", err)
+	}
+	if existing != nil && existing.Status == models.SurveyResponseSubmitted {
+		return utils.Conflict("Jawaban survey sudah dikirim dan tidak bisa diubah")
+	}
+
+	questions := map[string]models.SurveyQuestion{}
+	for _, q := range survey.Questions {
+		questions[q.Kode] = q
+	}
+
+	// Gabungkan jawaban tersimpan dengan jawaban baru
+	answers := map[string]json.RawMessage{}
+	if existing != nil {
+		for kode, value := range existing.Answers {
+			answers[kode] = value
+		}
+	}
+
+	var errs []utils.FieldError
+	for kode, value := range req.Answers {
+		question, ok := questions[kode]
+		if !ok {
+			errs = append(errs, utils.FieldError{Field: kode, Rule: "unknown_question", Message: "Pertanyaan tidak dikenal"})
+			continue
+		}
+		if isEmptyAnswer(value) {
+			delete(answers, kode)
+			continue
+		}
+		normalized, err := normalizeAnswer(question, value)
+		if err != nil {
+			errs = append(errs, utils.NewFieldError(kode, "answer", err))
+			continue
+		}
+		answers[kode] = normalized
+	}
+
+	// Jawaban untuk pertanyaan yang tersembunyi oleh branching dibuang
+	visible := visibleQuestions(survey.Questions, answers)
+	for kode := range answers {
+		if !visible[kode] {
+			delete(answers, kode)
+		}
+	}
+
+	if req.Submit {
+		for _, q := range survey.Questions {
+			if q.Wajib && visible[q.Kode] {
+				if _, ok := answers[q.Kode]; !ok {
+					errs = append(errs, utils.FieldError{Field: q.Kode, Rule: "required", Message: "Pertanyaan wajib diisi"})
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &utils.ValidationError{Message: "Jawaban survey tidak valid", Errors: errs}
+	}
+
+	byQuestionID := map[int]json.RawMessage{}
+	for kode, value := range answers {
+		byQuestionID[questions[kode].ID] = value
+	}
+
+	response, err := s.surveyRepo.SaveResponse(id, alumni.ID, byQuestionID, req.Submit)
+	if err != nil {
+		return utils.Internal("Gagal menyimpan jawaban survey", err)
+	}
+
+	if req.Submit {
+		s.auditService.Record(c, AuditActionCreate, AuditEntitySurveyResponse, response.ID, nil, response)
+		return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Jawaban survey berhasil dikirim"), "data": response})
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Jawaban survey berhasil disimpan"), "data": response})
+}
+
+// currentAlumni - baris alumni milik user yang sedang login
+func (s *surveyService) currentAlumni(c *fiber.Ctx) (*models.Alumni, error) {
+	userID, ok := c.Locals("user_id").(int)
+	if !ok {
+		return nil, utils.Unauthorized("User ID tidak ditemukan di context")
+	}
+	alumni, err := s.alumniRepo.GetAlumniByUserID(userID)
+	if err != nil {
+		return nil, utils.Internal("Gagal mengambil data alumni", err)
+	}
+	if alumni == nil {
+		return nil, utils.Forbidden("Akun Anda belum terhubung dengan data alumni").WithCode("ALUMNI_NOT_LINKED")
+	}
+	return alumni, nil
+}
+
+func (s *surveyService) isEligible(surveyID int, alumni *models.Alumni) (bool, error) {
+	surveys, err := s.surveyRepo.GetOpenForAlumni(alumni.Angkatan, alumni.Jurusan)
+	if err != nil {
+		return false, err
+	}
+	for _, survey := range surveys {
+		if survey.ID == surveyID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// validateSurveyDefinition - cek pertanyaan, opsi dan kondisi branching
+func validateSurveyDefinition(req *models.CreateSurveyRequest) []utils.FieldError {
+	var errs []utils.FieldError
+
+	if strings.TrimSpace(req.Judul) == "" {
+		errs = append(errs, utils.FieldError{Field: "judul", Rule: "required", Message: "Judul survey harus diisi"})
+	}
+	if req.OpensAt != nil && req.ClosesAt != nil && !req.ClosesAt.After(*req.OpensAt) {
+		errs = append(errs, utils.FieldError{Field: "closes_at", Rule: "gtfield", Message: "closes_at harus setelah opens_at"})
+	}
+	if len(req.Questions) == 0 {
+		errs = append(errs, utils.FieldError{Field: "questions", Rule: "min", Message: "Survey minimal memiliki satu pertanyaan"})
+	}
+
+	seen := map[string]models.SurveyQuestionRequest{}
+	for i, q := range req.Questions {
+		field := fmt.Sprintf("questions[%d]", i)
+		if strings.TrimSpace(q.Kode) == "" {
+			errs = append(errs, utils.FieldError{Field: field + ".kode", Rule: "required", Message: "Kode pertanyaan harus diisi"})
+		} else if _, dup := seen[q.Kode]; dup {
+			errs = append(errs, utils.FieldError{Field: field + ".kode", Rule: "unique", Message: "Kode pertanyaan duplikat: %s", Args: []interface{}{q.Kode}})
+		}
+		if strings.TrimSpace(q.Pertanyaan) == "" {
+			errs = append(errs, utils.FieldError{Field: field + ".pertanyaan", Rule: "required", Message: "Teks pertanyaan harus diisi"})
+		}
+
+		switch q.Tipe {
+		case models.QuestionTypeSingleChoice, models.QuestionTypeMultipleChoice:
+			if len(q.Options) < 2 {
+				errs = append(errs, utils.FieldError{Field: field + ".options", Rule: "min", Message: "Pertanyaan pilihan minimal memiliki dua opsi"})
+			}
+		case models.QuestionTypeScale:
+			if q.MinValue == nil || q.MaxValue == nil {
+				errs = append(errs, utils.FieldError{Field: field + ".min_value", Rule: "required", Message: "Pertanyaan skala membutuhkan min_value dan max_value"})
+			}
+		case models.QuestionTypeText, models.QuestionTypeTextarea, models.QuestionTypeNumber, models.QuestionTypeDate:
+		default:
+			errs = append(errs, utils.FieldError{Field: field + ".tipe", Rule: "oneof", Message: "Tipe pertanyaan tidak dikenal: %s", Args: []interface{}{q.Tipe}})
+		}
+		if q.MinValue != nil && q.MaxValue != nil && *q.MinValue > *q.MaxValue {
+			errs = append(errs, utils.FieldError{Field: field + ".max_value", Rule: "gtfield", Message: "max_value harus lebih besar dari min_value"})
+		}
+
+		// Branching hanya boleh merujuk pertanyaan sebelumnya agar tidak ada siklus
+		if q.ShowIf != nil {
+			if _, ok := seen[q.ShowIf.Question]; !ok {
+				errs = append(errs, utils.FieldError{Field: field + ".show_if.question", Rule: "show_if", Message: "show_if harus merujuk kode pertanyaan sebelumnya"})
+			}
+			switch q.ShowIf.Operator {
+			case "answered":
+			case "equals", "not_equals", "in":
+				if len(q.ShowIf.Value) == 0 {
+					errs = append(errs, utils.FieldError{Field: field + ".show_if.value", Rule: "required", Message: "show_if.value harus diisi"})
+				}
+			default:
+				errs = append(errs, utils.FieldError{Field: field + ".show_if.operator", Rule: "oneof", Message: "Operator harus salah satu dari: equals, not_equals, in, answered"})
+			}
+		}
+
+		seen[q.Kode] = q
+	}
+
+	return errs
+}
+
+// visibleQuestions - tentukan pertanyaan yang tampil berdasarkan kondisi show_if (dievaluasi berurutan)
+func visibleQuestions(questions []models.SurveyQuestion, answers map[string]json.RawMessage) map[string]bool {
+	visible := map[string]bool{}
+	for _, q := range questions {
+		if q.ShowIf == nil {
+			visible[q.Kode] = true
+			continue
+		}
+		if !visible[q.ShowIf.Question] {
+			continue
+		}
+		visible[q.Kode] = conditionHolds(q.ShowIf, answers[q.ShowIf.Question])
+	}
+	return visible
+}
+
+func conditionHolds(cond *models.QuestionCondition, answer json.RawMessage) bool {
+	if isEmptyAnswer(answer) {
+		return cond.Operator == "not_equals"
+	}
+
+	var actual interface{}
+	if err := json.Unmarshal(answer, &actual); err != nil {
+		return false
+	}
+	// Jawaban multiple_choice berupa array - cocok jika salah satu pilihan cocok
+	actualValues := []interface{}{actual}
+	if list, ok := actual.([]interface{}); ok {
+		actualValues = list
+	}
+
+	switch cond.Operator {
+	case "answered":
+		return true
+	case "equals", "not_equals":
+		var expected interface{}
+		if err := json.Unmarshal(cond.Value, &expected); err != nil {
+			return false
+		}
+		matched := false
+		for _, v := range actualValues {
+			if reflect.DeepEqual(v, expected) {
+				matched = true
+			}
+		}
+		if cond.Operator == "equals" {
+			return matched
+		}
+		return !matched
+	case "in":
+		var expected []interface{}
+		if err := json.Unmarshal(cond.Value, &expected); err != nil {
+			return false
+		}
+		for _, v := range actualValues {
+			for _, e := range expected {
+				if reflect.DeepEqual(v, e) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isEmptyAnswer(value json.RawMessage) bool {
+	trimmed := strings.TrimSpace(string(value))
+	return trimmed == "" || trimmed == "null" || trimmed == `""` || trimmed == "[]"
+}
+
+// normalizeAnswer - validasi jawaban sesuai tipe pertanyaan, return JSON yang disimpan
+func normalizeAnswer(q models.SurveyQuestion, value json.RawMessage) (json.RawMessage, error) {
+	switch q.Tipe {
+	case models.QuestionTypeText, models.QuestionTypeTextarea:
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			return nil, utils.BadRequest("Jawaban harus berupa teks")
+		}
+		return json.Marshal(strings.TrimSpace(text))
+
+	case models.QuestionTypeNumber, models.QuestionTypeScale:
+		var number float64
+		if err := json.Unmarshal(value, &number); err != nil {
+			return nil, utils.BadRequest("Jawaban harus berupa angka")
+		}
+		if q.Tipe == models.QuestionTypeScale && number != float64(int(number)) {
+			return nil, utils.BadRequest("Jawaban skala harus bilangan bulat")
+		}
+		if q.MinValue != nil && number < *q.MinValue {
+			return nil, utils.BadRequest("Jawaban minimal %v", *q.MinValue)
+		}
+		if q.MaxValue != nil && number > *q.MaxValue {
+			return nil, utils.BadRequest("Jawaban maksimal %v", *q.MaxValue)
+		}
+		return json.Marshal(number)
+
+	case models.QuestionTypeDate:
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			return nil, utils.BadRequest("Jawaban harus berupa tanggal YYYY-MM-DD")
+		}
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, utils.BadRequest("Jawaban harus berupa tanggal YYYY-MM-DD")
+		}
+		return json.Marshal(text)
+
+	case models.QuestionTypeSingleChoice:
+		var choice string
+		if err := json.Unmarshal(value, &choice); err != nil {
+			return nil, utils.BadRequest("Jawaban harus berupa salah satu opsi")
+		}
+		if !containsString(q.Options, choice) {
+			return nil, utils.BadRequest("Opsi tidak valid: %s", choice)
+		}
+		return json.Marshal(choice)
+
+	case models.QuestionTypeMultipleChoice:
+		var choices []string
+		if err := json.Unmarshal(value, &choices); err != nil {
+			return nil, utils.BadRequest("Jawaban harus berupa daftar opsi")
+		}
+		unique := []string{}
+		for _, choice := range choices {
+			if !containsString(q.Options, choice) {
+				return nil, utils.BadRequest("Opsi tidak valid: %s", choice)
+			}
+			if !containsString(unique, choice) {
+				unique = append(unique, choice)
+			}
+		}
+		return json.Marshal(unique)
+	}
+
+	return nil, utils.BadRequest("Tipe pertanyaan tidak dikenal")
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// answerToCSV - teks apa adanya, multiple choice digabung dengan "; "
+func answerToCSV(value json.RawMessage) string {
+	if len(value) == 0 {
+		return ""
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		return string(value)
+	}
+	switch v := decoded.(type) {
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, "; ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,435 @@
+package services
+
+import (
+	"alumni-management-system/i18n"
+	"alumni-management-system/models"
+	"alumni-management-system/repositories"
+	"alumni-management-system/utils"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const AuditEntityWebhook = "webhook"
+
+// Header yang dikirim ke penerima webhook
+const (
+	headerWebhookEvent     = "X-Webhook-Event"
+	headerWebhookID        = "X-Webhook-Id" // event_id, sama untuk pengiriman ulang
+	headerWebhookDelivery  = "X-Webhook-Delivery"
+	headerWebhookTimestamp = "X-Webhook-Timestamp"
+	headerWebhookSignature = "X-Webhook-Signature" // sha256=hex(HMAC-SHA256(secret, timestamp + "." + body))
+)
+
+const (
+	defaultWebhookMaxAttempts = 8 // ubah lewat WEBHOOK_MAX_ATTEMPTS
+	defaultWebhookTimeout     = 10 * time.Second
+	webhookBaseBackoff        = 30 * time.Second // 30s, 1m, 2m, ... maksimal webhookMaxBackoff
+	webhookMaxBackoff         = 6 * time.Hour
+	webhookPollInterval       = 5 * time.Second
+	webhookBatchSize          = 20
+	webhookMaxResponseLength  = 1024
+)
+
+type WebhookService interface {
+	GetWebhooks(c *fiber.Ctx) error          // GET /webhooks
+	GetWebhookByID(c *fiber.Ctx) error       // GET /webhooks/:id
+	CreateWebhook(c *fiber.Ctx) error        // POST /webhooks
+	UpdateWebhook(c *fiber.Ctx) error        // PUT /webhooks/:id
+	DeleteWebhook(c *fiber.Ctx) error        // DELETE /webhooks/:id
+	GetWebhookDeliveries(c *fiber.Ctx) error // GET /webhooks/:id/deliveries
+	RedeliverWebhook(c *fiber.Ctx) error     // POST /webhooks/:id/deliveries/:delivery_id/redeliver
+
+	// Publish - antre event untuk semua subscription aktif yang berlangganan. Gagal mengantre hanya dicatat
+	// di log, sama seperti audit trail, agar perubahan data tidak ikut gagal.
+	Publish(event string, data interface{})
+	// StartDispatcher - jalankan pengiriman delivery di background (dipanggil sekali dari main)
+	StartDispatcher()
+}
+
+type webhookService struct {
+	webhookRepo  repositories.WebhookRepository
+	auditService AuditService
+	client       *http.Client
+	maxAttempts  int
+	wake         chan struct{}
+	dispatching  sync.Mutex
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, auditService AuditService) WebhookService {
+	maxAttempts := defaultWebhookMaxAttempts
+	if value, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS")); err == nil && value > 0 {
+		maxAttempts = value
+	}
+	timeout := defaultWebhookTimeout
+	if seconds, err := strconv.ParseFloat(os.Getenv("WEBHOOK_TIMEOUT_SECONDS"), 64); err == nil && seconds > 0 {
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+	return &webhookService{
+		webhookRepo:  webhookRepo,
+		auditService: auditService,
+		client: &http.Client{
+			Timeout: timeout,
+			// Redirect dianggap gagal: payload bertanda tangan tidak boleh diteruskan ke URL lain
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		maxAttempts: maxAttempts,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// GetWebhooks - handle GET /webhooks
+func (s *webhookService) GetWebhooks(c *fiber.Ctx) error {
+	subscriptions, err := s.webhookRepo.GetAll()
+	if err != nil {
+		return utils.Internal("Gagal mengambil data webhook", err)
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data webhook berhasil diambil"), "data": subscriptions})
+}
+
+// GetWebhookByID - handle GET /webhooks/:id
+func (s *webhookService) GetWebhookByID(c *fiber.Ctx) error {
+	subscription, err := s.getSubscription(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Data webhook berhasil diambil"), "data": subscription})
+}
+
+// CreateWebhook - handle POST /webhooks. Secret dibuat server dan hanya ditampilkan di response ini.
+func (s *webhookService) CreateWebhook(c *fiber.Ctx) error {
+	var req models.CreateWebhookRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+	if err := validateWebhookURL(req.URL); err != nil {
+		return err
+	}
+	req.Events = uniqueStrings(req.Events)
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return utils.Internal("Gagal membuat secret webhook", err)
+	}
+	var createdBy *int
+	if userID, ok := c.Locals("user_id").(int); ok {
+		createdBy = &userID
+	}
+
+	subscription, err := s.webhookRepo.Create(&req, "whsec_"+hex.EncodeToString(secret), createdBy)
+	if err != nil {
+		return utils.Internal("Gagal membuat webhook", err)
+	}
+
+	audited := *subscription
+	audited.Secret = ""
+	s.auditService.Record(c, AuditActionCreate, AuditEntityWebhook, subscription.ID, nil, audited)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Webhook berhasil dibuat"), "data": subscription})
+}
+
+// UpdateWebhook - handle PUT /webhooks/:id. Delivery yang sudah diantre tetap dikirim ke URL terbaru.
+func (s *webhookService) UpdateWebhook(c *fiber.Ctx) error {
+	existing, err := s.getSubscription(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.UpdateWebhookRequest
+	if err := bindRequest(c, &req); err != nil {
+		return err
+	}
+	if err := validateWebhookURL(req.URL); err != nil {
+		return err
+	}
+	req.Events = uniqueStrings(req.Events)
+
+	subscription, err := s.webhookRepo.Update(existing.ID, &req)
+	if err != nil {
+		return utils.Internal("Gagal memperbarui webhook", err)
+	}
+	if subscription == nil {
+		return utils.NotFound("Webhook tidak ditemukan")
+	}
+
+	s.auditService.Record(c, AuditActionUpdate, AuditEntityWebhook, subscription.ID, existing, subscription)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Webhook berhasil diupdate"), "data": subscription})
+}
+
+// DeleteWebhook - handle DELETE /webhooks/:id (log delivery ikut terhapus)
+func (s *webhookService) DeleteWebhook(c *fiber.Ctx) error {
+	existing, err := s.getSubscription(c)
+	if err != nil {
+		return err
+	}
+	if err := s.webhookRepo.Delete(existing.ID); err != nil {
+		return utils.Internal("Gagal menghapus webhook", err)
+	}
+
+	s.auditService.Record(c, AuditActionDelete, AuditEntityWebhook, existing.ID, existing, nil)
+
+	return c.JSON(fiber.Map{"success": true, "message": i18n.T(c, "Webhook berhasil dihapus")})
+}
+
+// GetWebhookDeliveries - handle GET /webhooks/:id/deliveries?status=&page=&limit= (terbaru lebih dulu)
+func (s *webhookService) GetWebhookDeliveries(c *fiber.Ctx) error {
+	subscription, err := s.getSubscription(c)
+	if err != nil {
+		return err
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	status := c.Query("status")
+	if status != "" && !containsString([]string{models.WebhookDeliveryPending, models.WebhookDeliverySuccess, models.WebhookDeliveryFailed}, status) {
+		return utils.BadRequest("Parameter status harus pending, success, atau failed")
+	}
+
+	deliveries, total, err := s.webhookRepo.GetDeliveries(subscription.ID, status, limit, (page-1)*limit)
+	if err != nil {
+		return utils.Internal("Gagal mengambil log delivery webhook", err)
+	}
+
+	return c.JSON(models.WebhookDeliveryResponse{
+		Data: deliveries,
+		Meta: models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: "id",
+			Order:  "desc",
+		},
+	})
+}
+
+// RedeliverWebhook - handle POST /webhooks/:id/deliveries/:delivery_id/redeliver. Payload dan event_id sama
+// dengan delivery asal sehingga penerima bisa mendeteksi duplikat; percobaan dihitung dari awal.
+func (s *webhookService) RedeliverWebhook(c *fiber.Ctx) error {
+	subscription, err := s.getSubscription(c)
+	if err != nil {
+		return err
+	}
+	if !subscription.IsActive {
+		return utils.Conflict("Webhook tidak aktif, aktifkan terlebih dahulu sebelum mengirim ulang").WithCode("WEBHOOK_INACTIVE")
+	}
+	deliveryID, err := strconv.ParseInt(c.Params("delivery_id"), 10, 64)
+	if err != nil {
+		return utils.BadRequest("ID delivery tidak valid").WithError(err)
+	}
+	original, err := s.webhookRepo.GetDelivery(subscription.ID, deliveryID)
+	if err != nil {
+		return utils.Internal("Gagal mengambil delivery webhook", err)
+	}
+	if original == nil {
+		return utils.NotFound("Delivery webhook tidak ditemukan")
+	}
+
+	delivery, err := s.webhookRepo.Redeliver(original.ID)
+	if err != nil {
+		return utils.Internal("Gagal mengantre ulang delivery webhook", err)
+	}
+	s.notify()
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"success": true, "message": i18n.T(c, "Delivery webhook diantre untuk dikirim ulang"), "data": delivery})
+}
+
+func (s *webhookService) getSubscription(c *fiber.Ctx) (*models.WebhookSubscription, error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, utils.BadRequest("ID tidak valid").WithError(err)
+	}
+	subscription, err := s.webhookRepo.GetByID(id)
+	if err != nil {
+		return nil, utils.Internal("Gagal mengambil data webhook", err)
+	}
+	if subscription == nil {
+		return nil, utils.NotFound("Webhook tidak ditemukan")
+	}
+	return subscription, nil
+}
+
+// validateWebhookURL - hanya URL absolut http/https
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return utils.NewValidationError(utils.FieldError{Field: "url", Rule: "url", Message: "%s harus berupa URL http atau https", Args: []interface{}{"url"}})
+	}
+	return nil
+}
+
+func (s *webhookService) Publish(event string, data interface{}) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		log.Printf("[WEBHOOK] gagal membuat event %s: %v", event, err)
+		return
+	}
+	payload, err := json.Marshal(models.WebhookPayload{ID: "evt_" + hex.EncodeToString(id), Event: event, CreatedAt: time.Now(), Data: data})
+	if err != nil {
+		log.Printf("[WEBHOOK] gagal membuat payload %s: %v", event, err)
+		return
+	}
+
+	queued, err := s.webhookRepo.CreateDeliveries(event, "evt_"+hex.EncodeToString(id), payload)
+	if err != nil {
+		log.Printf("[WEBHOOK] gagal mengantre event %s: %v", event, err)
+		return
+	}
+	if queued > 0 {
+		s.notify()
+	}
+}
+
+// notify - bangunkan dispatcher tanpa menunggu (cukup satu sinyal tertunda)
+func (s *webhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *webhookService) StartDispatcher() {
+	go func() {
+		ticker := time.NewTicker(webhookPollInterval)
+		defer ticker.Stop()
+		for {
+			s.dispatchDue()
+			select {
+			case <-ticker.C:
+			case <-s.wake:
+			}
+		}
+	}()
+}
+
+// dispatchDue - kirim semua delivery yang jatuh tempo, per batch secara paralel
+func (s *webhookService) dispatchDue() {
+	s.dispatching.Lock()
+	defer s.dispatching.Unlock()
+
+	// Lease lebih lama dari timeout HTTP: jika proses mati di tengah pengiriman, delivery diambil ulang
+	lease := s.client.Timeout + time.Minute
+	for {
+		deliveries, err := s.webhookRepo.ClaimDueDeliveries(webhookBatchSize, lease)
+		if err != nil {
+			log.Printf("[WEBHOOK] gagal mengambil delivery: %v", err)
+			return
+		}
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				if err := s.webhookRepo.RecordAttempt(delivery.ID, s.deliver(delivery)); err != nil {
+					log.Printf("[WEBHOOK] gagal menyimpan hasil delivery #%d: %v", delivery.ID, err)
+				}
+			}(&deliveries[i])
+		}
+		wg.Wait()
+		if len(deliveries) < webhookBatchSize {
+			return
+		}
+	}
+}
+
+// deliver - satu percobaan POST ke penerima. Status 2xx berarti sukses; selain itu dicoba ulang dengan
+// exponential backoff sampai maxAttempts, lalu failed.
+func (s *webhookService) deliver(delivery *models.WebhookDelivery) models.WebhookAttempt {
+	if delivery.URL == "" {
+		return failedAttempt("subscription is inactive", nil, nil)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return failedAttempt(err.Error(), nil, nil)
+	}
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderUserAgent, "alumni-management-system-webhook/1.0")
+	req.Header.Set(headerWebhookEvent, delivery.Event)
+	req.Header.Set(headerWebhookID, delivery.EventID)
+	req.Header.Set(headerWebhookDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(headerWebhookTimestamp, timestamp)
+	req.Header.Set(headerWebhookSignature, webhookSignature(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return s.retryAttempt(delivery, err.Error(), nil, nil)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseLength))
+	response := string(bytes.ToValidUTF8(body, nil))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return models.WebhookAttempt{Status: models.WebhookDeliverySuccess, StatusCode: &resp.StatusCode, Response: &response}
+	}
+	return s.retryAttempt(delivery, fmt.Sprintf("receiver responded %s", resp.Status), &resp.StatusCode, &response)
+}
+
+func (s *webhookService) retryAttempt(delivery *models.WebhookDelivery, message string, statusCode *int, response *string) models.WebhookAttempt {
+	attempts := delivery.Attempts + 1
+	if attempts >= s.maxAttempts {
+		return failedAttempt(message, statusCode, response)
+	}
+	next := time.Now().Add(webhookBackoff(attempts))
+	return models.WebhookAttempt{Status: models.WebhookDeliveryPending, NextAttemptAt: &next, StatusCode: statusCode, Error: &message, Response: response}
+}
+
+func failedAttempt(message string, statusCode *int, response *string) models.WebhookAttempt {
+	return models.WebhookAttempt{Status: models.WebhookDeliveryFailed, StatusCode: statusCode, Error: &message, Response: response}
+}
+
+// webhookBackoff - jeda sebelum percobaan berikutnya setelah attempts kali gagal
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		backoff = webhookMaxBackoff
+	}
+	return backoff
+}
+
+// webhookSignature - penerima menghitung ulang HMAC dari header timestamp dan body mentah, membandingkannya
+// dengan constant-time compare, dan menolak timestamp yang terlalu lama (replay)
+func webhookSignature(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// uniqueStrings - hapus duplikat dengan urutan tetap
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"alumni-management-system/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestWebhookService(t *testing.T) *webhookService {
+	t.Helper()
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+	t.Setenv("WEBHOOK_TIMEOUT_SECONDS", "")
+	return NewWebhookService(nil, nopAuditService{}).(*webhookService)
+}
+
+func testDelivery(url string, attempts int) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:       42,
+		Event:    models.WebhookEventAlumniCreated,
+		EventID:  "evt_test",
+		Payload:  []byte(`{"id":"evt_test","event":"alumni.created","data":{"id":1}}`),
+		Attempts: attempts,
+		URL:      url,
+		Secret:   "whsec_test",
+	}
+}
+
+func TestWebhookDeliverSignsPayload(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	s := newTestWebhookService(t)
+	delivery := testDelivery(receiver.URL, 0)
+	attempt := s.deliver(delivery)
+
+	if attempt.Status != models.WebhookDeliverySuccess || attempt.StatusCode == nil || *attempt.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected attempt: %+v", attempt)
+	}
+	if attempt.NextAttemptAt != nil || attempt.Error != nil {
+		t.Errorf("successful attempt must not schedule a retry: %+v", attempt)
+	}
+
+	r := <-received
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("method %s, content type %q", r.Method, r.Header.Get("Content-Type"))
+	}
+	for header, want := range map[string]string{
+		headerWebhookEvent:    delivery.Event,
+		headerWebhookID:       delivery.EventID,
+		headerWebhookDelivery: strconv.FormatInt(delivery.ID, 10),
+	} {
+		if got := r.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body = %s", body)
+	}
+
+	// Penerima memverifikasi dengan HMAC-SHA256(secret, timestamp + "." + body)
+	timestamp := r.Header.Get(headerWebhookTimestamp)
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(unix, 0)) > time.Minute {
+		t.Fatalf("invalid timestamp %q", timestamp)
+	}
+	mac := hmac.New(sha256.New, []byte(delivery.Secret))
+	mac.Write([]byte(timestamp + "." + string(body)))
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); r.Header.Get(headerWebhookSignature) != want {
+		t.Errorf("signature = %s, want %s", r.Header.Get(headerWebhookSignature), want)
+	}
+}
+
+func TestWebhookDeliverRetriesWithBackoff(t *testing.T) {
+	s := newTestWebhookService(t)
+
+	t.Run("server error", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("maintenance"))
+		}))
+		defer receiver.Close()
+
+		before := time.Now()
+		attempt := s.deliver(testDelivery(receiver.URL, 1))
+		if attempt.Status != models.WebhookDeliveryPending {
+			t.Fatalf("status = %s, want pending", attempt.Status)
+		}
+		if attempt.StatusCode == nil || *attempt.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("status code = %v", attempt.StatusCode)
+		}
+		if attempt.Response == nil || *attempt.Response != "maintenance" {
+			t.Errorf("response = %v", attempt.Response)
+		}
+		// Percobaan ke-2 gagal: jeda berikutnya webhookBackoff(2)
+		assertNextAttempt(t, attempt, before, webhookBackoff(2))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer receiver.Close()
+		defer close(release)
+
+		s.client.Timeout = 100 * time.Millisecond
+		defer func() { s.client.Timeout = defaultWebhookTimeout }()
+
+		before := time.Now()
+		attempt := s.deliver(testDelivery(receiver.URL, 0))
+		if attempt.Status != models.WebhookDeliveryPending || attempt.StatusCode != nil || attempt.Error == nil {
+			t.Fatalf("unexpected attempt: %+v", attempt)
+		}
+		assertNextAttempt(t, attempt, before, webhookBackoff(1))
+	})
+}
+
+func assertNextAttempt(t *testing.T, attempt models.WebhookAttempt, before time.Time, backoff time.Duration) {
+	t.Helper()
+	if attempt.NextAttemptAt == nil {
+		t.Fatal("missing next_attempt_at")
+	}
+	if attempt.NextAttemptAt.Before(before.Add(backoff)) || attempt.NextAttemptAt.After(time.Now().Add(backoff)) {
+		t.Errorf("next_attempt_at = %s, want about %s from now", attempt.NextAttemptAt, backoff)
+	}
+}
+
+func TestWebhookDeliverFinalAttemptFails(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	s := newTestWebhookService(t)
+	attempt := s.deliver(testDelivery(receiver.URL, s.maxAttempts-1))
+	if attempt.Status != models.WebhookDeliveryFailed || attempt.NextAttemptAt != nil {
+		t.Errorf("unexpected attempt: %+v", attempt)
+	}
+}
+
+func TestWebhookDeliverDoesNotFollowRedirect(t *testing.T) {
+	var followed int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&followed, 1)
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	s := newTestWebhookService(t)
+	attempt := s.deliver(testDelivery(receiver.URL, 0))
+	if atomic.LoadInt32(&followed) != 0 {
+		t.Error("redirect was followed")
+	}
+	if attempt.Status != models.WebhookDeliveryPending || attempt.StatusCode == nil || *attempt.StatusCode != http.StatusTemporaryRedirect {
+		t.Errorf("unexpected attempt: %+v", attempt)
+	}
+}
+
+func TestWebhookDeliverInactiveSubscription(t *testing.T) {
+	s := newTestWebhookService(t)
+	if attempt := s.deliver(testDelivery("", 0)); attempt.Status != models.WebhookDeliveryFailed {
+		t.Errorf("status = %s, want failed", attempt.Status)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, webhookBaseBackoff},
+		{2, 2 * webhookBaseBackoff},
+		{3, 4 * webhookBaseBackoff},
+		{10, 512 * webhookBaseBackoff},
+		{11, webhookMaxBackoff},
+		{100, webhookMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := webhookBackoff(tt.attempts); got != tt.want {
+			t.Errorf("webhookBackoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	companyNonAlnumPattern  = regexp.MustCompile(`[^a-z0-9]+`)
+	companyLegalFormPattern = regexp.MustCompile(`\b(pt|cv|ud|tbk|persero|perseroan|terbatas|inc|ltd|corp|corporation|co)\b`)
+	companySpacePattern     = regexp.MustCompile(`\s+`)
+)
+
+// NormalizeCompanyName - kunci pencocokan nama perusahaan: "PT. Telkom Indonesia (Persero) Tbk" -> "telkom indonesia".
+// Logika sama dengan fungsi normalize_company_name di migrasi 006.
+func NormalizeCompanyName(name string) string {
+	s := companyNonAlnumPattern.ReplaceAllString(strings.ToLower(name), " ")
+	s = companyLegalFormPattern.ReplaceAllString(s, " ")
+	return strings.TrimSpace(companySpacePattern.ReplaceAllString(s, " "))
+}
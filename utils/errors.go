@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Kode error yang stabil untuk dibaca client (message boleh berubah, code tidak)
+const (
+	ErrCodeBadRequest           = "BAD_REQUEST"
+	ErrCodeValidation           = "VALIDATION_FAILED"
+	ErrCodeUnauthorized         = "UNAUTHORIZED"
+	ErrCodeForbidden            = "FORBIDDEN"
+	ErrCodeNotFound             = "NOT_FOUND"
+	ErrCodeConflict             = "CONFLICT"
+	ErrCodePreconditionFailed   = "PRECONDITION_FAILED"
+	ErrCodePreconditionRequired = "PRECONDITION_REQUIRED"
+	ErrCodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	ErrCodeInternal             = "INTERNAL_ERROR"
+	ErrCodeUpstream             = "UPSTREAM_ERROR"
+)
+
+// AppError - error domain yang dipetakan ke response HTTP oleh middleware.ErrorHandler.
+// Message adalah format pesan berbahasa Indonesia (sekaligus kunci katalog i18n) dengan Args sebagai
+// parameternya. Err adalah penyebab internal (error database, dsb) dan hanya ditampilkan di mode debug.
+type AppError struct {
+	Status  int
+	Code    string
+	Message string
+	Args    []interface{}
+	Details map[string]interface{}
+	Err     error
+}
+
+func (e *AppError) Error() string {
+	if e.Err != nil {
+		return e.Text() + ": " + e.Err.Error()
+	}
+	return e.Text()
+}
+
+// Text - pesan dalam bahasa Indonesia dengan Args sudah diterapkan
+func (e *AppError) Text() string {
+	if len(e.Args) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf(e.Message, e.Args...)
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// WithCode - ganti kode umum (NOT_FOUND, CONFLICT, ...) dengan kode yang lebih spesifik
+func (e *AppError) WithCode(code string) *AppError {
+	copied := *e
+	copied.Code = code
+	return &copied
+}
+
+// WithError - lampirkan penyebab internal
+func (e *AppError) WithError(err error) *AppError {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// WithDetail - data tambahan yang aman untuk client, ikut dikirim di response
+func (e *AppError) WithDetail(key string, value interface{}) *AppError {
+	copied := *e
+	copied.Details = map[string]interface{}{}
+	for k, v := range e.Details {
+		copied.Details[k] = v
+	}
+	copied.Details[key] = value
+	return &copied
+}
+
+func newAppError(status int, code, message string, args []interface{}) *AppError {
+	return &AppError{Status: status, Code: code, Message: message, Args: args}
+}
+
+func BadRequest(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusBadRequest, ErrCodeBadRequest, message, args)
+}
+
+func Unauthorized(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusUnauthorized, ErrCodeUnauthorized, message, args)
+}
+
+func Forbidden(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusForbidden, ErrCodeForbidden, message, args)
+}
+
+func NotFound(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusNotFound, ErrCodeNotFound, message, args)
+}
+
+func Conflict(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusConflict, ErrCodeConflict, message, args)
+}
+
+// PreconditionFailed - If-Match tidak cocok dengan versi data saat ini (optimistic concurrency)
+func PreconditionFailed(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusPreconditionFailed, ErrCodePreconditionFailed, message, args)
+}
+
+// PreconditionRequired - request tulis tanpa header If-Match
+func PreconditionRequired(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusPreconditionRequired, ErrCodePreconditionRequired, message, args)
+}
+
+// PayloadTooLarge - body request melebihi batas ukuran route
+func PayloadTooLarge(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge, message, args)
+}
+
+// BadGateway - layanan eksternal (identity provider, dsb) gagal dihubungi
+func BadGateway(message string, args ...interface{}) *AppError {
+	return newAppError(http.StatusBadGateway, ErrCodeUpstream, message, args)
+}
+
+// Internal - kesalahan server; err tidak pernah dikirim ke client kecuali mode debug
+func Internal(message string, err error) *AppError {
+	return &AppError{Status: http.StatusInternalServerError, Code: ErrCodeInternal, Message: message, Err: err}
+}
@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"alumni-management-system/models"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIdP - identity provider tiruan: discovery, JWKS, dan token endpoint yang memeriksa PKCE
+type mockIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+type mockGrant struct {
+	challenge string
+	idToken   string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdP{t: t, key: key, codes: map[string]mockGrant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kid": "test-key", "kty": "RSA", "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		idp.mu.Lock()
+		grant, ok := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		idp.mu.Unlock()
+		if !ok || PKCEChallenge(r.PostForm.Get("code_verifier")) != grant.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "code or verifier mismatch"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "at", "token_type": "Bearer", "id_token": grant.idToken})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// authorize - simulasikan persetujuan user: code hanya bisa ditukar dengan verifier yang cocok dengan challenge
+func (idp *mockIdP) authorize(challenge, idToken string) string {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	code := "code-" + strconv.Itoa(len(idp.codes)+1)
+	idp.codes[code] = mockGrant{challenge: challenge, idToken: idToken}
+	return code
+}
+
+// sign - ID token RS256 dengan key IdP
+func (idp *mockIdP) sign(claims *models.OIDCIDTokenClaims) string {
+	return signIDToken(idp.t, idp.key, "test-key", claims)
+}
+
+// claims - ID token valid untuk clientID dan nonce
+func (idp *mockIdP) claims(clientID, nonce string) *models.OIDCIDTokenClaims {
+	now := time.Now()
+	return &models.OIDCIDTokenClaims{
+		Email:         "budi@example.com",
+		EmailVerified: true,
+		Nonce:         nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    idp.server.URL,
+			Subject:   "subject-1",
+			Audience:  jwt.ClaimStrings{clientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(5 * time.Minute)),
+		},
+	}
+}
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, kid string, claims *models.OIDCIDTokenClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func testOIDCConfig(idp *mockIdP) OIDCConfig {
+	return OIDCConfig{
+		Enabled:     true,
+		Issuer:      idp.server.URL,
+		ClientID:    "alumni-app",
+		RedirectURL: "http://localhost:3000/callback",
+		Scopes:      []string{"openid", "email"},
+	}
+}
+
+func TestDiscoverOIDCProvider(t *testing.T) {
+	idp := newMockIdP(t)
+
+	provider, err := DiscoverOIDCProvider(idp.server.URL + "/")
+	if err != nil {
+		t.Fatalf("discovery: %v", err)
+	}
+	if provider.TokenEndpoint != idp.server.URL+"/token" || provider.JWKSURI != idp.server.URL+"/jwks" {
+		t.Errorf("unexpected endpoints: %+v", provider)
+	}
+
+	// Dokumen discovery harus berasal dari issuer yang dikonfigurasi
+	other := newMockIdP(t)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.server.URL+r.URL.Path, http.StatusFound)
+	}))
+	defer proxy.Close()
+	if _, err := DiscoverOIDCProvider(proxy.URL); err == nil || !strings.Contains(err.Error(), "issuer mismatch") {
+		t.Errorf("expected issuer mismatch, got %v", err)
+	}
+}
+
+func TestAuthCodeURLUsesPKCE(t *testing.T) {
+	idp := newMockIdP(t)
+	provider, err := DiscoverOIDCProvider(idp.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testOIDCConfig(idp)
+
+	authURL, err := url.Parse(provider.AuthCodeURL(cfg, "state-1", "nonce-1", "verifier-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := authURL.Query()
+	for name, want := range map[string]string{
+		"response_type":         "code",
+		"client_id":             cfg.ClientID,
+		"redirect_uri":          cfg.RedirectURL,
+		"state":                 "state-1",
+		"nonce":                 "nonce-1",
+		"code_challenge":        PKCEChallenge("verifier-1"),
+		"code_challenge_method": "S256",
+	} {
+		if got := query.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if query.Get("code_verifier") != "" {
+		t.Error("code_verifier must not be sent to the authorization endpoint")
+	}
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// Contoh dari RFC 7636 lampiran B
+	if got := PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("PKCEChallenge = %s", got)
+	}
+}
+
+func TestExchangeCodeSendsVerifier(t *testing.T) {
+	idp := newMockIdP(t)
+	provider, err := DiscoverOIDCProvider(idp.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testOIDCConfig(idp)
+	idToken := idp.sign(idp.claims(cfg.ClientID, "nonce-1"))
+
+	code := idp.authorize(PKCEChallenge("right-verifier"), idToken)
+	if _, err := provider.ExchangeCode(cfg, code, "wrong-verifier"); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Errorf("expected invalid_grant for wrong verifier, got %v", err)
+	}
+
+	code = idp.authorize(PKCEChallenge("right-verifier"), idToken)
+	got, err := provider.ExchangeCode(cfg, code, "right-verifier")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if got != idToken {
+		t.Error("exchange returned a different id_token")
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	idp := newMockIdP(t)
+	provider, err := DiscoverOIDCProvider(idp.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testOIDCConfig(idp)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr string
+	}{
+		{"valid", func() string {
+			return idp.sign(idp.claims(cfg.ClientID, "nonce-1"))
+		}, ""},
+		{"nonce mismatch", func() string {
+			return idp.sign(idp.claims(cfg.ClientID, "other-nonce"))
+		}, "nonce mismatch"},
+		{"expired", func() string {
+			claims := idp.claims(cfg.ClientID, "nonce-1")
+			claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+			return idp.sign(claims)
+		}, "expired"},
+		{"wrong audience", func() string {
+			return idp.sign(idp.claims("another-client", "nonce-1"))
+		}, "aud"},
+		{"wrong issuer", func() string {
+			claims := idp.claims(cfg.ClientID, "nonce-1")
+			claims.Issuer = "https://evil.example.com"
+			return idp.sign(claims)
+		}, "iss"},
+		{"no subject", func() string {
+			claims := idp.claims(cfg.ClientID, "nonce-1")
+			claims.Subject = ""
+			return idp.sign(claims)
+		}, "no subject"},
+		{"unknown signing key", func() string {
+			return signIDToken(t, otherKey, "test-key", idp.claims(cfg.ClientID, "nonce-1"))
+		}, "signature"},
+		{"unsigned", func() string {
+			raw, _ := jwt.NewWithClaims(jwt.SigningMethodNone, idp.claims(cfg.ClientID, "nonce-1")).SignedString(jwt.UnsafeAllowNoneSignatureType)
+			return raw
+		}, "signing method"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := provider.VerifyIDToken(cfg, tt.token(), "nonce-1")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if claims.Subject != "subject-1" || claims.Email != "budi@example.com" {
+					t.Errorf("unexpected claims: %+v", claims)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultGajiCurrency - mata uang yang dipakai jika tidak disebutkan
+const DefaultGajiCurrency = "IDR"
+
+var (
+	gajiNumberPattern   = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+	gajiThousandPattern = regexp.MustCompile(`^\d{1,3}(?:[.,]\d{3})+$`)
+	gajiCurrencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	gajiKiloPattern     = regexp.MustCompile(`\d\s*k\b`)
+	gajiMinOnlyPattern  = regexp.MustCompile(`(>|lebih dari|di ?atas|minimal|\bmin\b|mulai)`)
+	gajiMaxOnlyPattern  = regexp.MustCompile(`(<|kurang dari|di ?bawah|maksimal|\bmax\b|\bmaks\b|sampai|hingga)`)
+
+	// Satuan harus berdiri sebagai kata (boleh menempel ke angka: "15jt"), agar "rb" di "perbulan" tidak ikut cocok
+	gajiJutaPattern = regexp.MustCompile(`(?:^|[^a-z])(?:juta|jt)\b`)
+	gajiRibuPattern = regexp.MustCompile(`(?:^|[^a-z])(?:ribu|rb)\b`)
+)
+
+// Kata kunci mata uang selain IDR yang dikenali dari teks bebas
+var gajiCurrencyKeywords = []struct {
+	keyword  string
+	currency string
+}{
+	{"usd", "USD"},
+	{"$", "USD"},
+	{"sgd", "SGD"},
+	{"myr", "MYR"},
+	{"eur", "EUR"},
+	{"€", "EUR"},
+	{"jpy", "JPY"},
+	{"aud", "AUD"},
+}
+
+// ParseGajiRange - ubah teks gaji bebas ("5-10 juta", "Rp 7.500.000", "> 15jt", "USD 2k-3k")
+// menjadi nilai minimum, maksimum, dan mata uang. Angka IDR tanpa satuan di bawah 1000
+// dianggap dalam juta. Logika ini sama dengan fungsi parse_gaji_range di migrasi 005.
+func ParseGajiRange(text string) (*int64, *int64, string, error) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	if s == "" {
+		return nil, nil, "", fmt.Errorf("gaji_range kosong")
+	}
+
+	currency := DefaultGajiCurrency
+	for _, kw := range gajiCurrencyKeywords {
+		if strings.Contains(s, kw.keyword) {
+			currency = kw.currency
+			break
+		}
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.Contains(s, "miliar") || strings.Contains(s, "milyar"):
+		multiplier = 1e9
+	case gajiJutaPattern.MatchString(s):
+		multiplier = 1e6
+	case gajiRibuPattern.MatchString(s) || gajiKiloPattern.MatchString(s):
+		multiplier = 1e3
+	}
+
+	var values []float64
+	for _, raw := range gajiNumberPattern.FindAllString(s, -1) {
+		value, err := parseGajiNumber(raw)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("angka gaji tidak dikenali: %s", raw)
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 || len(values) > 2 {
+		return nil, nil, "", fmt.Errorf("format gaji_range tidak dikenali: %s", text)
+	}
+
+	largest := values[0]
+	for _, value := range values {
+		largest = math.Max(largest, value)
+	}
+	if multiplier == 1 && currency == DefaultGajiCurrency && largest < 1000 {
+		multiplier = 1e6
+	}
+
+	amounts := make([]int64, len(values))
+	for i, value := range values {
+		amounts[i] = int64(math.Round(value * multiplier))
+	}
+
+	if len(amounts) == 2 {
+		low, high := amounts[0], amounts[1]
+		if low > high {
+			low, high = high, low
+		}
+		return &low, &high, currency, nil
+	}
+
+	amount := amounts[0]
+	switch {
+	case gajiMinOnlyPattern.MatchString(s):
+		return &amount, nil, currency, nil
+	case gajiMaxOnlyPattern.MatchString(s):
+		return nil, &amount, currency, nil
+	}
+	high := amount
+	return &amount, &high, currency, nil
+}
+
+// parseGajiNumber - "7.500.000" / "5,000,000" dibaca sebagai ribuan, "7,5" / "7.5" sebagai desimal
+func parseGajiNumber(raw string) (float64, error) {
+	if gajiThousandPattern.MatchString(raw) {
+		raw = strings.NewReplacer(".", "", ",", "").Replace(raw)
+	} else {
+		raw = strings.ReplaceAll(raw, ",", ".")
+	}
+	return strconv.ParseFloat(raw, 64)
+}
+
+// FormatGajiRange - label gaji yang disimpan di kolom gaji_range (untuk tampilan & client lama)
+func FormatGajiRange(min, max *int64, currency string) *string {
+	var label string
+	switch {
+	case min != nil && max != nil && *min == *max:
+		label = fmt.Sprintf("%s %s", currency, formatGajiAmount(*min))
+	case min != nil && max != nil:
+		label = fmt.Sprintf("%s %s - %s", currency, formatGajiAmount(*min), formatGajiAmount(*max))
+	case min != nil:
+		label = fmt.Sprintf("> %s %s", currency, formatGajiAmount(*min))
+	case max != nil:
+		label = fmt.Sprintf("< %s %s", currency, formatGajiAmount(*max))
+	default:
+		return nil
+	}
+	return &label
+}
+
+// formatGajiAmount - 7500000 -> "7.500.000"
+func formatGajiAmount(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
+
+// IsValidGajiCurrency - kode mata uang ISO 4217 (3 huruf kapital)
+func IsValidGajiCurrency(currency string) bool {
+	return gajiCurrencyPattern.MatchString(currency)
+}
@@ -0,0 +1,52 @@
+package utils
+
+import "testing"
+
+func TestParseGajiRange(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max int64 // 0 = tidak ada batas
+		currency string
+	}{
+		{"Rp 5.000.000 perbulan", 5000000, 5000000, "IDR"},
+		{"8000000 perbulan", 8000000, 8000000, "IDR"},
+		{"Rp 7.500.000", 7500000, 7500000, "IDR"},
+		{"USD 2k-3k", 2000, 3000, "USD"},
+		{"> 15jt", 15000000, 0, "IDR"},
+		{"7,5 juta", 7500000, 7500000, "IDR"},
+		{"5-10 juta", 5000000, 10000000, "IDR"},
+		{"500rb", 500000, 500000, "IDR"},
+		{"maksimal 12 jt per bulan", 0, 12000000, "IDR"},
+	}
+	for _, tt := range tests {
+		min, max, currency, err := ParseGajiRange(tt.text)
+		if err != nil {
+			t.Errorf("ParseGajiRange(%q): %v", tt.text, err)
+			continue
+		}
+		if got := gajiBound(min); got != tt.min {
+			t.Errorf("ParseGajiRange(%q) min = %d, want %d", tt.text, got, tt.min)
+		}
+		if got := gajiBound(max); got != tt.max {
+			t.Errorf("ParseGajiRange(%q) max = %d, want %d", tt.text, got, tt.max)
+		}
+		if currency != tt.currency {
+			t.Errorf("ParseGajiRange(%q) currency = %s, want %s", tt.text, currency, tt.currency)
+		}
+	}
+}
+
+func TestParseGajiRangeRejectsUnparseable(t *testing.T) {
+	for _, text := range []string{"", "nego", "1 2 3 juta"} {
+		if _, _, _, err := ParseGajiRange(text); err == nil {
+			t.Errorf("ParseGajiRange(%q): expected error", text)
+		}
+	}
+}
+
+func gajiBound(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrStorageNotFound - key tidak ada di storage
+var ErrStorageNotFound = errors.New("storage object not found")
+
+// Storage - tempat isi file attachment disimpan. Key dibuat server (huruf kecil, angka, "/", "-", "."),
+// tidak pernah dari nama file client.
+type Storage interface {
+	Put(key string, data []byte, contentType string) error
+	Open(key string) (io.ReadCloser, int64, error) // ErrStorageNotFound jika key tidak ada
+	Delete(key string) error                       // key yang tidak ada bukan error
+}
+
+// UploadConfig - batas ukuran upload dan backend storage dari environment
+type UploadConfig struct {
+	Driver          string // local (default) atau s3
+	LocalPath       string
+	MaxPhotoSize    int64
+	MaxDocumentSize int64
+	S3              S3Config
+}
+
+// S3Config - bucket S3 atau layanan kompatibel (MinIO, dsb.), diakses dengan path-style URL
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// LoadUploadConfig - baca konfigurasi upload dari environment variable
+func LoadUploadConfig() UploadConfig {
+	cfg := UploadConfig{
+		Driver:          strings.ToLower(os.Getenv("STORAGE_DRIVER")),
+		LocalPath:       os.Getenv("STORAGE_LOCAL_PATH"),
+		MaxPhotoSize:    megabytesEnv("UPLOAD_MAX_PHOTO_MB", 2),
+		MaxDocumentSize: megabytesEnv("UPLOAD_MAX_DOCUMENT_MB", 10),
+		S3: S3Config{
+			Endpoint:        strings.TrimRight(os.Getenv("S3_ENDPOINT"), "/"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		},
+	}
+	if cfg.Driver == "" {
+		cfg.Driver = "local"
+	}
+	if cfg.LocalPath == "" {
+		cfg.LocalPath = "uploads"
+	}
+	if cfg.S3.Region == "" {
+		cfg.S3.Region = "us-east-1"
+	}
+	return cfg
+}
+
+// BodyLimit - batas body request Fiber: upload terbesar ditambah ruang untuk header multipart,
+// minimal default Fiber (4 MB)
+func (cfg UploadConfig) BodyLimit() int {
+	limit := cfg.MaxDocumentSize
+	if cfg.MaxPhotoSize > limit {
+		limit = cfg.MaxPhotoSize
+	}
+	limit += 1 << 20
+	if limit < 4<<20 {
+		limit = 4 << 20
+	}
+	return int(limit)
+}
+
+func megabytesEnv(name string, fallback float64) int64 {
+	size := fallback
+	if value, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil && value > 0 {
+		size = value
+	}
+	return int64(size * (1 << 20))
+}
+
+// NewStorage - backend storage sesuai cfg.Driver
+func NewStorage(cfg UploadConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "local":
+		if err := os.MkdirAll(cfg.LocalPath, 0o755); err != nil {
+			return nil, fmt.Errorf("storage: create %s: %w", cfg.LocalPath, err)
+		}
+		return &localStorage{root: cfg.LocalPath}, nil
+	case "s3":
+		if cfg.S3.Endpoint == "" || cfg.S3.Bucket == "" || cfg.S3.AccessKeyID == "" || cfg.S3.SecretAccessKey == "" {
+			return nil, errors.New("storage: S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required for STORAGE_DRIVER=s3")
+		}
+		return newS3Storage(cfg.S3), nil
+	}
+	return nil, fmt.Errorf("storage: unknown STORAGE_DRIVER %q", cfg.Driver)
+}
+
+// localStorage - file disimpan di bawah root dengan struktur direktori mengikuti key
+type localStorage struct {
+	root string
+}
+
+// path - lokasi file untuk key; key yang keluar dari root ditolak
+func (s *localStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean[1:])), nil
+}
+
+// Put - tulis ke file sementara lalu rename, sehingga pembaca tidak pernah melihat file setengah jadi
+func (s *localStorage) Put(key string, data []byte, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *localStorage) Open(key string) (io.ReadCloser, int64, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, ErrStorageNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+func (s *localStorage) Delete(key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+// maxImagePixels - gambar di atas ini ditolak sebelum di-decode (mencegah decompression bomb)
+const maxImagePixels = 40_000_000
+
+// ErrImageTooLarge - dimensi gambar melebihi maxImagePixels
+var ErrImageTooLarge = errors.New("image dimensions too large")
+
+// MakeThumbnail - perkecil gambar JPEG/PNG/GIF sehingga sisi terpanjang maksimal maxSize piksel (gambar yang
+// lebih kecil tidak diperbesar). Hasil selalu JPEG; area transparan menjadi putih.
+func MakeThumbnail(data []byte, maxSize int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := config.Width, config.Height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			width, height = maxSize, max(1, height*maxSize/width)
+		} else {
+			width, height = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resizeImage(src, width, height), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeImage - area averaging: setiap piksel tujuan adalah rata-rata piksel sumber yang tercakup
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r, g, b, a, n = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			// RGBA() premultiplied: tambahkan putih sebesar bagian yang transparan
+			white := 0xffff*n - a
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset+0] = uint8((r + white) / n >> 8)
+			dst.Pix[offset+1] = uint8((g + white) / n >> 8)
+			dst.Pix[offset+2] = uint8((b + white) / n >> 8)
+			dst.Pix[offset+3] = 0xff
+		}
+	}
+	return dst
+}
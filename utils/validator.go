@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultNIMPattern - format NIM bawaan (8-15 digit), bisa diganti lewat env NIM_PATTERN
+const DefaultNIMPattern = `^[0-9]{8,15}$`
+
+// FieldError - satu kesalahan validasi pada field request (nama field mengikuti tag json).
+// Message adalah format pesan (kunci katalog i18n) dengan Args sebagai parameternya.
+type FieldError struct {
+	Field   string        `json:"field"`
+	Rule    string        `json:"rule"`
+	Message string        `json:"message"`
+	Args    []interface{} `json:"-"`
+}
+
+// Text - pesan dalam bahasa Indonesia dengan Args sudah diterapkan
+func (fe FieldError) Text() string {
+	if len(fe.Args) == 0 {
+		return fe.Message
+	}
+	return fmt.Sprintf(fe.Message, fe.Args...)
+}
+
+// ValidationError - kumpulan FieldError, dikirim ke client sebagai 422 dengan kode VALIDATION_FAILED.
+// Message opsional, default "Validasi request gagal".
+type ValidationError struct {
+	Message string
+	Errors  []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		messages = append(messages, fe.Field+": "+fe.Text())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// NewFieldError - FieldError dari error biasa; pesan AppError dipertahankan sebagai format + Args
+func NewFieldError(field, rule string, err error) FieldError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return FieldError{Field: field, Rule: rule, Message: appErr.Message, Args: appErr.Args}
+	}
+	return FieldError{Field: field, Rule: rule, Message: err.Error()}
+}
+
+// NewValidationError - ValidationError untuk aturan domain yang tidak bisa ditulis sebagai tag
+func NewValidationError(errs ...FieldError) *ValidationError {
+	return &ValidationError{Errors: errs}
+}
+
+var (
+	validate     *validator.Validate
+	validateOnce sync.Once
+)
+
+// Validator - instance validator bersama dengan tag nama json dan aturan domain (nim)
+func Validator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return toSnakeCase(field.Name)
+			}
+			return name
+		})
+
+		pattern := os.Getenv("NIM_PATTERN")
+		if pattern == "" {
+			pattern = DefaultNIMPattern
+		}
+		nimPattern := regexp.MustCompile(pattern)
+		validate.RegisterValidation("nim", func(fl validator.FieldLevel) bool {
+			return nimPattern.MatchString(fl.Field().String())
+		})
+	})
+	return validate
+}
+
+// ValidateStruct - jalankan tag validate pada request. Mengembalikan *ValidationError jika ada field yang tidak valid.
+func ValidateStruct(s interface{}) error {
+	err := Validator().Struct(s)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	result := &ValidationError{}
+	for _, fe := range fieldErrs {
+		message, args := validationMessage(fe)
+		result.Errors = append(result.Errors, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: message,
+			Args:    args,
+		})
+	}
+	return result
+}
+
+// fieldPath - namespace tanpa nama struct terluar: "CreateSurveyRequest.questions[0].kode" -> "questions[0].kode"
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// validationMessage - format pesan (kunci katalog i18n) dan argumennya untuk satu tag yang gagal
+func validationMessage(fe validator.FieldError) (string, []interface{}) {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return "%s harus diisi", []interface{}{field}
+	case "email":
+		return "%s harus berupa alamat email yang valid", []interface{}{field}
+	case "oneof":
+		return "%s harus salah satu dari: %s", []interface{}{field, strings.Join(strings.Fields(fe.Param()), ", ")}
+	case "nim":
+		return "%s tidak sesuai format NIM", []interface{}{field}
+	case "min", "max", "len":
+		var unit string
+		switch fe.Kind() {
+		case reflect.String:
+			unit = " karakter"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			unit = " item"
+		}
+		format := map[string]string{"min": "%s minimal %s", "max": "%s maksimal %s", "len": "%s harus tepat %s"}[fe.Tag()]
+		return format + unit, []interface{}{field, fe.Param()}
+	case "gt":
+		return "%s harus lebih besar dari %s", []interface{}{field, fe.Param()}
+	case "gte":
+		return "%s tidak boleh lebih kecil dari %s", []interface{}{field, fe.Param()}
+	case "gtfield":
+		return "%s harus lebih besar dari %s", []interface{}{field, toSnakeCase(fe.Param())}
+	case "gtefield":
+		return "%s tidak boleh lebih kecil dari %s", []interface{}{field, toSnakeCase(fe.Param())}
+	}
+	return "%s tidak valid", []interface{}{field}
+}
+
+// toSnakeCase - nama field Go ke gaya json: "TahunLulus" -> "tahun_lulus", "AlumniID" -> "alumni_id"
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}